@@ -0,0 +1,75 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpenSearchRoleSpec defines role of OpenSearch security plugin
+type OpenSearchRoleSpec struct {
+	// OpenSearchServiceRef - OpenSearchService in the same namespace the role is created in.
+	OpenSearchServiceRef corev1.LocalObjectReference `json:"opensearchServiceRef"`
+	// RoleName - Name of the role in OpenSearch, name of the custom resource is used if it is not specified.
+	RoleName           string             `json:"roleName,omitempty"`
+	Description        string             `json:"description,omitempty"`
+	ClusterPermissions []string           `json:"clusterPermissions,omitempty"`
+	IndexPermissions   []IndexPermission  `json:"indexPermissions,omitempty"`
+	TenantPermissions  []TenantPermission `json:"tenantPermissions,omitempty"`
+}
+
+type IndexPermission struct {
+	IndexPatterns []string `json:"indexPatterns"`
+	// DLS - Document level security query.
+	DLS string `json:"dls,omitempty"`
+	// FLS - Field level security rules.
+	FLS            []string `json:"fls,omitempty"`
+	MaskedFields   []string `json:"maskedFields,omitempty"`
+	AllowedActions []string `json:"allowedActions,omitempty"`
+}
+
+type TenantPermission struct {
+	TenantPatterns []string `json:"tenantPatterns"`
+	AllowedActions []string `json:"allowedActions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Service",type=string,JSONPath=`.spec.opensearchServiceRef.name`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// OpenSearchRole is the Schema for the opensearchroles API
+type OpenSearchRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenSearchRoleSpec   `json:"spec,omitempty"`
+	Status SecurityObjectStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OpenSearchRoleList contains a list of OpenSearchRole
+type OpenSearchRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenSearchRole `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenSearchRole{}, &OpenSearchRoleList{})
+}
@@ -0,0 +1,61 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpenSearchRoleMappingSpec defines mapping of users, backend roles and hosts to role of OpenSearch security plugin
+type OpenSearchRoleMappingSpec struct {
+	// OpenSearchServiceRef - OpenSearchService in the same namespace the role mapping is created in.
+	OpenSearchServiceRef corev1.LocalObjectReference `json:"opensearchServiceRef"`
+	// RoleName - Name of the mapped role in OpenSearch, name of the custom resource is used if it is not specified.
+	RoleName     string   `json:"roleName,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	Users        []string `json:"users,omitempty"`
+	BackendRoles []string `json:"backendRoles,omitempty"`
+	Hosts        []string `json:"hosts,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Service",type=string,JSONPath=`.spec.opensearchServiceRef.name`
+//+kubebuilder:printcolumn:name="Role",type=string,JSONPath=`.spec.roleName`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// OpenSearchRoleMapping is the Schema for the opensearchrolemappings API
+type OpenSearchRoleMapping struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenSearchRoleMappingSpec `json:"spec,omitempty"`
+	Status SecurityObjectStatus      `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OpenSearchRoleMappingList contains a list of OpenSearchRoleMapping
+type OpenSearchRoleMappingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenSearchRoleMapping `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenSearchRoleMapping{}, &OpenSearchRoleMappingList{})
+}
@@ -15,6 +15,8 @@
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -31,16 +33,139 @@ type OpenSearch struct {
 	StatefulSetNames          string     `json:"statefulSetNames,omitempty"`
 	ReadinessTimeout          string     `json:"readinessTimeout,omitempty"`
 	DisabledRestCategories    []string   `json:"disabledRestCategories,omitempty"`
+	// ClusterSettings - Persistent cluster settings with flat names, for example "cluster.routing.allocation.disk.watermark.low".
+	// Settings removed from the list are reset to defaults, manual changes of the settings are reverted.
+	ClusterSettings map[string]apiextensionsv1.JSON `json:"clusterSettings,omitempty"`
+	// SecureSettings - Settings written to the keystore of OpenSearch nodes with values from secrets.
+	// Credentials of S3 snapshot repository are added to the keystore automatically.
+	SecureSettings []SecureSetting `json:"secureSettings,omitempty"`
+	// RollingUpdateOptions - Controls of the rolling update performed by the operator.
+	RollingUpdateOptions *RollingUpdateOptions `json:"rollingUpdateOptions,omitempty"`
+	// ScaleDown - Target replicas of OpenSearch stateful sets. Shards are moved from the nodes being removed
+	// before replicas of the stateful set are reduced.
+	ScaleDown []ScaleDownTarget `json:"scaleDown,omitempty"`
+	// TLSReload - Secrets with TLS certificates reloaded on OpenSearch nodes without restart when they are renewed.
+	TLSReload *TLSReload `json:"tlsReload,omitempty"`
+}
+
+// TLSReload defines secrets with transport and HTTP certificates of OpenSearch nodes. When the certificate
+// in the secret is changed, the operator reloads it on all nodes and restarts the nodes if the reload fails.
+type TLSReload struct {
+	TransportSecretName string `json:"transportSecretName,omitempty"`
+	HTTPSecretName      string `json:"httpSecretName,omitempty"`
+}
+
+// ScaleDownTarget defines the number of replicas the stateful set is safely scaled down to
+type ScaleDownTarget struct {
+	StatefulSetName string `json:"statefulSetName"`
+	// +kubebuilder:validation:Minimum=1
+	Replicas int32 `json:"replicas"`
+}
+
+// RollingUpdateOptions defines how the operator performs the rolling update of OpenSearch pods
+type RollingUpdateOptions struct {
+	// Action - "pause" stops the rolling update before the next pod, "abort" stops it and does not start new
+	// rolling updates until the action is changed, "resume" or empty value continues the rolling update.
+	// Shard allocation is enabled when the rolling update is paused or aborted.
+	// +kubebuilder:validation:Enum=pause;resume;abort
+	Action string `json:"action,omitempty"`
+	// MaxUnavailable - Number of data nodes restarted at the same time, cluster manager eligible nodes are
+	// always restarted one by one. The default value is 1.
+	MaxUnavailable int `json:"maxUnavailable,omitempty"`
+	// Gates - Safety checks evaluated before each pod restart and after the restarted pod rejoins the cluster.
+	Gates *RollingUpdateGates `json:"gates,omitempty"`
+	// ZoneAwareness - Restart of all pods of one availability zone together when shard allocation awareness
+	// is configured in OpenSearch.
+	ZoneAwareness *ZoneAwareness `json:"zoneAwareness,omitempty"`
+}
+
+// ZoneAwareness defines how the operator discovers availability zones of OpenSearch pods.
+// The zone is read from the pod label and then from the label of Kubernetes node the pod is running on.
+type ZoneAwareness struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// TopologyKey - Label with the zone of the pod or node, the default value is "topology.kubernetes.io/zone".
+	TopologyKey string `json:"topologyKey,omitempty"`
+}
+
+// RollingUpdateGates defines safety checks of the rolling update. If a check does not pass within the timeout,
+// the rolling update is halted and shard allocation is enabled. The halted rolling update continues when
+// the checks performed before pod restart pass.
+type RollingUpdateGates struct {
+	// Disabled - Names of checks which are not evaluated: "shardsSettled", "pendingTasks", "diskUsage",
+	// "nodeJoined" and "recoveryComplete".
+	Disabled []string `json:"disabled,omitempty"`
+	// MaxPendingTasks - Maximum number of pending cluster tasks allowed before pod restart, the default value is 10.
+	MaxPendingTasks *int `json:"maxPendingTasks,omitempty"`
+	// Timeout - Time the operator waits for each check to pass, the default value is "10m".
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// SecureSetting defines the keystore entry of OpenSearch nodes with value from the secret
+type SecureSetting struct {
+	// Name of the secure setting, for example "s3.client.default.session_token"
+	Name         string                   `json:"name"`
+	SecretKeyRef corev1.SecretKeySelector `json:"secretKeyRef"`
 }
 
 type ExternalOpenSearch struct {
 	Config map[string]string `json:"config"`
 	Url    string            `json:"url"`
+	// CredentialsSecretName - Secret with "username" and "password" keys, "<name>-secret" is used if it is not specified.
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+	// CASecret - Secret key with bundle of CA certificates used to verify TLS certificate of OpenSearch.
+	CASecret *corev1.SecretKeySelector `json:"caSecret,omitempty"`
+	// FailoverUrls - Additional URLs of the same OpenSearch cluster used when Url is unavailable.
+	FailoverUrls []string `json:"failoverUrls,omitempty"`
+	// ClientCertificateSecretName - Secret with "tls.crt" and "tls.key" keys used to authenticate in OpenSearch by client certificate.
+	ClientCertificateSecretName string `json:"clientCertificateSecretName,omitempty"`
+	// ReadinessTimeout - Time the operator waits for OpenSearch to become ready, for example "800s".
+	ReadinessTimeout string     `json:"readinessTimeout,omitempty"`
+	Snapshots        *Snapshots `json:"snapshots,omitempty"`
 }
 
 type Snapshots struct {
-	RepositoryName string `json:"repositoryName"`
-	S3             *S3    `json:"s3,omitempty"`
+	RepositoryName string             `json:"repositoryName"`
+	S3             *S3                `json:"s3,omitempty"`
+	Schedules      []SnapshotSchedule `json:"schedules,omitempty"`
+	// Repositories - Additional snapshot repositories registered in OpenSearch besides the repository with RepositoryName.
+	Repositories []SnapshotRepository `json:"repositories,omitempty"`
+}
+
+// SnapshotRepository describes additional snapshot repository registered by the operator in OpenSearch
+type SnapshotRepository struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=fs;s3;gcs;azure
+	Type string `json:"type"`
+	// Settings - Repository settings passed to OpenSearch as is, for example "bucket", "base_path" or "client".
+	// Credentials must be provided as secure settings of the corresponding client.
+	Settings map[string]apiextensionsv1.JSON `json:"settings,omitempty"`
+	// Readonly - Whether the repository is registered in readonly mode and used only to restore snapshots.
+	Readonly bool `json:"readonly,omitempty"`
+}
+
+// SnapshotSchedule describes Snapshot Management policy maintained by the operator in OpenSearch
+type SnapshotSchedule struct {
+	Name string `json:"name"`
+	// Cron - Cron expression of snapshot creation schedule.
+	Cron string `json:"cron"`
+	// Timezone - Timezone of cron expressions, "UTC" is used if it is not specified.
+	Timezone string `json:"timezone,omitempty"`
+	// IndexPatterns - Patterns of indices included to snapshots, all indices are included if it is empty.
+	IndexPatterns      []string           `json:"indexPatterns,omitempty"`
+	IncludeGlobalState bool               `json:"includeGlobalState,omitempty"`
+	Retention          *SnapshotRetention `json:"retention,omitempty"`
+	// DeletionCron - Cron expression of outdated snapshots deletion schedule, creation schedule is used if it is not specified.
+	DeletionCron string `json:"deletionCron,omitempty"`
+}
+
+// SnapshotRetention describes conditions of snapshots deletion
+type SnapshotRetention struct {
+	// MaxCount - Maximum number of snapshots kept.
+	MaxCount int `json:"maxCount,omitempty"`
+	// MinCount - Minimum number of snapshots kept regardless of their age.
+	MinCount int `json:"minCount,omitempty"`
+	// MaxAge - Maximum age of kept snapshots in OpenSearch time units format, for example "7d".
+	MaxAge string `json:"maxAge,omitempty"`
 }
 
 type S3 struct {
@@ -52,6 +177,23 @@ type S3 struct {
 	Region          string `json:"region,omitempty"`
 	SecretName      string `json:"secretName,omitempty"`
 	GcsEnabled      bool   `json:"gcsEnabled,omitempty"`
+	// Protocol used to connect to S3 storage. It is taken from the scheme of url if it is not specified
+	// +kubebuilder:validation:Enum=http;https
+	Protocol string `json:"protocol,omitempty"`
+	// UsePodIdentity enables credential-less mode, in which access keys are not sent to OpenSearch and
+	// the identity of OpenSearch pods (IAM role, IRSA or instance profile) is used to access S3 storage
+	UsePodIdentity       bool `json:"usePodIdentity,omitempty"`
+	ServerSideEncryption bool `json:"serverSideEncryption,omitempty"`
+	// +kubebuilder:validation:Enum=standard;reduced_redundancy;standard_ia;onezone_ia;intelligent_tiering
+	StorageClass           string `json:"storageClass,omitempty"`
+	ChunkSize              string `json:"chunkSize,omitempty"`
+	MaxSnapshotBytesPerSec string `json:"maxSnapshotBytesPerSec,omitempty"`
+	MaxRestoreBytesPerSec  string `json:"maxRestoreBytesPerSec,omitempty"`
+}
+
+// KeystoreCredentialsEnabled returns true if access keys of S3 storage are written to the keystore of OpenSearch nodes
+func (in *S3) KeystoreCredentialsEnabled() bool {
+	return in.Enabled && !in.GcsEnabled && !in.UsePodIdentity
 }
 
 // Dashboards structure defines parameters necessary for interaction with Dashboards
@@ -102,6 +244,48 @@ type DisasterRecovery struct {
 	ReplicationWatcherInterval int    `json:"replicationWatcherInterval,omitempty"`
 }
 
+// IndexManagement defines index templates, component templates and ISM policies maintained by the operator
+type IndexManagement struct {
+	ComponentTemplates []IndexManagementResource `json:"componentTemplates,omitempty"`
+	IndexTemplates     []IndexManagementResource `json:"indexTemplates,omitempty"`
+	ISMPolicies        []ISMPolicy               `json:"ismPolicies,omitempty"`
+}
+
+// IndexManagementResource is a named OpenSearch resource defined by the body of its create request
+type IndexManagementResource struct {
+	Name string               `json:"name"`
+	Body apiextensionsv1.JSON `json:"body"`
+}
+
+// ISMPolicy defines Index State Management policy
+type ISMPolicy struct {
+	Name string `json:"name"`
+	// Body - Policy definition, for example {"policy": {"states": [...]}}.
+	Body apiextensionsv1.JSON `json:"body"`
+	// IndexPatterns - Patterns of existing indices the policy is attached to.
+	IndexPatterns []string `json:"indexPatterns,omitempty"`
+}
+
+// TeardownStep is a cleanup step performed in OpenSearch when OpenSearchService is deleted
+// +kubebuilder:validation:Enum=watchers;slowLogSettings;replication;snapshotRepository
+type TeardownStep string
+
+const (
+	TeardownWatchersStep           TeardownStep = "watchers"
+	TeardownSlowLogSettingsStep    TeardownStep = "slowLogSettings"
+	TeardownReplicationStep        TeardownStep = "replication"
+	TeardownSnapshotRepositoryStep TeardownStep = "snapshotRepository"
+)
+
+// Teardown defines cleanup performed before OpenSearchService is released on deletion
+type Teardown struct {
+	// Steps - Ordered list of cleanup steps. All steps are performed in the default order if it is empty.
+	Steps []TeardownStep `json:"steps,omitempty"`
+	// IgnoreErrors - Whether OpenSearchService is released even if some of the steps are failed.
+	// Errors are ignored if teardown is not specified, failed steps are not retried longer than 10 minutes since deletion.
+	IgnoreErrors bool `json:"ignoreErrors,omitempty"`
+}
+
 // OpenSearchServiceSpec defines the desired state of OpenSearchService
 type OpenSearchServiceSpec struct {
 	// Important: Run "make" to regenerate code after modifying this file
@@ -113,6 +297,8 @@ type OpenSearchServiceSpec struct {
 	ElasticsearchDbaasAdapter *ElasticsearchDbaasAdapter `json:"elasticsearchDbaasAdapter,omitempty"`
 	Curator                   *Curator                   `json:"curator,omitempty"`
 	DisasterRecovery          *DisasterRecovery          `json:"disasterRecovery,omitempty"`
+	Teardown                  *Teardown                  `json:"teardown,omitempty"`
+	IndexManagement           *IndexManagement           `json:"indexManagement,omitempty"`
 }
 
 type DisasterRecoveryStatus struct {
@@ -121,6 +307,25 @@ type DisasterRecoveryStatus struct {
 	Comment            string `json:"comment,omitempty"` // deprecated
 	Message            string `json:"message,omitempty"`
 	UsersRecoveryState string `json:"usersRecoveryState,omitempty"`
+	// ReplicationHealth - Health of replication in standby mode, can be "up", "degraded" or "down".
+	ReplicationHealth string `json:"replicationHealth,omitempty"`
+	// Phase - Step of the running switchover the operator waits for: "replicationRemoval", "replicationSetup",
+	// "replicationStart", "replicationCheck", "replicationVerification", "replicationStop" or "usersRecovery".
+	Phase string `json:"phase,omitempty"`
+	// ReplicationCheckpoints - Leader checkpoints of indices which are not replicated yet in "replicationVerification" phase.
+	ReplicationCheckpoints map[string]int `json:"replicationCheckpoints,omitempty"`
+	// StartTime - Time when the last switchover was started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// PhaseStartTime - Time when the current step of the running switchover was started.
+	PhaseStartTime *metav1.Time `json:"phaseStartTime,omitempty"`
+}
+
+// ReadinessStatus shows whether OpenSearch is ready to be configured by the operator
+type ReadinessStatus struct {
+	Ready bool `json:"ready"`
+	// WaitStartTime - Time when the operator started to wait for OpenSearch to become ready.
+	WaitStartTime *metav1.Time `json:"waitStartTime,omitempty"`
+	Message       string       `json:"message,omitempty"`
 }
 
 // OpenSearchServiceStatus defines the observed state of OpenSearchService
@@ -129,11 +334,188 @@ type OpenSearchServiceStatus struct {
 	DisasterRecoveryStatus DisasterRecoveryStatus `json:"disasterRecoveryStatus,omitempty"`
 	Conditions             []StatusCondition      `json:"conditions,omitempty"`
 	RollingUpdateStatus    RollingUpdateStatus    `json:"rollingUpdateStatus,omitempty"`
+	TeardownStatus         TeardownStatus         `json:"teardownStatus,omitempty"`
+
+	OpenSearchStatus                *ClusterStatus   `json:"opensearchStatus,omitempty"`
+	ExternalOpenSearchStatus        *ClusterStatus   `json:"externalOpenSearchStatus,omitempty"`
+	DashboardsStatus                *ComponentStatus `json:"dashboardsStatus,omitempty"`
+	MonitoringStatus                *ComponentStatus `json:"monitoringStatus,omitempty"`
+	DbaasAdapterStatus              *ComponentStatus `json:"dbaasAdapterStatus,omitempty"`
+	ElasticsearchDbaasAdapterStatus *ComponentStatus `json:"elasticsearchDbaasAdapterStatus,omitempty"`
+	CuratorStatus                   *ComponentStatus `json:"curatorStatus,omitempty"`
+
+	IndexManagementStatus IndexManagementStatus      `json:"indexManagementStatus,omitempty"`
+	SnapshotPolicies      []SnapshotPolicyStatus     `json:"snapshotPolicies,omitempty"`
+	SnapshotRepositories  []SnapshotRepositoryStatus `json:"snapshotRepositories,omitempty"`
+	ClusterSettingsStatus *ClusterSettingsStatus     `json:"clusterSettingsStatus,omitempty"`
+	SecureSettingsStatus  *SecureSettingsStatus      `json:"secureSettingsStatus,omitempty"`
+	ScaleDownStatuses     []ScaleDownStatus          `json:"scaleDownStatuses,omitempty"`
+	ReadinessStatus       *ReadinessStatus           `json:"readinessStatus,omitempty"`
+	TLSReloadStatuses     []TLSReloadStatus          `json:"tlsReloadStatuses,omitempty"`
+}
+
+// TLSReloadStatus shows progress of the reload of the renewed certificate on OpenSearch nodes
+type TLSReloadStatus struct {
+	// Layer - Can be "transport" or "http".
+	Layer string `json:"layer"`
+	// SerialNumber - Hexadecimal serial number of the certificate which is being reloaded.
+	SerialNumber string `json:"serialNumber"`
+	// Nodes - Names of nodes which have not loaded the certificate yet.
+	Nodes     []string     `json:"nodes,omitempty"`
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
+
+// ScaleDownStatus shows progress of the safe scale-down of the stateful set
+type ScaleDownStatus struct {
+	StatefulSetName string `json:"statefulSetName"`
+	TargetReplicas  int32  `json:"targetReplicas"`
+	// Status - Can be "draining", "blocked", "scaling" or "done".
+	Status string `json:"status"`
+	// ExcludedNodes - Names of nodes excluded from shard allocation by the operator before they are removed.
+	ExcludedNodes []string `json:"excludedNodes,omitempty"`
+	// RemainingShards - Number of shards which are not moved from the excluded nodes yet.
+	RemainingShards    int         `json:"remainingShards,omitempty"`
+	Message            string      `json:"message,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// IndexManagementStatus shows state of resources from indexManagement section in OpenSearch
+type IndexManagementStatus struct {
+	ComponentTemplates []ManagedResourceStatus `json:"componentTemplates,omitempty"`
+	IndexTemplates     []ManagedResourceStatus `json:"indexTemplates,omitempty"`
+	ISMPolicies        []ManagedResourceStatus `json:"ismPolicies,omitempty"`
+}
+
+// ClusterSettingsStatus shows state of persistent cluster settings from the spec in OpenSearch
+type ClusterSettingsStatus struct {
+	// Status - Can be "applied" or "failed".
+	Status string `json:"status"`
+	// AppliedKeys - Names of settings applied by the operator, they are reset when removed from the spec.
+	AppliedKeys []string `json:"appliedKeys,omitempty"`
+	// SpecHash - Hash of the applied settings from the spec.
+	SpecHash string `json:"specHash,omitempty"`
+	// DriftedKeys - Names of settings changed outside of the operator and reverted during the last check.
+	DriftedKeys        []string     `json:"driftedKeys,omitempty"`
+	LastDriftTime      *metav1.Time `json:"lastDriftTime,omitempty"`
+	Message            string       `json:"message,omitempty"`
+	LastTransitionTime metav1.Time  `json:"lastTransitionTime,omitempty"`
+}
+
+// SecureSettingsStatus shows state of secure settings in the keystore of OpenSearch nodes
+type SecureSettingsStatus struct {
+	// Status - Can be "applied" or "failed".
+	Status string `json:"status"`
+	// AppliedKeys - Names of secure settings written by the operator, they are removed from the keystore when removed from the spec.
+	AppliedKeys []string `json:"appliedKeys,omitempty"`
+	// LastReloadTime - Time of the last successful reload of secure settings on all nodes.
+	LastReloadTime     *metav1.Time `json:"lastReloadTime,omitempty"`
+	Message            string       `json:"message,omitempty"`
+	LastTransitionTime metav1.Time  `json:"lastTransitionTime,omitempty"`
+}
+
+// SnapshotPolicyStatus shows state of Snapshot Management policy and results of its executions
+type SnapshotPolicyStatus struct {
+	ManagedResourceStatus `json:",inline"`
+	LastSuccessTime       *metav1.Time `json:"lastSuccessTime,omitempty"`
+	LastFailureTime       *metav1.Time `json:"lastFailureTime,omitempty"`
+	LastFailureMessage    string       `json:"lastFailureMessage,omitempty"`
+	NextRunTime           *metav1.Time `json:"nextRunTime,omitempty"`
+}
+
+// ManagedResourceStatus shows state of OpenSearch resource maintained by the operator
+type ManagedResourceStatus struct {
+	Name string `json:"name"`
+	// Status - Can be "applied" or "failed".
+	Status string `json:"status"`
+	// Drifted - Whether the resource was changed in OpenSearch outside of the operator since the last apply.
+	Drifted bool   `json:"drifted,omitempty"`
+	Message string `json:"message,omitempty"`
+	// SpecHash - Hash of the resource definition from the spec that was applied.
+	SpecHash string `json:"specHash,omitempty"`
+	// ObservedHash - Hash of the resource returned by OpenSearch after the last apply.
+	ObservedHash       string      `json:"observedHash,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// ClusterStatus shows health of OpenSearch cluster
+type ClusterStatus struct {
+	// Health - Can be "green", "yellow", "red" or "unknown" if the cluster is not available.
+	Health              string      `json:"health"`
+	Version             string      `json:"version,omitempty"`
+	NumberOfNodes       int         `json:"numberOfNodes,omitempty"`
+	NumberOfDataNodes   int         `json:"numberOfDataNodes,omitempty"`
+	ActivePrimaryShards int         `json:"activePrimaryShards,omitempty"`
+	ActiveShards        int         `json:"activeShards,omitempty"`
+	RelocatingShards    int         `json:"relocatingShards,omitempty"`
+	InitializingShards  int         `json:"initializingShards,omitempty"`
+	UnassignedShards    int         `json:"unassignedShards,omitempty"`
+	Message             string      `json:"message,omitempty"`
+	LastUpdateTime      metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// SnapshotRepositoryStatus shows state of snapshot repository and results of its verification
+type SnapshotRepositoryStatus struct {
+	ManagedResourceStatus `json:",inline"`
+	Verified              bool `json:"verified"`
+	// VerifiedNodes - Names of nodes that confirmed access to the repository during the last verification.
+	VerifiedNodes             []string     `json:"verifiedNodes,omitempty"`
+	LastVerificationTime      *metav1.Time `json:"lastVerificationTime,omitempty"`
+	LastVerificationError     string       `json:"lastVerificationError,omitempty"`
+	LastVerificationErrorTime *metav1.Time `json:"lastVerificationErrorTime,omitempty"`
+}
+
+// ComponentStatus shows readiness of OpenSearch service component deployment
+type ComponentStatus struct {
+	Ready         bool   `json:"ready"`
+	Replicas      int32  `json:"replicas,omitempty"`
+	ReadyReplicas int32  `json:"readyReplicas,omitempty"`
+	Message       string `json:"message,omitempty"`
+}
+
+// TeardownStatus shows progress of cleanup performed on OpenSearchService deletion
+type TeardownStatus struct {
+	Steps []TeardownStepStatus `json:"steps,omitempty"`
+}
+
+type TeardownStepStatus struct {
+	Name TeardownStep `json:"name"`
+	// Status - Can be "done", "failed" or "skipped".
+	Status             string      `json:"status"`
+	Message            string      `json:"message,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
 type RollingUpdateStatus struct {
 	Status              string              `json:"status,omitempty"`
 	StatefulSetStatuses []StatefulSetStatus `json:"statefulSetStatuses,omitempty"`
+	// RestartOrder - Names of pods in the order they are restarted during the rolling update: data and ingest nodes first,
+	// then cluster manager eligible nodes and the elected cluster manager last.
+	RestartOrder []string `json:"restartOrder,omitempty"`
+	// CurrentPods - Names of pods which are being restarted.
+	CurrentPods []string `json:"currentPods,omitempty"`
+	// CompletedPods - Names of pods restarted during the current rolling update.
+	CompletedPods []string `json:"completedPods,omitempty"`
+	// Zones - Availability zones in the order they are restarted during the zone-aware rolling update.
+	Zones []ZoneRestartStatus `json:"zones,omitempty"`
+	// PauseReason - Reason why the rolling update is paused or aborted.
+	PauseReason    string       `json:"pauseReason,omitempty"`
+	StartTime      *metav1.Time `json:"startTime,omitempty"`
+	PauseTime      *metav1.Time `json:"pauseTime,omitempty"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Phase - Step the running rolling update waits for: "flush", "podsReady", "clusterManager", "gate" or "clusterHealth".
+	Phase string `json:"phase,omitempty"`
+	// Gate - Name of the rolling update gate the operator waits for in "gate" phase.
+	Gate string `json:"gate,omitempty"`
+	// PhaseStartTime - Time when the operator started to wait for the current phase.
+	PhaseStartTime *metav1.Time `json:"phaseStartTime,omitempty"`
+}
+
+// ZoneRestartStatus describes restart of pods of one availability zone
+type ZoneRestartStatus struct {
+	Name string   `json:"name"`
+	Pods []string `json:"pods,omitempty"`
+	// Status - "pending", "restarting" or "done".
+	Status string `json:"status,omitempty"`
 }
 
 type StatefulSetStatus struct {
@@ -159,6 +541,11 @@ type StatusCondition struct {
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Health",type=string,JSONPath=`.status.opensearchStatus.health`
+//+kubebuilder:printcolumn:name="Nodes",type=integer,JSONPath=`.status.opensearchStatus.numberOfNodes`
+//+kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.opensearchStatus.version`
+//+kubebuilder:printcolumn:name="DR Mode",type=string,JSONPath=`.status.disasterRecoveryStatus.mode`,priority=1
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 //+kubebuilder:storageversion
 
 // OpenSearchService is the Schema for the opensearchservices API
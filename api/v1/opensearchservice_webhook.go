@@ -0,0 +1,413 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/strings/slices"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// DefaultReadinessTimeout is the time the operator waits for OpenSearch to become ready
+	DefaultReadinessTimeout = 800 * time.Second
+	// DefaultReplicationWatcherInterval is the interval in seconds between replication checks
+	DefaultReplicationWatcherInterval = 30
+	// DefaultSnapshotRepositoryName is the name of snapshot repository registered in OpenSearch
+	DefaultSnapshotRepositoryName = "snapshots"
+
+	// S3AccessKeySetting is the secure setting with access key of S3 snapshot repository client
+	S3AccessKeySetting = "s3.client.default.access_key"
+	// S3SecretKeySetting is the secure setting with secret key of S3 snapshot repository client
+	S3SecretKeySetting = "s3.client.default.secret_key"
+
+	// DefaultRollingUpdateGateTimeout is the time the operator waits for each rolling update gate to pass
+	DefaultRollingUpdateGateTimeout = 10 * time.Minute
+	// DefaultRollingUpdateMaxPendingTasks is the maximum number of pending cluster tasks allowed before pod restart
+	DefaultRollingUpdateMaxPendingTasks = 10
+
+	RollingUpdateGateShardsSettled    = "shardsSettled"
+	RollingUpdateGatePendingTasks     = "pendingTasks"
+	RollingUpdateGateDiskUsage        = "diskUsage"
+	RollingUpdateGateNodeJoined       = "nodeJoined"
+	RollingUpdateGateRecoveryComplete = "recoveryComplete"
+
+	DisasterRecoveryActiveMode  = "active"
+	DisasterRecoveryStandbyMode = "standby"
+	DisasterRecoveryDisableMode = "disable"
+)
+
+var opensearchservicelog = logf.Log.WithName("opensearchservice-resource")
+
+// byteSizePattern matches OpenSearch byte size values such as 512kb or 1gb
+var byteSizePattern = regexp.MustCompile(`^(?i)[0-9]+(b|kb|mb|gb|tb|pb)?$`)
+
+// secureSettingNamePattern matches names of keystore entries, it also keeps them safe to use in shell commands
+var secureSettingNamePattern = regexp.MustCompile(`^[a-z0-9_-]+(\.[a-z0-9_-]+)*$`)
+
+// RollingUpdateGateNames are names of all rolling update gates
+var RollingUpdateGateNames = []string{RollingUpdateGateShardsSettled, RollingUpdateGatePendingTasks,
+	RollingUpdateGateDiskUsage, RollingUpdateGateNodeJoined, RollingUpdateGateRecoveryComplete}
+
+var disasterRecoveryModes = []string{DisasterRecoveryActiveMode, DisasterRecoveryStandbyMode, DisasterRecoveryDisableMode}
+
+// SetupWebhookWithManager registers defaulting and validating webhooks for OpenSearchService
+func (r *OpenSearchService) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-qubership-org-v1-opensearchservice,mutating=true,failurePolicy=fail,sideEffects=None,groups=qubership.org,resources=opensearchservices,verbs=create;update,versions=v1,name=mopensearchservice.qubership.org,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &OpenSearchService{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *OpenSearchService) Default() {
+	opensearchservicelog.Info("Setting defaults", "name", r.Name, "namespace", r.Namespace)
+
+	if r.Spec.OpenSearch != nil {
+		if r.Spec.OpenSearch.ReadinessTimeout == "" {
+			r.Spec.OpenSearch.ReadinessTimeout = DefaultReadinessTimeout.String()
+		}
+		if r.Spec.OpenSearch.Snapshots != nil && r.Spec.OpenSearch.Snapshots.RepositoryName == "" {
+			r.Spec.OpenSearch.Snapshots.RepositoryName = DefaultSnapshotRepositoryName
+		}
+	}
+	if r.Spec.ExternalOpenSearch != nil {
+		if r.Spec.ExternalOpenSearch.ReadinessTimeout == "" {
+			r.Spec.ExternalOpenSearch.ReadinessTimeout = DefaultReadinessTimeout.String()
+		}
+		if r.Spec.ExternalOpenSearch.Snapshots != nil && r.Spec.ExternalOpenSearch.Snapshots.RepositoryName == "" {
+			r.Spec.ExternalOpenSearch.Snapshots.RepositoryName = DefaultSnapshotRepositoryName
+		}
+	}
+	if r.Spec.DisasterRecovery != nil {
+		if r.Spec.DisasterRecovery.ReplicationWatcherInterval <= 0 {
+			r.Spec.DisasterRecovery.ReplicationWatcherInterval = DefaultReplicationWatcherInterval
+		}
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-qubership-org-v1-opensearchservice,mutating=false,failurePolicy=fail,sideEffects=None,groups=qubership.org,resources=opensearchservices,verbs=create;update,versions=v1,name=vopensearchservice.qubership.org,admissionReviewVersions=v1
+
+var _ webhook.Validator = &OpenSearchService{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *OpenSearchService) ValidateCreate() (admission.Warnings, error) {
+	opensearchservicelog.Info("Validating creation", "name", r.Name, "namespace", r.Namespace)
+	return nil, r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *OpenSearchService) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	// Removal of the finalizer from deleted resources and metadata updates must not be blocked by the spec accepted earlier
+	if r.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	if oldInstance, ok := old.(*OpenSearchService); ok && reflect.DeepEqual(oldInstance.Spec, r.Spec) {
+		return nil, nil
+	}
+	opensearchservicelog.Info("Validating update", "name", r.Name, "namespace", r.Namespace)
+	return nil, r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *OpenSearchService) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (r *OpenSearchService) validate() error {
+	specPath := field.NewPath("spec")
+	var allErrs field.ErrorList
+	if r.Spec.OpenSearch != nil {
+		allErrs = append(allErrs, r.Spec.OpenSearch.validate(specPath.Child("opensearch"))...)
+	}
+	if r.Spec.ExternalOpenSearch != nil {
+		allErrs = append(allErrs, r.Spec.ExternalOpenSearch.validate(specPath.Child("externalOpenSearch"))...)
+	}
+	if r.Spec.Monitoring != nil && r.Spec.Monitoring.SlowQueries != nil && r.Spec.Monitoring.SlowQueries.MinSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("monitoring", "slowQueries", "minSeconds"),
+			r.Spec.Monitoring.SlowQueries.MinSeconds, "must be greater than or equal to 0"))
+	}
+	if r.Spec.DisasterRecovery != nil {
+		allErrs = append(allErrs, r.Spec.DisasterRecovery.validate(specPath.Child("disasterRecovery"))...)
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("OpenSearchService").GroupKind(), r.Name, allErrs)
+}
+
+func (in *OpenSearch) validate(path *field.Path) field.ErrorList {
+	allErrs := validateDuration(path.Child("readinessTimeout"), in.ReadinessTimeout)
+	if in.StatefulSetNames != "" {
+		allErrs = append(allErrs, validateStatefulSetNames(path.Child("statefulSetNames"), in.StatefulSetNames)...)
+	} else if in.RollingUpdate {
+		allErrs = append(allErrs, field.Required(path.Child("statefulSetNames"),
+			"must be specified when rolling update is enabled"))
+	} else if len(in.ScaleDown) > 0 {
+		allErrs = append(allErrs, field.Required(path.Child("statefulSetNames"),
+			"must be specified when scale-down is requested"))
+	}
+	if in.Snapshots != nil {
+		allErrs = append(allErrs, in.Snapshots.validate(path.Child("snapshots"))...)
+	}
+	if in.RollingUpdateOptions != nil {
+		allErrs = append(allErrs, in.RollingUpdateOptions.validate(path.Child("rollingUpdateOptions"))...)
+	}
+	for name, value := range in.ClusterSettings {
+		settingPath := path.Child("clusterSettings").Key(name)
+		var parsed interface{}
+		if err := json.Unmarshal(value.Raw, &parsed); err != nil {
+			allErrs = append(allErrs, field.Invalid(settingPath, string(value.Raw), err.Error()))
+			continue
+		}
+		if _, ok := parsed.(map[string]interface{}); ok {
+			allErrs = append(allErrs, field.Invalid(settingPath, string(value.Raw),
+				"must be a scalar or a list, nested settings must be specified with flat names"))
+		}
+	}
+	names := map[string]bool{}
+	if in.Snapshots != nil && in.Snapshots.S3 != nil && in.Snapshots.S3.KeystoreCredentialsEnabled() {
+		names[S3AccessKeySetting] = true
+		names[S3SecretKeySetting] = true
+	}
+	for i, setting := range in.SecureSettings {
+		settingPath := path.Child("secureSettings").Index(i)
+		if !secureSettingNamePattern.MatchString(setting.Name) {
+			allErrs = append(allErrs, field.Invalid(settingPath.Child("name"), setting.Name,
+				"must consist of lower case alphanumeric characters, '_', '-' or '.'"))
+		} else if names[setting.Name] {
+			allErrs = append(allErrs, field.Duplicate(settingPath.Child("name"), setting.Name))
+		}
+		names[setting.Name] = true
+		if setting.SecretKeyRef.Name == "" {
+			allErrs = append(allErrs, field.Required(settingPath.Child("secretKeyRef", "name"), "must be specified"))
+		}
+		if setting.SecretKeyRef.Key == "" {
+			allErrs = append(allErrs, field.Required(settingPath.Child("secretKeyRef", "key"), "must be specified"))
+		}
+	}
+	statefulSetNames := strings.Split(in.StatefulSetNames, ",")
+	scaleDownNames := map[string]bool{}
+	for i, target := range in.ScaleDown {
+		targetPath := path.Child("scaleDown").Index(i)
+		if in.StatefulSetNames != "" && !slices.Contains(statefulSetNames, target.StatefulSetName) {
+			allErrs = append(allErrs, field.NotSupported(targetPath.Child("statefulSetName"), target.StatefulSetName, statefulSetNames))
+		} else if scaleDownNames[target.StatefulSetName] {
+			allErrs = append(allErrs, field.Duplicate(targetPath.Child("statefulSetName"), target.StatefulSetName))
+		}
+		scaleDownNames[target.StatefulSetName] = true
+		if target.Replicas < 1 {
+			allErrs = append(allErrs, field.Invalid(targetPath.Child("replicas"), target.Replicas, "must be greater than 0"))
+		}
+	}
+	return allErrs
+}
+
+func (in *Snapshots) validate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if in.S3 != nil {
+		allErrs = append(allErrs, in.S3.validate(path.Child("s3"))...)
+	}
+	names := map[string]bool{}
+	for i, schedule := range in.Schedules {
+		schedulePath := path.Child("schedules").Index(i)
+		if schedule.Name == "" {
+			allErrs = append(allErrs, field.Required(schedulePath.Child("name"), "must be specified"))
+		} else if names[schedule.Name] {
+			allErrs = append(allErrs, field.Duplicate(schedulePath.Child("name"), schedule.Name))
+		}
+		names[schedule.Name] = true
+		if schedule.Cron == "" {
+			allErrs = append(allErrs, field.Required(schedulePath.Child("cron"), "must be specified"))
+		}
+		if schedule.Retention != nil {
+			allErrs = append(allErrs, schedule.Retention.validate(schedulePath.Child("retention"))...)
+		}
+	}
+	repositoryNames := map[string]bool{in.RepositoryName: true}
+	for i, repository := range in.Repositories {
+		repositoryPath := path.Child("repositories").Index(i)
+		if repository.Name == "" {
+			allErrs = append(allErrs, field.Required(repositoryPath.Child("name"), "must be specified"))
+		} else if repositoryNames[repository.Name] {
+			allErrs = append(allErrs, field.Duplicate(repositoryPath.Child("name"), repository.Name))
+		}
+		repositoryNames[repository.Name] = true
+		if repository.Type == "" {
+			allErrs = append(allErrs, field.Required(repositoryPath.Child("type"), "must be specified"))
+		}
+		for name, value := range repository.Settings {
+			var parsed interface{}
+			if err := json.Unmarshal(value.Raw, &parsed); err != nil {
+				allErrs = append(allErrs, field.Invalid(repositoryPath.Child("settings").Key(name), string(value.Raw), err.Error()))
+			}
+		}
+	}
+	return allErrs
+}
+
+func (in *SnapshotRetention) validate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if in.MaxCount < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxCount"), in.MaxCount, "must be greater than or equal to 0"))
+	}
+	if in.MinCount < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("minCount"), in.MinCount, "must be greater than or equal to 0"))
+	}
+	if in.MaxCount > 0 && in.MinCount > in.MaxCount {
+		allErrs = append(allErrs, field.Invalid(path.Child("minCount"), in.MinCount, "must not be greater than maxCount"))
+	}
+	if in.MaxCount == 0 && in.MaxAge == "" {
+		allErrs = append(allErrs, field.Required(path, "maxCount or maxAge must be specified"))
+	}
+	return allErrs
+}
+
+func validateDuration(path *field.Path, value string) field.ErrorList {
+	var allErrs field.ErrorList
+	if value == "" {
+		return allErrs
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(path, value, err.Error()))
+	} else if timeout <= 0 {
+		allErrs = append(allErrs, field.Invalid(path, value, "must be a positive duration"))
+	}
+	return allErrs
+}
+
+func validateStatefulSetNames(path *field.Path, value string) field.ErrorList {
+	var allErrs field.ErrorList
+	names := map[string]bool{}
+	for _, name := range strings.Split(value, ",") {
+		if name == "" {
+			allErrs = append(allErrs, field.Invalid(path, value, "must be a comma-separated list without empty names"))
+			continue
+		}
+		for _, message := range validation.IsDNS1123Subdomain(name) {
+			allErrs = append(allErrs, field.Invalid(path, name, message))
+		}
+		if names[name] {
+			allErrs = append(allErrs, field.Duplicate(path, name))
+		}
+		names[name] = true
+	}
+	return allErrs
+}
+
+func (in *S3) validate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !in.Enabled && !in.GcsEnabled {
+		return allErrs
+	}
+	if in.Bucket == "" {
+		allErrs = append(allErrs, field.Required(path.Child("bucket"), "must be specified when snapshots in bucket are enabled"))
+	}
+	if in.Enabled && !in.GcsEnabled && !in.UsePodIdentity && in.SecretName == "" {
+		allErrs = append(allErrs, field.Required(path.Child("secretName"), "must be specified when S3 snapshots are enabled without pod identity"))
+	}
+	if in.Url != "" {
+		if _, err := url.ParseRequestURI(in.Url); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("url"), in.Url, err.Error()))
+		}
+	}
+	allErrs = append(allErrs, validateByteSize(path.Child("chunkSize"), in.ChunkSize)...)
+	allErrs = append(allErrs, validateByteSize(path.Child("maxSnapshotBytesPerSec"), in.MaxSnapshotBytesPerSec)...)
+	allErrs = append(allErrs, validateByteSize(path.Child("maxRestoreBytesPerSec"), in.MaxRestoreBytesPerSec)...)
+	return allErrs
+}
+
+func (in *RollingUpdateOptions) validate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if in.MaxUnavailable < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxUnavailable"), in.MaxUnavailable,
+			"must be greater than or equal to 0"))
+	}
+	if in.Gates == nil {
+		return allErrs
+	}
+	gatesPath := path.Child("gates")
+	for i, gate := range in.Gates.Disabled {
+		if !slices.Contains(RollingUpdateGateNames, gate) {
+			allErrs = append(allErrs, field.NotSupported(gatesPath.Child("disabled").Index(i), gate, RollingUpdateGateNames))
+		}
+	}
+	if in.Gates.MaxPendingTasks != nil && *in.Gates.MaxPendingTasks < 0 {
+		allErrs = append(allErrs, field.Invalid(gatesPath.Child("maxPendingTasks"), *in.Gates.MaxPendingTasks,
+			"must be greater than or equal to 0"))
+	}
+	return append(allErrs, validateDuration(gatesPath.Child("timeout"), in.Gates.Timeout)...)
+}
+
+func validateByteSize(path *field.Path, value string) field.ErrorList {
+	var allErrs field.ErrorList
+	if value != "" && !byteSizePattern.MatchString(value) {
+		allErrs = append(allErrs, field.Invalid(path, value, "must be a byte size value, for example 1gb or 40mb"))
+	}
+	return allErrs
+}
+
+func (in *ExternalOpenSearch) validate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if in.Url == "" {
+		return append(allErrs, field.Required(path.Child("url"), "must be specified"))
+	}
+	if _, err := url.ParseRequestURI(in.Url); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("url"), in.Url, err.Error()))
+	}
+	allErrs = append(allErrs, validateDuration(path.Child("readinessTimeout"), in.ReadinessTimeout)...)
+	if in.CASecret != nil && (in.CASecret.Name == "" || in.CASecret.Key == "") {
+		allErrs = append(allErrs, field.Required(path.Child("caSecret"), "name and key must be specified"))
+	}
+	if in.Snapshots != nil {
+		allErrs = append(allErrs, in.Snapshots.validate(path.Child("snapshots"))...)
+	}
+	return allErrs
+}
+
+func (in *DisasterRecovery) validate(path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	validMode := false
+	for _, mode := range disasterRecoveryModes {
+		if in.Mode == mode {
+			validMode = true
+			break
+		}
+	}
+	if !validMode {
+		allErrs = append(allErrs, field.NotSupported(path.Child("mode"), in.Mode, disasterRecoveryModes))
+	}
+	if in.ConfigMapName == "" {
+		allErrs = append(allErrs, field.Required(path.Child("configMapName"), "must be specified"))
+	}
+	return allErrs
+}
@@ -0,0 +1,203 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOpenSearchServiceValidate(t *testing.T) {
+	negative := -1
+	secretKeyRef := func(name string, key string) corev1.SecretKeySelector {
+		return corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key}
+	}
+	tests := []struct {
+		name           string
+		spec           OpenSearchServiceSpec
+		expectedFields []string
+	}{
+		{name: "empty spec", spec: OpenSearchServiceSpec{}},
+		{name: "valid spec", spec: OpenSearchServiceSpec{
+			OpenSearch: &OpenSearch{
+				RollingUpdate:        true,
+				StatefulSetNames:     "opensearch,opensearch-data",
+				ReadinessTimeout:     "800s",
+				RollingUpdateOptions: &RollingUpdateOptions{MaxUnavailable: 2},
+				ScaleDown:            []ScaleDownTarget{{StatefulSetName: "opensearch-data", Replicas: 2}},
+				Snapshots: &Snapshots{RepositoryName: "snapshots", S3: &S3{Enabled: true, Bucket: "opensearch",
+					SecretName: "s3-credentials", Url: "https://s3.example.com", ChunkSize: "1gb"}},
+				ClusterSettings: map[string]apiextensionsv1.JSON{"cluster.routing.allocation.enable": {Raw: []byte(`"all"`)}},
+				SecureSettings: []SecureSetting{{Name: "s3.client.default.session_token",
+					SecretKeyRef: secretKeyRef("s3-credentials", "session-token")}},
+			},
+			DisasterRecovery: &DisasterRecovery{Mode: DisasterRecoveryActiveMode, ConfigMapName: "opensearch-replication-config"},
+		}},
+		{name: "invalid readiness timeout",
+			spec:           OpenSearchServiceSpec{OpenSearch: &OpenSearch{ReadinessTimeout: "800"}},
+			expectedFields: []string{"spec.opensearch.readinessTimeout"}},
+		{name: "negative readiness timeout",
+			spec:           OpenSearchServiceSpec{OpenSearch: &OpenSearch{ReadinessTimeout: "-1m"}},
+			expectedFields: []string{"spec.opensearch.readinessTimeout"}},
+		{name: "rolling update without stateful set names",
+			spec:           OpenSearchServiceSpec{OpenSearch: &OpenSearch{RollingUpdate: true}},
+			expectedFields: []string{"spec.opensearch.statefulSetNames"}},
+		{name: "invalid stateful set names",
+			spec:           OpenSearchServiceSpec{OpenSearch: &OpenSearch{StatefulSetNames: "opensearch,,Data,opensearch"}},
+			expectedFields: []string{"spec.opensearch.statefulSetNames", "spec.opensearch.statefulSetNames", "spec.opensearch.statefulSetNames"}},
+		{name: "invalid scale-down targets",
+			spec: OpenSearchServiceSpec{OpenSearch: &OpenSearch{StatefulSetNames: "opensearch,opensearch-data",
+				ScaleDown: []ScaleDownTarget{
+					{StatefulSetName: "opensearch-client", Replicas: 1},
+					{StatefulSetName: "opensearch-data", Replicas: 0},
+					{StatefulSetName: "opensearch-data", Replicas: 1},
+				}}},
+			expectedFields: []string{"spec.opensearch.scaleDown[0].statefulSetName", "spec.opensearch.scaleDown[1].replicas",
+				"spec.opensearch.scaleDown[2].statefulSetName"}},
+		{name: "scale-down without stateful set names",
+			spec: OpenSearchServiceSpec{OpenSearch: &OpenSearch{
+				ScaleDown: []ScaleDownTarget{{StatefulSetName: "opensearch-data", Replicas: 1}}}},
+			expectedFields: []string{"spec.opensearch.statefulSetNames"}},
+		{name: "invalid rolling update options",
+			spec: OpenSearchServiceSpec{OpenSearch: &OpenSearch{RollingUpdateOptions: &RollingUpdateOptions{
+				MaxUnavailable: -1,
+				Gates: &RollingUpdateGates{Disabled: []string{RollingUpdateGateDiskUsage, "unknown"},
+					MaxPendingTasks: &negative, Timeout: "ten minutes"},
+			}}},
+			expectedFields: []string{"spec.opensearch.rollingUpdateOptions.maxUnavailable",
+				"spec.opensearch.rollingUpdateOptions.gates.disabled[1]",
+				"spec.opensearch.rollingUpdateOptions.gates.maxPendingTasks",
+				"spec.opensearch.rollingUpdateOptions.gates.timeout"}},
+		{name: "nested cluster setting",
+			spec: OpenSearchServiceSpec{OpenSearch: &OpenSearch{ClusterSettings: map[string]apiextensionsv1.JSON{
+				"cluster": {Raw: []byte(`{"routing":{"allocation":{"enable":"all"}}}`)}}}},
+			expectedFields: []string{"spec.opensearch.clusterSettings[cluster]"}},
+		{name: "invalid secure settings",
+			spec: OpenSearchServiceSpec{OpenSearch: &OpenSearch{
+				Snapshots: &Snapshots{S3: &S3{Enabled: true, Bucket: "opensearch", SecretName: "s3-credentials"}},
+				SecureSettings: []SecureSetting{
+					{Name: S3AccessKeySetting, SecretKeyRef: secretKeyRef("s3-credentials", "s3-key-id")},
+					{Name: "Invalid Name", SecretKeyRef: secretKeyRef("secret", "key")},
+					{Name: "plugins.setting"},
+				}}},
+			expectedFields: []string{"spec.opensearch.secureSettings[0].name", "spec.opensearch.secureSettings[1].name",
+				"spec.opensearch.secureSettings[2].secretKeyRef.name", "spec.opensearch.secureSettings[2].secretKeyRef.key"}},
+		{name: "invalid S3 snapshots",
+			spec: OpenSearchServiceSpec{OpenSearch: &OpenSearch{Snapshots: &Snapshots{S3: &S3{Enabled: true,
+				Url: "s3 storage", ChunkSize: "1 gigabyte"}}}},
+			expectedFields: []string{"spec.opensearch.snapshots.s3.bucket", "spec.opensearch.snapshots.s3.secretName",
+				"spec.opensearch.snapshots.s3.url", "spec.opensearch.snapshots.s3.chunkSize"}},
+		{name: "S3 snapshots in pod identity mode",
+			spec: OpenSearchServiceSpec{OpenSearch: &OpenSearch{Snapshots: &Snapshots{S3: &S3{Enabled: true,
+				Bucket: "opensearch", UsePodIdentity: true}}}}},
+		{name: "invalid snapshot schedules and repositories",
+			spec: OpenSearchServiceSpec{OpenSearch: &OpenSearch{Snapshots: &Snapshots{
+				RepositoryName: "snapshots",
+				Schedules: []SnapshotSchedule{
+					{Name: "daily", Cron: "0 0 * * *", Retention: &SnapshotRetention{MaxCount: 2, MinCount: 3}},
+					{Name: "daily", Retention: &SnapshotRetention{}},
+				},
+				Repositories: []SnapshotRepository{{Name: "snapshots", Type: "fs"}, {Name: "archive"}},
+			}}},
+			expectedFields: []string{"spec.opensearch.snapshots.schedules[0].retention.minCount",
+				"spec.opensearch.snapshots.schedules[1].name", "spec.opensearch.snapshots.schedules[1].cron",
+				"spec.opensearch.snapshots.schedules[1].retention", "spec.opensearch.snapshots.repositories[0].name",
+				"spec.opensearch.snapshots.repositories[1].type"}},
+		{name: "external OpenSearch without url",
+			spec:           OpenSearchServiceSpec{ExternalOpenSearch: &ExternalOpenSearch{}},
+			expectedFields: []string{"spec.externalOpenSearch.url"}},
+		{name: "invalid external OpenSearch",
+			spec: OpenSearchServiceSpec{ExternalOpenSearch: &ExternalOpenSearch{Url: "opensearch",
+				ReadinessTimeout: "0s", CASecret: &corev1.SecretKeySelector{Key: "ca.crt"}}},
+			expectedFields: []string{"spec.externalOpenSearch.url", "spec.externalOpenSearch.readinessTimeout",
+				"spec.externalOpenSearch.caSecret"}},
+		{name: "negative slow queries threshold",
+			spec:           OpenSearchServiceSpec{Monitoring: &Monitoring{SlowQueries: &SlowQueries{MinSeconds: -1}}},
+			expectedFields: []string{"spec.monitoring.slowQueries.minSeconds"}},
+		{name: "invalid disaster recovery",
+			spec:           OpenSearchServiceSpec{DisasterRecovery: &DisasterRecovery{Mode: "Active"}},
+			expectedFields: []string{"spec.disasterRecovery.mode", "spec.disasterRecovery.configMapName"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			instance := &OpenSearchService{
+				ObjectMeta: metav1.ObjectMeta{Name: "opensearch", Namespace: "opensearch-service"},
+				Spec:       test.spec,
+			}
+			_, createErr := instance.ValidateCreate()
+			_, updateErr := instance.ValidateUpdate(&OpenSearchService{})
+			assert.Equal(t, createErr, updateErr)
+			if len(test.expectedFields) == 0 {
+				assert.NoError(t, createErr)
+				return
+			}
+			var statusError *apierrors.StatusError
+			if !assert.ErrorAs(t, createErr, &statusError) {
+				return
+			}
+			assert.True(t, apierrors.IsInvalid(createErr))
+			var fields []string
+			for _, cause := range statusError.ErrStatus.Details.Causes {
+				fields = append(fields, cause.Field)
+			}
+			assert.ElementsMatch(t, test.expectedFields, fields)
+		})
+	}
+}
+
+func TestOpenSearchServiceValidateUpdate(t *testing.T) {
+	invalidSpec := OpenSearchServiceSpec{OpenSearch: &OpenSearch{ReadinessTimeout: "ten minutes"}}
+	validSpec := OpenSearchServiceSpec{OpenSearch: &OpenSearch{ReadinessTimeout: "800s"}}
+	deletionTimestamp := metav1.Now()
+	tests := []struct {
+		name          string
+		old           OpenSearchServiceSpec
+		new           OpenSearchServiceSpec
+		deleted       bool
+		expectedError bool
+	}{
+		{name: "invalid spec is changed", old: validSpec, new: invalidSpec, expectedError: true},
+		{name: "invalid spec is not changed", old: invalidSpec, new: invalidSpec},
+		{name: "invalid spec is fixed", old: invalidSpec, new: validSpec},
+		{name: "resource is deleted", old: validSpec, new: invalidSpec, deleted: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			oldInstance := &OpenSearchService{Spec: *test.old.DeepCopy()}
+			instance := &OpenSearchService{Spec: *test.new.DeepCopy()}
+			if test.deleted {
+				instance.DeletionTimestamp = &deletionTimestamp
+			}
+			_, err := instance.ValidateUpdate(oldInstance)
+			if test.expectedError {
+				assert.True(t, apierrors.IsInvalid(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOpenSearchServiceValidateDelete(t *testing.T) {
+	instance := &OpenSearchService{Spec: OpenSearchServiceSpec{OpenSearch: &OpenSearch{ReadinessTimeout: "800"}}}
+	warnings, err := instance.ValidateDelete()
+	assert.Empty(t, warnings)
+	assert.NoError(t, err)
+}
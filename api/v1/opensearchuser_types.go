@@ -0,0 +1,77 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SecurityObjectStatus shows result of synchronization of security object with OpenSearch
+type SecurityObjectStatus struct {
+	// Status - Can be "applied" or "failed".
+	Status  string `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+	// ObservedGeneration - Generation of the custom resource that was applied.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// AppliedHash - Hash of the applied definition including values of referenced secrets.
+	AppliedHash string `json:"appliedHash,omitempty"`
+	// AppliedName - Name of the entity in OpenSearch created by the custom resource.
+	AppliedName        string      `json:"appliedName,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// OpenSearchUserSpec defines internal user of OpenSearch security plugin
+type OpenSearchUserSpec struct {
+	// OpenSearchServiceRef - OpenSearchService in the same namespace the user is created in.
+	OpenSearchServiceRef corev1.LocalObjectReference `json:"opensearchServiceRef"`
+	// Username - Name of the user in OpenSearch, name of the custom resource is used if it is not specified.
+	Username string `json:"username,omitempty"`
+	// PasswordSecret - Key of the secret in the same namespace with password of the user.
+	PasswordSecret corev1.SecretKeySelector `json:"passwordSecret"`
+	BackendRoles   []string                 `json:"backendRoles,omitempty"`
+	// SecurityRoles - OpenSearch roles the user is mapped to directly.
+	SecurityRoles []string          `json:"securityRoles,omitempty"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+	Description   string            `json:"description,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Service",type=string,JSONPath=`.spec.opensearchServiceRef.name`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// OpenSearchUser is the Schema for the opensearchusers API
+type OpenSearchUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenSearchUserSpec   `json:"spec,omitempty"`
+	Status SecurityObjectStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OpenSearchUserList contains a list of OpenSearchUser
+type OpenSearchUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenSearchUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenSearchUser{}, &OpenSearchUserList{})
+}
@@ -6,9 +6,72 @@
 package v1
 
 import (
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSettingsStatus) DeepCopyInto(out *ClusterSettingsStatus) {
+	*out = *in
+	if in.AppliedKeys != nil {
+		in, out := &in.AppliedKeys, &out.AppliedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DriftedKeys != nil {
+		in, out := &in.DriftedKeys, &out.DriftedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastDriftTime != nil {
+		in, out := &in.LastDriftTime, &out.LastDriftTime
+		*out = (*in).DeepCopy()
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSettingsStatus.
+func (in *ClusterSettingsStatus) DeepCopy() *ClusterSettingsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSettingsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
+func (in *ClusterStatus) DeepCopy() *ClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
+func (in *ComponentStatus) DeepCopy() *ComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Curator) DeepCopyInto(out *Curator) {
 	*out = *in
@@ -72,6 +135,21 @@ func (in *DisasterRecovery) DeepCopy() *DisasterRecovery {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DisasterRecoveryStatus) DeepCopyInto(out *DisasterRecoveryStatus) {
 	*out = *in
+	if in.ReplicationCheckpoints != nil {
+		in, out := &in.ReplicationCheckpoints, &out.ReplicationCheckpoints
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PhaseStartTime != nil {
+		in, out := &in.PhaseStartTime, &out.PhaseStartTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisasterRecoveryStatus.
@@ -109,6 +187,21 @@ func (in *ExternalOpenSearch) DeepCopyInto(out *ExternalOpenSearch) {
 			(*out)[key] = val
 		}
 	}
+	if in.CASecret != nil {
+		in, out := &in.CASecret, &out.CASecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailoverUrls != nil {
+		in, out := &in.FailoverUrls, &out.FailoverUrls
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = new(Snapshots)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalOpenSearch.
@@ -121,6 +214,166 @@ func (in *ExternalOpenSearch) DeepCopy() *ExternalOpenSearch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ISMPolicy) DeepCopyInto(out *ISMPolicy) {
+	*out = *in
+	in.Body.DeepCopyInto(&out.Body)
+	if in.IndexPatterns != nil {
+		in, out := &in.IndexPatterns, &out.IndexPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ISMPolicy.
+func (in *ISMPolicy) DeepCopy() *ISMPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ISMPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexManagement) DeepCopyInto(out *IndexManagement) {
+	*out = *in
+	if in.ComponentTemplates != nil {
+		in, out := &in.ComponentTemplates, &out.ComponentTemplates
+		*out = make([]IndexManagementResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IndexTemplates != nil {
+		in, out := &in.IndexTemplates, &out.IndexTemplates
+		*out = make([]IndexManagementResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ISMPolicies != nil {
+		in, out := &in.ISMPolicies, &out.ISMPolicies
+		*out = make([]ISMPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexManagement.
+func (in *IndexManagement) DeepCopy() *IndexManagement {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexManagement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexManagementResource) DeepCopyInto(out *IndexManagementResource) {
+	*out = *in
+	in.Body.DeepCopyInto(&out.Body)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexManagementResource.
+func (in *IndexManagementResource) DeepCopy() *IndexManagementResource {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexManagementResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexManagementStatus) DeepCopyInto(out *IndexManagementStatus) {
+	*out = *in
+	if in.ComponentTemplates != nil {
+		in, out := &in.ComponentTemplates, &out.ComponentTemplates
+		*out = make([]ManagedResourceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IndexTemplates != nil {
+		in, out := &in.IndexTemplates, &out.IndexTemplates
+		*out = make([]ManagedResourceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ISMPolicies != nil {
+		in, out := &in.ISMPolicies, &out.ISMPolicies
+		*out = make([]ManagedResourceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexManagementStatus.
+func (in *IndexManagementStatus) DeepCopy() *IndexManagementStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexManagementStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexPermission) DeepCopyInto(out *IndexPermission) {
+	*out = *in
+	if in.IndexPatterns != nil {
+		in, out := &in.IndexPatterns, &out.IndexPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FLS != nil {
+		in, out := &in.FLS, &out.FLS
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaskedFields != nil {
+		in, out := &in.MaskedFields, &out.MaskedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedActions != nil {
+		in, out := &in.AllowedActions, &out.AllowedActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexPermission.
+func (in *IndexPermission) DeepCopy() *IndexPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedResourceStatus) DeepCopyInto(out *ManagedResourceStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedResourceStatus.
+func (in *ManagedResourceStatus) DeepCopy() *ManagedResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Monitoring) DeepCopyInto(out *Monitoring) {
 	*out = *in
@@ -154,6 +407,35 @@ func (in *OpenSearch) DeepCopyInto(out *OpenSearch) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ClusterSettings != nil {
+		in, out := &in.ClusterSettings, &out.ClusterSettings
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.SecureSettings != nil {
+		in, out := &in.SecureSettings, &out.SecureSettings
+		*out = make([]SecureSetting, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RollingUpdateOptions != nil {
+		in, out := &in.RollingUpdateOptions, &out.RollingUpdateOptions
+		*out = new(RollingUpdateOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScaleDown != nil {
+		in, out := &in.ScaleDown, &out.ScaleDown
+		*out = make([]ScaleDownTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLSReload != nil {
+		in, out := &in.TLSReload, &out.TLSReload
+		*out = new(TLSReload)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearch.
@@ -167,7 +449,7 @@ func (in *OpenSearch) DeepCopy() *OpenSearch {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenSearchService) DeepCopyInto(out *OpenSearchService) {
+func (in *OpenSearchRole) DeepCopyInto(out *OpenSearchRole) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -175,18 +457,18 @@ func (in *OpenSearchService) DeepCopyInto(out *OpenSearchService) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchService.
-func (in *OpenSearchService) DeepCopy() *OpenSearchService {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchRole.
+func (in *OpenSearchRole) DeepCopy() *OpenSearchRole {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenSearchService)
+	out := new(OpenSearchRole)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OpenSearchService) DeepCopyObject() runtime.Object {
+func (in *OpenSearchRole) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -194,31 +476,31 @@ func (in *OpenSearchService) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenSearchServiceList) DeepCopyInto(out *OpenSearchServiceList) {
+func (in *OpenSearchRoleList) DeepCopyInto(out *OpenSearchRoleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]OpenSearchService, len(*in))
+		*out = make([]OpenSearchRole, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchServiceList.
-func (in *OpenSearchServiceList) DeepCopy() *OpenSearchServiceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchRoleList.
+func (in *OpenSearchRoleList) DeepCopy() *OpenSearchRoleList {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenSearchServiceList)
+	out := new(OpenSearchRoleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OpenSearchServiceList) DeepCopyObject() runtime.Object {
+func (in *OpenSearchRoleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -226,78 +508,514 @@ func (in *OpenSearchServiceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenSearchServiceSpec) DeepCopyInto(out *OpenSearchServiceSpec) {
+func (in *OpenSearchRoleMapping) DeepCopyInto(out *OpenSearchRoleMapping) {
 	*out = *in
-	if in.OpenSearch != nil {
-		in, out := &in.OpenSearch, &out.OpenSearch
-		*out = new(OpenSearch)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ExternalOpenSearch != nil {
-		in, out := &in.ExternalOpenSearch, &out.ExternalOpenSearch
-		*out = new(ExternalOpenSearch)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Dashboards != nil {
-		in, out := &in.Dashboards, &out.Dashboards
-		*out = new(Dashboards)
-		**out = **in
-	}
-	if in.Monitoring != nil {
-		in, out := &in.Monitoring, &out.Monitoring
-		*out = new(Monitoring)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.DbaasAdapter != nil {
-		in, out := &in.DbaasAdapter, &out.DbaasAdapter
-		*out = new(DbaasAdapter)
-		**out = **in
-	}
-	if in.ElasticsearchDbaasAdapter != nil {
-		in, out := &in.ElasticsearchDbaasAdapter, &out.ElasticsearchDbaasAdapter
-		*out = new(ElasticsearchDbaasAdapter)
-		**out = **in
-	}
-	if in.Curator != nil {
-		in, out := &in.Curator, &out.Curator
-		*out = new(Curator)
-		**out = **in
-	}
-	if in.DisasterRecovery != nil {
-		in, out := &in.DisasterRecovery, &out.DisasterRecovery
-		*out = new(DisasterRecovery)
-		**out = **in
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchServiceSpec.
-func (in *OpenSearchServiceSpec) DeepCopy() *OpenSearchServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchRoleMapping.
+func (in *OpenSearchRoleMapping) DeepCopy() *OpenSearchRoleMapping {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenSearchServiceSpec)
+	out := new(OpenSearchRoleMapping)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchRoleMapping) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenSearchServiceStatus) DeepCopyInto(out *OpenSearchServiceStatus) {
+func (in *OpenSearchRoleMappingList) DeepCopyInto(out *OpenSearchRoleMappingList) {
 	*out = *in
-	out.DisasterRecoveryStatus = in.DisasterRecoveryStatus
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]StatusCondition, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenSearchRoleMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	in.RollingUpdateStatus.DeepCopyInto(&out.RollingUpdateStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchServiceStatus.
-func (in *OpenSearchServiceStatus) DeepCopy() *OpenSearchServiceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchRoleMappingList.
+func (in *OpenSearchRoleMappingList) DeepCopy() *OpenSearchRoleMappingList {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenSearchServiceStatus)
+	out := new(OpenSearchRoleMappingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchRoleMappingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchRoleMappingSpec) DeepCopyInto(out *OpenSearchRoleMappingSpec) {
+	*out = *in
+	out.OpenSearchServiceRef = in.OpenSearchServiceRef
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BackendRoles != nil {
+		in, out := &in.BackendRoles, &out.BackendRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchRoleMappingSpec.
+func (in *OpenSearchRoleMappingSpec) DeepCopy() *OpenSearchRoleMappingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchRoleMappingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchRoleSpec) DeepCopyInto(out *OpenSearchRoleSpec) {
+	*out = *in
+	out.OpenSearchServiceRef = in.OpenSearchServiceRef
+	if in.ClusterPermissions != nil {
+		in, out := &in.ClusterPermissions, &out.ClusterPermissions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IndexPermissions != nil {
+		in, out := &in.IndexPermissions, &out.IndexPermissions
+		*out = make([]IndexPermission, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TenantPermissions != nil {
+		in, out := &in.TenantPermissions, &out.TenantPermissions
+		*out = make([]TenantPermission, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchRoleSpec.
+func (in *OpenSearchRoleSpec) DeepCopy() *OpenSearchRoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchRoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchService) DeepCopyInto(out *OpenSearchService) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchService.
+func (in *OpenSearchService) DeepCopy() *OpenSearchService {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchService) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchServiceList) DeepCopyInto(out *OpenSearchServiceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenSearchService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchServiceList.
+func (in *OpenSearchServiceList) DeepCopy() *OpenSearchServiceList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchServiceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchServiceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchServiceSpec) DeepCopyInto(out *OpenSearchServiceSpec) {
+	*out = *in
+	if in.OpenSearch != nil {
+		in, out := &in.OpenSearch, &out.OpenSearch
+		*out = new(OpenSearch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalOpenSearch != nil {
+		in, out := &in.ExternalOpenSearch, &out.ExternalOpenSearch
+		*out = new(ExternalOpenSearch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Dashboards != nil {
+		in, out := &in.Dashboards, &out.Dashboards
+		*out = new(Dashboards)
+		**out = **in
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(Monitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DbaasAdapter != nil {
+		in, out := &in.DbaasAdapter, &out.DbaasAdapter
+		*out = new(DbaasAdapter)
+		**out = **in
+	}
+	if in.ElasticsearchDbaasAdapter != nil {
+		in, out := &in.ElasticsearchDbaasAdapter, &out.ElasticsearchDbaasAdapter
+		*out = new(ElasticsearchDbaasAdapter)
+		**out = **in
+	}
+	if in.Curator != nil {
+		in, out := &in.Curator, &out.Curator
+		*out = new(Curator)
+		**out = **in
+	}
+	if in.DisasterRecovery != nil {
+		in, out := &in.DisasterRecovery, &out.DisasterRecovery
+		*out = new(DisasterRecovery)
+		**out = **in
+	}
+	if in.Teardown != nil {
+		in, out := &in.Teardown, &out.Teardown
+		*out = new(Teardown)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IndexManagement != nil {
+		in, out := &in.IndexManagement, &out.IndexManagement
+		*out = new(IndexManagement)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchServiceSpec.
+func (in *OpenSearchServiceSpec) DeepCopy() *OpenSearchServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchServiceStatus) DeepCopyInto(out *OpenSearchServiceStatus) {
+	*out = *in
+	in.DisasterRecoveryStatus.DeepCopyInto(&out.DisasterRecoveryStatus)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]StatusCondition, len(*in))
+		copy(*out, *in)
+	}
+	in.RollingUpdateStatus.DeepCopyInto(&out.RollingUpdateStatus)
+	in.TeardownStatus.DeepCopyInto(&out.TeardownStatus)
+	if in.OpenSearchStatus != nil {
+		in, out := &in.OpenSearchStatus, &out.OpenSearchStatus
+		*out = new(ClusterStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalOpenSearchStatus != nil {
+		in, out := &in.ExternalOpenSearchStatus, &out.ExternalOpenSearchStatus
+		*out = new(ClusterStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DashboardsStatus != nil {
+		in, out := &in.DashboardsStatus, &out.DashboardsStatus
+		*out = new(ComponentStatus)
+		**out = **in
+	}
+	if in.MonitoringStatus != nil {
+		in, out := &in.MonitoringStatus, &out.MonitoringStatus
+		*out = new(ComponentStatus)
+		**out = **in
+	}
+	if in.DbaasAdapterStatus != nil {
+		in, out := &in.DbaasAdapterStatus, &out.DbaasAdapterStatus
+		*out = new(ComponentStatus)
+		**out = **in
+	}
+	if in.ElasticsearchDbaasAdapterStatus != nil {
+		in, out := &in.ElasticsearchDbaasAdapterStatus, &out.ElasticsearchDbaasAdapterStatus
+		*out = new(ComponentStatus)
+		**out = **in
+	}
+	if in.CuratorStatus != nil {
+		in, out := &in.CuratorStatus, &out.CuratorStatus
+		*out = new(ComponentStatus)
+		**out = **in
+	}
+	in.IndexManagementStatus.DeepCopyInto(&out.IndexManagementStatus)
+	if in.SnapshotPolicies != nil {
+		in, out := &in.SnapshotPolicies, &out.SnapshotPolicies
+		*out = make([]SnapshotPolicyStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SnapshotRepositories != nil {
+		in, out := &in.SnapshotRepositories, &out.SnapshotRepositories
+		*out = make([]SnapshotRepositoryStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterSettingsStatus != nil {
+		in, out := &in.ClusterSettingsStatus, &out.ClusterSettingsStatus
+		*out = new(ClusterSettingsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecureSettingsStatus != nil {
+		in, out := &in.SecureSettingsStatus, &out.SecureSettingsStatus
+		*out = new(SecureSettingsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScaleDownStatuses != nil {
+		in, out := &in.ScaleDownStatuses, &out.ScaleDownStatuses
+		*out = make([]ScaleDownStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReadinessStatus != nil {
+		in, out := &in.ReadinessStatus, &out.ReadinessStatus
+		*out = new(ReadinessStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSReloadStatuses != nil {
+		in, out := &in.TLSReloadStatuses, &out.TLSReloadStatuses
+		*out = make([]TLSReloadStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchServiceStatus.
+func (in *OpenSearchServiceStatus) DeepCopy() *OpenSearchServiceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchServiceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchUser) DeepCopyInto(out *OpenSearchUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchUser.
+func (in *OpenSearchUser) DeepCopy() *OpenSearchUser {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchUserList) DeepCopyInto(out *OpenSearchUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenSearchUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchUserList.
+func (in *OpenSearchUserList) DeepCopy() *OpenSearchUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchUserSpec) DeepCopyInto(out *OpenSearchUserSpec) {
+	*out = *in
+	out.OpenSearchServiceRef = in.OpenSearchServiceRef
+	in.PasswordSecret.DeepCopyInto(&out.PasswordSecret)
+	if in.BackendRoles != nil {
+		in, out := &in.BackendRoles, &out.BackendRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityRoles != nil {
+		in, out := &in.SecurityRoles, &out.SecurityRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchUserSpec.
+func (in *OpenSearchUserSpec) DeepCopy() *OpenSearchUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessStatus) DeepCopyInto(out *ReadinessStatus) {
+	*out = *in
+	if in.WaitStartTime != nil {
+		in, out := &in.WaitStartTime, &out.WaitStartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessStatus.
+func (in *ReadinessStatus) DeepCopy() *ReadinessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdateGates) DeepCopyInto(out *RollingUpdateGates) {
+	*out = *in
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxPendingTasks != nil {
+		in, out := &in.MaxPendingTasks, &out.MaxPendingTasks
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateGates.
+func (in *RollingUpdateGates) DeepCopy() *RollingUpdateGates {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingUpdateGates)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdateOptions) DeepCopyInto(out *RollingUpdateOptions) {
+	*out = *in
+	if in.Gates != nil {
+		in, out := &in.Gates, &out.Gates
+		*out = new(RollingUpdateGates)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ZoneAwareness != nil {
+		in, out := &in.ZoneAwareness, &out.ZoneAwareness
+		*out = new(ZoneAwareness)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateOptions.
+func (in *RollingUpdateOptions) DeepCopy() *RollingUpdateOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingUpdateOptions)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -312,6 +1030,44 @@ func (in *RollingUpdateStatus) DeepCopyInto(out *RollingUpdateStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RestartOrder != nil {
+		in, out := &in.RestartOrder, &out.RestartOrder
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CurrentPods != nil {
+		in, out := &in.CurrentPods, &out.CurrentPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletedPods != nil {
+		in, out := &in.CompletedPods, &out.CompletedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ZoneRestartStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PauseTime != nil {
+		in, out := &in.PauseTime, &out.PauseTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PhaseStartTime != nil {
+		in, out := &in.PhaseStartTime, &out.PhaseStartTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateStatus.
@@ -339,6 +1095,99 @@ func (in *S3) DeepCopy() *S3 {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleDownStatus) DeepCopyInto(out *ScaleDownStatus) {
+	*out = *in
+	if in.ExcludedNodes != nil {
+		in, out := &in.ExcludedNodes, &out.ExcludedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleDownStatus.
+func (in *ScaleDownStatus) DeepCopy() *ScaleDownStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleDownStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleDownTarget) DeepCopyInto(out *ScaleDownTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleDownTarget.
+func (in *ScaleDownTarget) DeepCopy() *ScaleDownTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleDownTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecureSetting) DeepCopyInto(out *SecureSetting) {
+	*out = *in
+	in.SecretKeyRef.DeepCopyInto(&out.SecretKeyRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecureSetting.
+func (in *SecureSetting) DeepCopy() *SecureSetting {
+	if in == nil {
+		return nil
+	}
+	out := new(SecureSetting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecureSettingsStatus) DeepCopyInto(out *SecureSettingsStatus) {
+	*out = *in
+	if in.AppliedKeys != nil {
+		in, out := &in.AppliedKeys, &out.AppliedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastReloadTime != nil {
+		in, out := &in.LastReloadTime, &out.LastReloadTime
+		*out = (*in).DeepCopy()
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecureSettingsStatus.
+func (in *SecureSettingsStatus) DeepCopy() *SecureSettingsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecureSettingsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityObjectStatus) DeepCopyInto(out *SecurityObjectStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityObjectStatus.
+func (in *SecurityObjectStatus) DeepCopy() *SecurityObjectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityObjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SlowQueries) DeepCopyInto(out *SlowQueries) {
 	*out = *in
@@ -354,6 +1203,125 @@ func (in *SlowQueries) DeepCopy() *SlowQueries {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotPolicyStatus) DeepCopyInto(out *SnapshotPolicyStatus) {
+	*out = *in
+	in.ManagedResourceStatus.DeepCopyInto(&out.ManagedResourceStatus)
+	if in.LastSuccessTime != nil {
+		in, out := &in.LastSuccessTime, &out.LastSuccessTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRunTime != nil {
+		in, out := &in.NextRunTime, &out.NextRunTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotPolicyStatus.
+func (in *SnapshotPolicyStatus) DeepCopy() *SnapshotPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotRepository) DeepCopyInto(out *SnapshotRepository) {
+	*out = *in
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRepository.
+func (in *SnapshotRepository) DeepCopy() *SnapshotRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotRepositoryStatus) DeepCopyInto(out *SnapshotRepositoryStatus) {
+	*out = *in
+	in.ManagedResourceStatus.DeepCopyInto(&out.ManagedResourceStatus)
+	if in.VerifiedNodes != nil {
+		in, out := &in.VerifiedNodes, &out.VerifiedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastVerificationTime != nil {
+		in, out := &in.LastVerificationTime, &out.LastVerificationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastVerificationErrorTime != nil {
+		in, out := &in.LastVerificationErrorTime, &out.LastVerificationErrorTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRepositoryStatus.
+func (in *SnapshotRepositoryStatus) DeepCopy() *SnapshotRepositoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotRepositoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotRetention) DeepCopyInto(out *SnapshotRetention) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRetention.
+func (in *SnapshotRetention) DeepCopy() *SnapshotRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotSchedule) DeepCopyInto(out *SnapshotSchedule) {
+	*out = *in
+	if in.IndexPatterns != nil {
+		in, out := &in.IndexPatterns, &out.IndexPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(SnapshotRetention)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotSchedule.
+func (in *SnapshotSchedule) DeepCopy() *SnapshotSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Snapshots) DeepCopyInto(out *Snapshots) {
 	*out = *in
@@ -362,6 +1330,20 @@ func (in *Snapshots) DeepCopyInto(out *Snapshots) {
 		*out = new(S3)
 		**out = **in
 	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]SnapshotSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]SnapshotRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Snapshots.
@@ -408,3 +1390,160 @@ func (in *StatusCondition) DeepCopy() *StatusCondition {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSReload) DeepCopyInto(out *TLSReload) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSReload.
+func (in *TLSReload) DeepCopy() *TLSReload {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSReload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSReloadStatus) DeepCopyInto(out *TLSReloadStatus) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSReloadStatus.
+func (in *TLSReloadStatus) DeepCopy() *TLSReloadStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSReloadStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Teardown) DeepCopyInto(out *Teardown) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]TeardownStep, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Teardown.
+func (in *Teardown) DeepCopy() *Teardown {
+	if in == nil {
+		return nil
+	}
+	out := new(Teardown)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeardownStatus) DeepCopyInto(out *TeardownStatus) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]TeardownStepStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeardownStatus.
+func (in *TeardownStatus) DeepCopy() *TeardownStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TeardownStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeardownStepStatus) DeepCopyInto(out *TeardownStepStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeardownStepStatus.
+func (in *TeardownStepStatus) DeepCopy() *TeardownStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TeardownStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantPermission) DeepCopyInto(out *TenantPermission) {
+	*out = *in
+	if in.TenantPatterns != nil {
+		in, out := &in.TenantPatterns, &out.TenantPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedActions != nil {
+		in, out := &in.AllowedActions, &out.AllowedActions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantPermission.
+func (in *TenantPermission) DeepCopy() *TenantPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneAwareness) DeepCopyInto(out *ZoneAwareness) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneAwareness.
+func (in *ZoneAwareness) DeepCopy() *ZoneAwareness {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneAwareness)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneRestartStatus) DeepCopyInto(out *ZoneRestartStatus) {
+	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneRestartStatus.
+func (in *ZoneRestartStatus) DeepCopy() *ZoneRestartStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneRestartStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,585 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	v1 "github.com/Netcracker/opensearch-service/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/strings/slices"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// v1TransitionTimeLayout is the layout of time.Time String() the operator uses for v1 conditions
+const v1TransitionTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// v1 readiness timeouts which are not valid durations are kept in annotations to restore them on conversion back to v1
+const (
+	readinessTimeoutAnnotation         = "opensearchservice.qubership.org/v1-readiness-timeout"
+	externalReadinessTimeoutAnnotation = "opensearchservice.qubership.org/v1-external-readiness-timeout"
+)
+
+// v1 condition types contain spaces which are not allowed in metav1.Condition type
+var conditionTypesFromV1 = map[string]string{
+	"In progress": "InProgress",
+}
+
+var _ conversion.Convertible = &OpenSearchService{}
+
+// ConvertTo converts this OpenSearchService to the hub (v1) version
+func (src *OpenSearchService) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1.OpenSearchService)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Annotations = withoutAnnotations(src.Annotations, readinessTimeoutAnnotation, externalReadinessTimeoutAnnotation)
+
+	dst.Spec = v1.OpenSearchServiceSpec{
+		ExternalOpenSearch:        convertExternalOpenSearchToV1(src.Spec.ExternalOpenSearch),
+		Dashboards:                (*v1.Dashboards)(src.Spec.Dashboards),
+		Monitoring:                convertMonitoringToV1(src.Spec.Monitoring),
+		DbaasAdapter:              (*v1.DbaasAdapter)(src.Spec.DbaasAdapter),
+		ElasticsearchDbaasAdapter: (*v1.ElasticsearchDbaasAdapter)(src.Spec.ElasticsearchDbaasAdapter),
+		Curator:                   (*v1.Curator)(src.Spec.Curator),
+		Teardown:                  convertTeardownToV1(src.Spec.Teardown),
+	}
+	if dst.Spec.ExternalOpenSearch != nil && dst.Spec.ExternalOpenSearch.ReadinessTimeout == "" {
+		dst.Spec.ExternalOpenSearch.ReadinessTimeout = src.Annotations[externalReadinessTimeoutAnnotation]
+	}
+	if err := convertSection(src.Spec.IndexManagement, &dst.Spec.IndexManagement); err != nil {
+		return err
+	}
+	if src.Spec.OpenSearch != nil {
+		opensearch := src.Spec.OpenSearch
+		dst.Spec.OpenSearch = &v1.OpenSearch{
+			DedicatedClientPod:        opensearch.DedicatedClientPod,
+			DedicatedDataPod:          opensearch.DedicatedDataPod,
+			Snapshots:                 convertSnapshotsToV1(opensearch.Snapshots),
+			SecurityConfigurationName: opensearch.SecurityConfigurationName,
+			CompatibilityModeEnabled:  opensearch.CompatibilityModeEnabled,
+			RollingUpdate:             opensearch.RollingUpdate,
+			StatefulSetNames:          strings.Join(opensearch.StatefulSetNames, ","),
+			DisabledRestCategories:    opensearch.DisabledRestCategories,
+			ClusterSettings:           opensearch.ClusterSettings,
+		}
+		if opensearch.ReadinessTimeout != nil {
+			dst.Spec.OpenSearch.ReadinessTimeout = opensearch.ReadinessTimeout.Duration.String()
+		} else {
+			dst.Spec.OpenSearch.ReadinessTimeout = src.Annotations[readinessTimeoutAnnotation]
+		}
+		if err := convertSection(opensearch.SecureSettings, &dst.Spec.OpenSearch.SecureSettings); err != nil {
+			return err
+		}
+		if err := convertSection(opensearch.RollingUpdateOptions, &dst.Spec.OpenSearch.RollingUpdateOptions); err != nil {
+			return err
+		}
+		if err := convertSection(opensearch.ScaleDown, &dst.Spec.OpenSearch.ScaleDown); err != nil {
+			return err
+		}
+		if err := convertSection(opensearch.TLSReload, &dst.Spec.OpenSearch.TLSReload); err != nil {
+			return err
+		}
+	}
+	if src.Spec.DisasterRecovery != nil {
+		disasterRecovery := src.Spec.DisasterRecovery
+		dst.Spec.DisasterRecovery = &v1.DisasterRecovery{
+			Mode:                       string(disasterRecovery.Mode),
+			NoWait:                     disasterRecovery.NoWait,
+			ConfigMapName:              disasterRecovery.ConfigMapName,
+			ReplicationWatcherEnabled:  disasterRecovery.ReplicationWatcherEnabled,
+			ReplicationWatcherInterval: disasterRecovery.ReplicationWatcherInterval,
+		}
+	}
+
+	dst.Status = v1.OpenSearchServiceStatus{
+		DisasterRecoveryStatus: v1.DisasterRecoveryStatus{
+			Mode:                   string(src.Status.DisasterRecoveryStatus.Mode),
+			Status:                 src.Status.DisasterRecoveryStatus.Status,
+			Comment:                src.Status.DisasterRecoveryStatus.Comment,
+			Message:                src.Status.DisasterRecoveryStatus.Message,
+			UsersRecoveryState:     src.Status.DisasterRecoveryStatus.UsersRecoveryState,
+			ReplicationHealth:      src.Status.DisasterRecoveryStatus.ReplicationHealth,
+			Phase:                  src.Status.DisasterRecoveryStatus.Phase,
+			ReplicationCheckpoints: src.Status.DisasterRecoveryStatus.ReplicationCheckpoints,
+			StartTime:              src.Status.DisasterRecoveryStatus.StartTime,
+			PhaseStartTime:         src.Status.DisasterRecoveryStatus.PhaseStartTime,
+		},
+		RollingUpdateStatus: v1.RollingUpdateStatus{
+			Status:         src.Status.RollingUpdateStatus.Status,
+			RestartOrder:   src.Status.RollingUpdateStatus.RestartOrder,
+			CurrentPods:    src.Status.RollingUpdateStatus.CurrentPods,
+			CompletedPods:  src.Status.RollingUpdateStatus.CompletedPods,
+			PauseReason:    src.Status.RollingUpdateStatus.PauseReason,
+			StartTime:      src.Status.RollingUpdateStatus.StartTime,
+			PauseTime:      src.Status.RollingUpdateStatus.PauseTime,
+			CompletionTime: src.Status.RollingUpdateStatus.CompletionTime,
+			Phase:          src.Status.RollingUpdateStatus.Phase,
+			Gate:           src.Status.RollingUpdateStatus.Gate,
+			PhaseStartTime: src.Status.RollingUpdateStatus.PhaseStartTime,
+		},
+		OpenSearchStatus:                convertClusterStatusToV1(src.Status.OpenSearchStatus),
+		ExternalOpenSearchStatus:        convertClusterStatusToV1(src.Status.ExternalOpenSearchStatus),
+		DashboardsStatus:                (*v1.ComponentStatus)(src.Status.DashboardsStatus),
+		MonitoringStatus:                (*v1.ComponentStatus)(src.Status.MonitoringStatus),
+		DbaasAdapterStatus:              (*v1.ComponentStatus)(src.Status.DbaasAdapterStatus),
+		ElasticsearchDbaasAdapterStatus: (*v1.ComponentStatus)(src.Status.ElasticsearchDbaasAdapterStatus),
+		CuratorStatus:                   (*v1.ComponentStatus)(src.Status.CuratorStatus),
+	}
+	for _, statefulSetStatus := range src.Status.RollingUpdateStatus.StatefulSetStatuses {
+		dst.Status.RollingUpdateStatus.StatefulSetStatuses =
+			append(dst.Status.RollingUpdateStatus.StatefulSetStatuses, v1.StatefulSetStatus(statefulSetStatus))
+	}
+	for _, zoneStatus := range src.Status.RollingUpdateStatus.Zones {
+		dst.Status.RollingUpdateStatus.Zones = append(dst.Status.RollingUpdateStatus.Zones, v1.ZoneRestartStatus(zoneStatus))
+	}
+	for _, condition := range src.Status.Conditions {
+		dst.Status.Conditions = append(dst.Status.Conditions, convertConditionToV1(condition))
+	}
+	for _, stepStatus := range src.Status.TeardownStatus.Steps {
+		dst.Status.TeardownStatus.Steps = append(dst.Status.TeardownStatus.Steps, v1.TeardownStepStatus{
+			Name:               v1.TeardownStep(stepStatus.Name),
+			Status:             stepStatus.Status,
+			Message:            stepStatus.Message,
+			LastTransitionTime: stepStatus.LastTransitionTime,
+		})
+	}
+	if err := convertSection(src.Status.IndexManagementStatus, &dst.Status.IndexManagementStatus); err != nil {
+		return err
+	}
+	if err := convertSection(src.Status.SnapshotPolicies, &dst.Status.SnapshotPolicies); err != nil {
+		return err
+	}
+	if err := convertSection(src.Status.SnapshotRepositories, &dst.Status.SnapshotRepositories); err != nil {
+		return err
+	}
+	if err := convertSection(src.Status.ClusterSettingsStatus, &dst.Status.ClusterSettingsStatus); err != nil {
+		return err
+	}
+	if err := convertSection(src.Status.SecureSettingsStatus, &dst.Status.SecureSettingsStatus); err != nil {
+		return err
+	}
+	if err := convertSection(src.Status.ScaleDownStatuses, &dst.Status.ScaleDownStatuses); err != nil {
+		return err
+	}
+	if err := convertSection(src.Status.ReadinessStatus, &dst.Status.ReadinessStatus); err != nil {
+		return err
+	}
+	return convertSection(src.Status.TLSReloadStatuses, &dst.Status.TLSReloadStatuses)
+}
+
+// ConvertFrom converts from the hub (v1) version to this version
+func (dst *OpenSearchService) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1.OpenSearchService)
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec = OpenSearchServiceSpec{
+		ExternalOpenSearch:        convertExternalOpenSearchFromV1(src.Spec.ExternalOpenSearch),
+		Dashboards:                (*Dashboards)(src.Spec.Dashboards),
+		Monitoring:                convertMonitoringFromV1(src.Spec.Monitoring),
+		DbaasAdapter:              (*DbaasAdapter)(src.Spec.DbaasAdapter),
+		ElasticsearchDbaasAdapter: (*ElasticsearchDbaasAdapter)(src.Spec.ElasticsearchDbaasAdapter),
+		Curator:                   (*Curator)(src.Spec.Curator),
+		Teardown:                  convertTeardownFromV1(src.Spec.Teardown),
+	}
+	if external := src.Spec.ExternalOpenSearch; external != nil && external.ReadinessTimeout != "" &&
+		dst.Spec.ExternalOpenSearch.ReadinessTimeout == nil {
+		dst.Annotations = withAnnotation(dst.Annotations, externalReadinessTimeoutAnnotation, external.ReadinessTimeout)
+	}
+	if err := convertSection(src.Spec.IndexManagement, &dst.Spec.IndexManagement); err != nil {
+		return err
+	}
+	if src.Spec.OpenSearch != nil {
+		opensearch := src.Spec.OpenSearch
+		dst.Spec.OpenSearch = &OpenSearch{
+			DedicatedClientPod:        opensearch.DedicatedClientPod,
+			DedicatedDataPod:          opensearch.DedicatedDataPod,
+			Snapshots:                 convertSnapshotsFromV1(opensearch.Snapshots),
+			SecurityConfigurationName: opensearch.SecurityConfigurationName,
+			CompatibilityModeEnabled:  opensearch.CompatibilityModeEnabled,
+			RollingUpdate:             opensearch.RollingUpdate,
+			DisabledRestCategories:    opensearch.DisabledRestCategories,
+			ClusterSettings:           opensearch.ClusterSettings,
+		}
+		dst.Spec.OpenSearch.StatefulSetNames = splitStatefulSetNames(opensearch.StatefulSetNames)
+		if opensearch.ReadinessTimeout != "" {
+			if timeout, err := time.ParseDuration(opensearch.ReadinessTimeout); err == nil {
+				dst.Spec.OpenSearch.ReadinessTimeout = &metav1.Duration{Duration: timeout}
+			} else {
+				dst.Annotations = withAnnotation(dst.Annotations, readinessTimeoutAnnotation, opensearch.ReadinessTimeout)
+			}
+		}
+		if err := convertSection(opensearch.SecureSettings, &dst.Spec.OpenSearch.SecureSettings); err != nil {
+			return err
+		}
+		if err := convertSection(opensearch.RollingUpdateOptions, &dst.Spec.OpenSearch.RollingUpdateOptions); err != nil {
+			return err
+		}
+		if err := convertSection(opensearch.ScaleDown, &dst.Spec.OpenSearch.ScaleDown); err != nil {
+			return err
+		}
+		if err := convertSection(opensearch.TLSReload, &dst.Spec.OpenSearch.TLSReload); err != nil {
+			return err
+		}
+	}
+	if src.Spec.DisasterRecovery != nil {
+		disasterRecovery := src.Spec.DisasterRecovery
+		dst.Spec.DisasterRecovery = &DisasterRecovery{
+			Mode:                       DisasterRecoveryMode(strings.ToLower(disasterRecovery.Mode)),
+			NoWait:                     disasterRecovery.NoWait,
+			ConfigMapName:              disasterRecovery.ConfigMapName,
+			ReplicationWatcherEnabled:  disasterRecovery.ReplicationWatcherEnabled,
+			ReplicationWatcherInterval: disasterRecovery.ReplicationWatcherInterval,
+		}
+	}
+
+	dst.Status = OpenSearchServiceStatus{
+		DisasterRecoveryStatus: DisasterRecoveryStatus{
+			Mode:                   DisasterRecoveryMode(src.Status.DisasterRecoveryStatus.Mode),
+			Status:                 src.Status.DisasterRecoveryStatus.Status,
+			Comment:                src.Status.DisasterRecoveryStatus.Comment,
+			Message:                src.Status.DisasterRecoveryStatus.Message,
+			UsersRecoveryState:     src.Status.DisasterRecoveryStatus.UsersRecoveryState,
+			ReplicationHealth:      src.Status.DisasterRecoveryStatus.ReplicationHealth,
+			Phase:                  src.Status.DisasterRecoveryStatus.Phase,
+			ReplicationCheckpoints: src.Status.DisasterRecoveryStatus.ReplicationCheckpoints,
+			StartTime:              src.Status.DisasterRecoveryStatus.StartTime,
+			PhaseStartTime:         src.Status.DisasterRecoveryStatus.PhaseStartTime,
+		},
+		RollingUpdateStatus: RollingUpdateStatus{
+			Status:         src.Status.RollingUpdateStatus.Status,
+			RestartOrder:   src.Status.RollingUpdateStatus.RestartOrder,
+			CurrentPods:    src.Status.RollingUpdateStatus.CurrentPods,
+			CompletedPods:  src.Status.RollingUpdateStatus.CompletedPods,
+			PauseReason:    src.Status.RollingUpdateStatus.PauseReason,
+			StartTime:      src.Status.RollingUpdateStatus.StartTime,
+			PauseTime:      src.Status.RollingUpdateStatus.PauseTime,
+			CompletionTime: src.Status.RollingUpdateStatus.CompletionTime,
+			Phase:          src.Status.RollingUpdateStatus.Phase,
+			Gate:           src.Status.RollingUpdateStatus.Gate,
+			PhaseStartTime: src.Status.RollingUpdateStatus.PhaseStartTime,
+		},
+		OpenSearchStatus:                convertClusterStatusFromV1(src.Status.OpenSearchStatus),
+		ExternalOpenSearchStatus:        convertClusterStatusFromV1(src.Status.ExternalOpenSearchStatus),
+		DashboardsStatus:                (*ComponentStatus)(src.Status.DashboardsStatus),
+		MonitoringStatus:                (*ComponentStatus)(src.Status.MonitoringStatus),
+		DbaasAdapterStatus:              (*ComponentStatus)(src.Status.DbaasAdapterStatus),
+		ElasticsearchDbaasAdapterStatus: (*ComponentStatus)(src.Status.ElasticsearchDbaasAdapterStatus),
+		CuratorStatus:                   (*ComponentStatus)(src.Status.CuratorStatus),
+	}
+	for _, statefulSetStatus := range src.Status.RollingUpdateStatus.StatefulSetStatuses {
+		dst.Status.RollingUpdateStatus.StatefulSetStatuses =
+			append(dst.Status.RollingUpdateStatus.StatefulSetStatuses, StatefulSetStatus(statefulSetStatus))
+	}
+	for _, zoneStatus := range src.Status.RollingUpdateStatus.Zones {
+		dst.Status.RollingUpdateStatus.Zones = append(dst.Status.RollingUpdateStatus.Zones, ZoneRestartStatus(zoneStatus))
+	}
+	for _, condition := range src.Status.Conditions {
+		dst.Status.Conditions = append(dst.Status.Conditions, convertConditionFromV1(condition, src.Generation))
+	}
+	for _, stepStatus := range src.Status.TeardownStatus.Steps {
+		dst.Status.TeardownStatus.Steps = append(dst.Status.TeardownStatus.Steps, TeardownStepStatus{
+			Name:               TeardownStep(stepStatus.Name),
+			Status:             stepStatus.Status,
+			Message:            stepStatus.Message,
+			LastTransitionTime: stepStatus.LastTransitionTime,
+		})
+	}
+	if err := convertSection(src.Status.IndexManagementStatus, &dst.Status.IndexManagementStatus); err != nil {
+		return err
+	}
+	if err := convertSection(src.Status.SnapshotPolicies, &dst.Status.SnapshotPolicies); err != nil {
+		return err
+	}
+	if err := convertSection(src.Status.SnapshotRepositories, &dst.Status.SnapshotRepositories); err != nil {
+		return err
+	}
+	if err := convertSection(src.Status.ClusterSettingsStatus, &dst.Status.ClusterSettingsStatus); err != nil {
+		return err
+	}
+	if err := convertSection(src.Status.SecureSettingsStatus, &dst.Status.SecureSettingsStatus); err != nil {
+		return err
+	}
+	if err := convertSection(src.Status.ScaleDownStatuses, &dst.Status.ScaleDownStatuses); err != nil {
+		return err
+	}
+	if err := convertSection(src.Status.ReadinessStatus, &dst.Status.ReadinessStatus); err != nil {
+		return err
+	}
+	return convertSection(src.Status.TLSReloadStatuses, &dst.Status.TLSReloadStatuses)
+}
+
+// splitStatefulSetNames splits v1 comma-separated names as is, they are validated by v1 webhook
+func splitStatefulSetNames(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// withAnnotation returns copy of annotations with specified one, the source map is shared with the converted object
+func withAnnotation(annotations map[string]string, key string, value string) map[string]string {
+	result := make(map[string]string, len(annotations)+1)
+	for k, v := range annotations {
+		result[k] = v
+	}
+	result[key] = value
+	return result
+}
+
+// withoutAnnotations returns copy of annotations without specified keys, nil is returned if nothing is left
+func withoutAnnotations(annotations map[string]string, keys ...string) map[string]string {
+	var result map[string]string
+	for k, v := range annotations {
+		if slices.Contains(keys, k) {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]string, len(annotations))
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// convertSection copies section which has the same schema in both versions
+func convertSection(src interface{}, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+func convertExternalOpenSearchToV1(externalOpenSearch *ExternalOpenSearch) *v1.ExternalOpenSearch {
+	if externalOpenSearch == nil {
+		return nil
+	}
+	converted := &v1.ExternalOpenSearch{
+		Config:                      externalOpenSearch.Config,
+		Url:                         externalOpenSearch.Url,
+		CredentialsSecretName:       externalOpenSearch.CredentialsSecretName,
+		CASecret:                    externalOpenSearch.CASecret,
+		FailoverUrls:                externalOpenSearch.FailoverUrls,
+		ClientCertificateSecretName: externalOpenSearch.ClientCertificateSecretName,
+		Snapshots:                   convertSnapshotsToV1(externalOpenSearch.Snapshots),
+	}
+	if externalOpenSearch.ReadinessTimeout != nil {
+		converted.ReadinessTimeout = externalOpenSearch.ReadinessTimeout.Duration.String()
+	}
+	return converted
+}
+
+func convertExternalOpenSearchFromV1(externalOpenSearch *v1.ExternalOpenSearch) *ExternalOpenSearch {
+	if externalOpenSearch == nil {
+		return nil
+	}
+	converted := &ExternalOpenSearch{
+		Config:                      externalOpenSearch.Config,
+		Url:                         externalOpenSearch.Url,
+		CredentialsSecretName:       externalOpenSearch.CredentialsSecretName,
+		CASecret:                    externalOpenSearch.CASecret,
+		FailoverUrls:                externalOpenSearch.FailoverUrls,
+		ClientCertificateSecretName: externalOpenSearch.ClientCertificateSecretName,
+		Snapshots:                   convertSnapshotsFromV1(externalOpenSearch.Snapshots),
+	}
+	if externalOpenSearch.ReadinessTimeout != "" {
+		if timeout, err := time.ParseDuration(externalOpenSearch.ReadinessTimeout); err == nil {
+			converted.ReadinessTimeout = &metav1.Duration{Duration: timeout}
+		}
+	}
+	return converted
+}
+
+func convertSnapshotsToV1(snapshots *Snapshots) *v1.Snapshots {
+	if snapshots == nil {
+		return nil
+	}
+	converted := &v1.Snapshots{
+		RepositoryName: snapshots.RepositoryName,
+		S3:             (*v1.S3)(snapshots.S3),
+	}
+	for _, schedule := range snapshots.Schedules {
+		converted.Schedules = append(converted.Schedules, v1.SnapshotSchedule{
+			Name:               schedule.Name,
+			Cron:               schedule.Cron,
+			Timezone:           schedule.Timezone,
+			IndexPatterns:      schedule.IndexPatterns,
+			IncludeGlobalState: schedule.IncludeGlobalState,
+			Retention:          (*v1.SnapshotRetention)(schedule.Retention),
+			DeletionCron:       schedule.DeletionCron,
+		})
+	}
+	for _, repository := range snapshots.Repositories {
+		converted.Repositories = append(converted.Repositories, v1.SnapshotRepository(repository))
+	}
+	return converted
+}
+
+func convertSnapshotsFromV1(snapshots *v1.Snapshots) *Snapshots {
+	if snapshots == nil {
+		return nil
+	}
+	converted := &Snapshots{
+		RepositoryName: snapshots.RepositoryName,
+		S3:             (*S3)(snapshots.S3),
+	}
+	for _, schedule := range snapshots.Schedules {
+		converted.Schedules = append(converted.Schedules, SnapshotSchedule{
+			Name:               schedule.Name,
+			Cron:               schedule.Cron,
+			Timezone:           schedule.Timezone,
+			IndexPatterns:      schedule.IndexPatterns,
+			IncludeGlobalState: schedule.IncludeGlobalState,
+			Retention:          (*SnapshotRetention)(schedule.Retention),
+			DeletionCron:       schedule.DeletionCron,
+		})
+	}
+	for _, repository := range snapshots.Repositories {
+		converted.Repositories = append(converted.Repositories, SnapshotRepository(repository))
+	}
+	return converted
+}
+
+func convertMonitoringToV1(monitoring *Monitoring) *v1.Monitoring {
+	if monitoring == nil {
+		return nil
+	}
+	return &v1.Monitoring{
+		Name:        monitoring.Name,
+		SecretName:  monitoring.SecretName,
+		SlowQueries: (*v1.SlowQueries)(monitoring.SlowQueries),
+	}
+}
+
+func convertMonitoringFromV1(monitoring *v1.Monitoring) *Monitoring {
+	if monitoring == nil {
+		return nil
+	}
+	return &Monitoring{
+		Name:        monitoring.Name,
+		SecretName:  monitoring.SecretName,
+		SlowQueries: (*SlowQueries)(monitoring.SlowQueries),
+	}
+}
+
+func convertTeardownToV1(teardown *Teardown) *v1.Teardown {
+	if teardown == nil {
+		return nil
+	}
+	result := &v1.Teardown{IgnoreErrors: teardown.IgnoreErrors}
+	for _, step := range teardown.Steps {
+		result.Steps = append(result.Steps, v1.TeardownStep(step))
+	}
+	return result
+}
+
+func convertTeardownFromV1(teardown *v1.Teardown) *Teardown {
+	if teardown == nil {
+		return nil
+	}
+	result := &Teardown{IgnoreErrors: teardown.IgnoreErrors}
+	for _, step := range teardown.Steps {
+		result.Steps = append(result.Steps, TeardownStep(step))
+	}
+	return result
+}
+
+func convertClusterStatusToV1(status *ClusterStatus) *v1.ClusterStatus {
+	if status == nil {
+		return nil
+	}
+	return &v1.ClusterStatus{
+		Health:              status.Health,
+		Version:             status.Version,
+		NumberOfNodes:       status.NumberOfNodes,
+		NumberOfDataNodes:   status.NumberOfDataNodes,
+		ActivePrimaryShards: status.ActivePrimaryShards,
+		ActiveShards:        status.ActiveShards,
+		RelocatingShards:    status.RelocatingShards,
+		InitializingShards:  status.InitializingShards,
+		UnassignedShards:    status.UnassignedShards,
+		Message:             status.Message,
+		LastUpdateTime:      status.LastUpdateTime,
+	}
+}
+
+func convertClusterStatusFromV1(status *v1.ClusterStatus) *ClusterStatus {
+	if status == nil {
+		return nil
+	}
+	return &ClusterStatus{
+		Health:              status.Health,
+		Version:             status.Version,
+		NumberOfNodes:       status.NumberOfNodes,
+		NumberOfDataNodes:   status.NumberOfDataNodes,
+		ActivePrimaryShards: status.ActivePrimaryShards,
+		ActiveShards:        status.ActiveShards,
+		RelocatingShards:    status.RelocatingShards,
+		InitializingShards:  status.InitializingShards,
+		UnassignedShards:    status.UnassignedShards,
+		Message:             status.Message,
+		LastUpdateTime:      status.LastUpdateTime,
+	}
+}
+
+func convertConditionToV1(condition metav1.Condition) v1.StatusCondition {
+	conditionType := condition.Type
+	for v1Type, v2Type := range conditionTypesFromV1 {
+		if conditionType == v2Type {
+			conditionType = v1Type
+		}
+	}
+	return v1.StatusCondition{
+		Type:               conditionType,
+		Status:             string(condition.Status),
+		Reason:             condition.Reason,
+		Message:            condition.Message,
+		LastTransitionTime: condition.LastTransitionTime.String(),
+	}
+}
+
+func convertConditionFromV1(condition v1.StatusCondition, generation int64) metav1.Condition {
+	conditionType := condition.Type
+	if v2Type, ok := conditionTypesFromV1[conditionType]; ok {
+		conditionType = v2Type
+	}
+	return metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionStatus(condition.Status),
+		ObservedGeneration: generation,
+		Reason:             condition.Reason,
+		Message:            condition.Message,
+		LastTransitionTime: parseTransitionTime(condition.LastTransitionTime),
+	}
+}
+
+// parseTransitionTime parses v1 condition time which is stored either in RFC 3339 or in time.Time String() format.
+// Monotonic clock reading is dropped, unknown formats are converted to zero time.
+func parseTransitionTime(value string) metav1.Time {
+	if value == "" {
+		return metav1.Time{}
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return metav1.NewTime(parsed)
+	}
+	if index := strings.Index(value, " m="); index != -1 {
+		value = value[:index]
+	}
+	if parsed, err := time.Parse(v1TransitionTimeLayout, value); err == nil {
+		return metav1.NewTime(parsed)
+	}
+	return metav1.Time{}
+}
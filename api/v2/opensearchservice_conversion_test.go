@@ -0,0 +1,169 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConvertRoundTripFromV1(t *testing.T) {
+	// status times are converted through JSON which restores them in local time
+	startTime := metav1.NewTime(time.Unix(1741064767, 0))
+	newV1 := func(change func(instance *v1.OpenSearchService)) *v1.OpenSearchService {
+		instance := &v1.OpenSearchService{
+			ObjectMeta: metav1.ObjectMeta{Name: "opensearch", Namespace: "opensearch-service", Generation: 3},
+			Spec: v1.OpenSearchServiceSpec{
+				OpenSearch: &v1.OpenSearch{
+					SecurityConfigurationName: "opensearch-security-configuration",
+					RollingUpdate:             true,
+					StatefulSetNames:          "opensearch,opensearch-data",
+					ReadinessTimeout:          "13m20s",
+					RollingUpdateOptions:      &v1.RollingUpdateOptions{MaxUnavailable: 2},
+					ScaleDown:                 []v1.ScaleDownTarget{{StatefulSetName: "opensearch-data", Replicas: 2}},
+					TLSReload:                 &v1.TLSReload{TransportSecretName: "opensearch-transport-certs"},
+				},
+				DisasterRecovery: &v1.DisasterRecovery{Mode: "active", ConfigMapName: "opensearch-replication-config"},
+			},
+			Status: v1.OpenSearchServiceStatus{
+				DisasterRecoveryStatus: v1.DisasterRecoveryStatus{
+					Mode: "active", Status: "running", Phase: "usersRecovery", StartTime: &startTime,
+					PhaseStartTime: &startTime,
+				},
+				RollingUpdateStatus: v1.RollingUpdateStatus{
+					Status:              "running",
+					RestartOrder:        []string{"opensearch-data-1", "opensearch-data-0"},
+					CurrentPods:         []string{"opensearch-data-1"},
+					StartTime:           &startTime,
+					Phase:               "gate",
+					Gate:                "shardsSettled",
+					PhaseStartTime:      &startTime,
+					StatefulSetStatuses: []v1.StatefulSetStatus{{Name: "opensearch-data", UpdatedReplicas: []int32{2}}},
+					Zones:               []v1.ZoneRestartStatus{{Name: "zone-a", Pods: []string{"opensearch-data-1"}}},
+				},
+				Conditions: []v1.StatusCondition{{Type: "In progress", Status: "False", Reason: "ReconcileCycleStatus",
+					Message: "Start reconcile cycle", LastTransitionTime: "2025-03-04 05:06:07 +0000 UTC"}},
+				TLSReloadStatuses: []v1.TLSReloadStatus{{Layer: "transport", SerialNumber: "1a2b",
+					Nodes: []string{"opensearch-0"}, StartTime: &startTime}},
+			},
+		}
+		if change != nil {
+			change(instance)
+		}
+		return instance
+	}
+
+	tests := []struct {
+		name     string
+		src      *v1.OpenSearchService
+		expected *v1.OpenSearchService
+	}{
+		{name: "empty resource", src: &v1.OpenSearchService{}},
+		{name: "full resource", src: newV1(nil)},
+		{name: "external OpenSearch", src: newV1(func(instance *v1.OpenSearchService) {
+			instance.Spec.OpenSearch = nil
+			instance.Spec.ExternalOpenSearch = &v1.ExternalOpenSearch{Url: "https://opensearch:9200",
+				FailoverUrls: []string{"https://opensearch-backup:9200"}, ReadinessTimeout: "5m0s"}
+		})},
+		{name: "readiness timeouts which are not durations are kept", src: newV1(func(instance *v1.OpenSearchService) {
+			instance.Spec.OpenSearch.ReadinessTimeout = "800"
+			instance.Spec.ExternalOpenSearch = &v1.ExternalOpenSearch{Url: "https://opensearch:9200",
+				ReadinessTimeout: "ten minutes"}
+		})},
+		{name: "readiness timeout is normalized",
+			src: newV1(func(instance *v1.OpenSearchService) {
+				instance.Spec.OpenSearch.ReadinessTimeout = "800s"
+			}),
+			expected: newV1(nil)},
+		{name: "stateful set names are kept as is",
+			src: newV1(func(instance *v1.OpenSearchService) {
+				instance.Spec.OpenSearch.StatefulSetNames = " opensearch,, opensearch-data "
+			}),
+			expected: newV1(func(instance *v1.OpenSearchService) {
+				instance.Spec.OpenSearch.StatefulSetNames = " opensearch,, opensearch-data "
+			})},
+		{name: "disaster recovery mode is lower cased",
+			src: newV1(func(instance *v1.OpenSearchService) {
+				instance.Spec.DisasterRecovery.Mode = "Active"
+			}),
+			expected: newV1(nil)},
+		{name: "RFC 3339 condition time is converted",
+			src: newV1(func(instance *v1.OpenSearchService) {
+				instance.Status.Conditions[0].LastTransitionTime = "2025-03-04T05:06:07Z"
+			}),
+			expected: newV1(nil)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			if expected == nil {
+				expected = test.src.DeepCopy()
+			}
+			converted := &OpenSearchService{}
+			assert.NoError(t, converted.ConvertFrom(test.src.DeepCopy()))
+			actual := &v1.OpenSearchService{}
+			assert.NoError(t, converted.ConvertTo(actual))
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestConvertRoundTripFromV2(t *testing.T) {
+	// status times are converted through JSON which restores them in local time
+	startTime := metav1.NewTime(time.Unix(1741064767, 0))
+	tests := []struct {
+		name string
+		src  *OpenSearchService
+	}{
+		{name: "empty resource", src: &OpenSearchService{}},
+		{name: "full resource", src: &OpenSearchService{
+			ObjectMeta: metav1.ObjectMeta{Name: "opensearch", Namespace: "opensearch-service", Generation: 3,
+				Annotations: map[string]string{"qubership.org/owner": "platform"}},
+			Spec: OpenSearchServiceSpec{
+				OpenSearch: &OpenSearch{
+					RollingUpdate:    true,
+					StatefulSetNames: []string{"opensearch", "opensearch-data"},
+					ReadinessTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+				ExternalOpenSearch: &ExternalOpenSearch{Url: "https://opensearch:9200",
+					ReadinessTimeout: &metav1.Duration{Duration: time.Minute}},
+				DisasterRecovery: &DisasterRecovery{Mode: "standby", ConfigMapName: "opensearch-replication-config"},
+			},
+			Status: OpenSearchServiceStatus{
+				DisasterRecoveryStatus: DisasterRecoveryStatus{Mode: "standby", Status: "running",
+					Phase: "replicationCheck", PhaseStartTime: &startTime},
+				RollingUpdateStatus: RollingUpdateStatus{Status: "paused", CompletedPods: []string{"opensearch-0"},
+					PauseTime: &startTime, Phase: "clusterHealth", PhaseStartTime: &startTime},
+				Conditions: []metav1.Condition{{Type: "InProgress", Status: metav1.ConditionFalse,
+					ObservedGeneration: 3, Reason: "ReconcileCycleStatus", Message: "Start reconcile cycle",
+					LastTransitionTime: metav1.NewTime(time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC))}},
+				TLSReloadStatuses: []TLSReloadStatus{{Layer: "http", SerialNumber: "3c4d", StartTime: &startTime}},
+			},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			converted := &v1.OpenSearchService{}
+			assert.NoError(t, test.src.DeepCopy().ConvertTo(converted))
+			actual := &OpenSearchService{}
+			assert.NoError(t, actual.ConvertFrom(converted))
+			assert.Equal(t, test.src, actual)
+		})
+	}
+}
@@ -210,7 +210,21 @@ Whether TLS for OpenSearch is enabled
 OpenSearch configuration
 */}}
 {{- define "opensearch.config" -}}
+{{- if .Values.opensearch.tls.hotReload }}
+{{- $config := deepCopy .Values.opensearch.config }}
+{{- $_ := set $config "plugins.security.ssl_cert_reload_enabled" true }}
+{{- $_ = set $config "plugins.security.ssl.transport.pemcert_filepath" "transport-certs/tls.crt" }}
+{{- $_ = set $config "plugins.security.ssl.transport.pemkey_filepath" "transport-certs/tls.key" }}
+{{- $_ = set $config "plugins.security.ssl.transport.pemtrustedcas_filepath" "transport-certs/ca.crt" }}
+{{- if eq (include "opensearch.tlsEnabled" .) "true" }}
+{{- $_ = set $config "plugins.security.ssl.http.pemcert_filepath" "rest-certs/tls.crt" }}
+{{- $_ = set $config "plugins.security.ssl.http.pemkey_filepath" "rest-certs/tls.key" }}
+{{- $_ = set $config "plugins.security.ssl.http.pemtrustedcas_filepath" "rest-certs/ca.crt" }}
+{{- end }}
+{{ toYaml $config }}
+{{- else }}
 {{ toYaml .Values.opensearch.config }}
+{{- end }}
 {{- if and (eq (include "opensearch.tlsEnabled" .) "true") (or .Values.opensearch.tls.cipherSuites .Values.global.tls.cipherSuites) }}
 plugins.security.ssl.http.enabled_ciphers:
 {{- range (coalesce .Values.opensearch.tls.cipherSuites .Values.global.tls.cipherSuites) }}
@@ -0,0 +1,296 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	startWatcherAction     = "start"
+	stopWatcherAction      = "stop"
+	terminateWatcherAction = "terminate"
+	// watcherStuckTimeout is the time the iteration of the watcher can take in addition to the watch interval
+	// before the watcher is considered as stuck
+	watcherStuckTimeout = 10 * time.Minute
+)
+
+// watcherSignal changes state of the background watcher loop
+type watcherSignal struct {
+	action    string
+	interval  time.Duration
+	iteration func(ctx context.Context)
+}
+
+// backgroundWatcher runs the iteration periodically as manager runnable, so it is started only on the elected leader
+// and is stopped when the manager is stopped. The loop is started, stopped, reconfigured and terminated with signals
+// which are processed between iterations. Only the latest signal is kept, so sending never waits for the iteration,
+// and the context of the iteration in progress is cancelled when the watcher is stopped or terminated.
+type backgroundWatcher struct {
+	name    string
+	logger  logr.Logger
+	signals chan watcherSignal
+	// sendLock serializes senders replacing the pending signal
+	sendLock sync.Mutex
+	done     chan struct{}
+	once     sync.Once
+	running  atomic.Bool
+	// cancelLock guards cancelIteration which cancels the context of the iteration in progress
+	cancelLock      sync.Mutex
+	cancelIteration context.CancelFunc
+	// lastActivity is the time in Unix nanoseconds the loop was last waiting for signals
+	lastActivity atomic.Int64
+	interval     atomic.Int64
+}
+
+func newBackgroundWatcher(name string) *backgroundWatcher {
+	return &backgroundWatcher{
+		name:    name,
+		logger:  log.WithValues("watcher", name),
+		signals: make(chan watcherSignal, 1),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start runs the watcher loop until the context is cancelled or the watcher is terminated
+func (bw *backgroundWatcher) Start(ctx context.Context) error {
+	defer bw.close()
+	bw.logger.Info("Watcher loop is started")
+	var iteration func(ctx context.Context)
+	var interval time.Duration
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		bw.lastActivity.Store(time.Now().UnixNano())
+		select {
+		case <-ctx.Done():
+			bw.running.Store(false)
+			bw.logger.Info("Watcher loop is stopped because the manager is stopped")
+			return nil
+		case signal := <-bw.signals:
+			switch signal.action {
+			case startWatcherAction:
+				wasRunning := iteration != nil
+				iteration = signal.iteration
+				if !wasRunning || interval != signal.interval {
+					resetTimer(timer, 0)
+				}
+				interval = signal.interval
+				bw.interval.Store(int64(interval))
+				bw.running.Store(true)
+			case stopWatcherAction:
+				iteration = nil
+				timer.Stop()
+				bw.running.Store(false)
+			case terminateWatcherAction:
+				bw.running.Store(false)
+				bw.logger.Info("Watcher loop is terminated")
+				return nil
+			}
+		case <-timer.C:
+			// The watcher can be already stopped while the stop signal is pending
+			if iteration != nil && bw.isRunning() {
+				bw.runIteration(ctx, iteration)
+			}
+			if iteration != nil {
+				resetTimer(timer, interval)
+			}
+		}
+	}
+}
+
+// runIteration runs the iteration with context which is cancelled when the watcher is stopped
+func (bw *backgroundWatcher) runIteration(ctx context.Context, iteration func(ctx context.Context)) {
+	iterationCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	bw.cancelLock.Lock()
+	bw.cancelIteration = cancel
+	bw.cancelLock.Unlock()
+	defer func() {
+		bw.cancelLock.Lock()
+		bw.cancelIteration = nil
+		bw.cancelLock.Unlock()
+	}()
+	iteration(iterationCtx)
+}
+
+// cancel interrupts the iteration in progress if any
+func (bw *backgroundWatcher) cancel() {
+	bw.cancelLock.Lock()
+	defer bw.cancelLock.Unlock()
+	if bw.cancelIteration != nil {
+		bw.cancelIteration()
+	}
+}
+
+// NeedLeaderElection makes the manager start the watcher only on the elected leader
+func (bw *backgroundWatcher) NeedLeaderElection() bool {
+	return true
+}
+
+// run starts the loop or replaces the iteration and interval of the running loop
+func (bw *backgroundWatcher) run(interval time.Duration, iteration func(ctx context.Context)) {
+	bw.send(watcherSignal{action: startWatcherAction, interval: interval, iteration: iteration})
+}
+
+// halt stops the loop and cancels the iteration in progress without waiting for it
+func (bw *backgroundWatcher) halt() {
+	bw.running.Store(false)
+	bw.send(watcherSignal{action: stopWatcherAction})
+	bw.cancel()
+}
+
+// terminate exits from the loop, the watcher can not be started again
+func (bw *backgroundWatcher) terminate() {
+	bw.running.Store(false)
+	bw.send(watcherSignal{action: terminateWatcherAction})
+	bw.cancel()
+}
+
+func (bw *backgroundWatcher) isRunning() bool {
+	return bw.running.Load()
+}
+
+// check returns error if the iteration of the running watcher takes too long
+func (bw *backgroundWatcher) check() error {
+	if !bw.isRunning() {
+		return nil
+	}
+	inactivity := time.Since(time.Unix(0, bw.lastActivity.Load()))
+	if limit := time.Duration(bw.interval.Load()) + watcherStuckTimeout; inactivity > limit {
+		return fmt.Errorf("%s is stuck, the last iteration was started %s ago", bw.name, inactivity.Round(time.Second))
+	}
+	return nil
+}
+
+// send puts the signal for the loop without waiting, the pending signal which is not processed yet is replaced
+// except termination. Signals are skipped if the loop is already finished.
+func (bw *backgroundWatcher) send(signal watcherSignal) {
+	bw.sendLock.Lock()
+	defer bw.sendLock.Unlock()
+	for {
+		select {
+		case <-bw.done:
+			return
+		case bw.signals <- signal:
+			return
+		default:
+		}
+		select {
+		case pending := <-bw.signals:
+			if pending.action == terminateWatcherAction {
+				signal = pending
+			}
+		default:
+		}
+	}
+}
+
+// close marks the loop as finished, it is also used when the watcher is removed before the loop is started
+func (bw *backgroundWatcher) close() {
+	bw.once.Do(func() {
+		close(bw.done)
+	})
+}
+
+// watcherGroup is the single manager runnable which runs loops of background watchers of the same type
+// for all custom resources. Watchers are added and removed with custom resources, so watchers of deleted
+// custom resources are not kept by the manager.
+type watcherGroup struct {
+	name     string
+	lock     sync.Mutex
+	ctx      context.Context
+	watchers map[*backgroundWatcher]struct{}
+}
+
+func newWatcherGroup(name string) *watcherGroup {
+	return &watcherGroup{
+		name:     name,
+		watchers: map[*backgroundWatcher]struct{}{},
+	}
+}
+
+// Start runs loops of added watchers and the ones added later until the context is cancelled
+func (wg *watcherGroup) Start(ctx context.Context) error {
+	wg.lock.Lock()
+	wg.ctx = ctx
+	for watcher := range wg.watchers {
+		go wg.run(ctx, watcher)
+	}
+	wg.lock.Unlock()
+	<-ctx.Done()
+	return nil
+}
+
+// NeedLeaderElection makes the manager start watchers only on the elected leader
+func (wg *watcherGroup) NeedLeaderElection() bool {
+	return true
+}
+
+// add registers the watcher and starts its loop if the group is already started
+func (wg *watcherGroup) add(watcher *backgroundWatcher) {
+	wg.lock.Lock()
+	defer wg.lock.Unlock()
+	wg.watchers[watcher] = struct{}{}
+	if wg.ctx != nil {
+		go wg.run(wg.ctx, watcher)
+	}
+}
+
+// remove terminates the watcher and forgets it, the loop which is not started yet is never started
+func (wg *watcherGroup) remove(watcher *backgroundWatcher) {
+	wg.lock.Lock()
+	delete(wg.watchers, watcher)
+	if wg.ctx == nil {
+		watcher.close()
+	}
+	wg.lock.Unlock()
+	watcher.terminate()
+}
+
+func (wg *watcherGroup) run(ctx context.Context, watcher *backgroundWatcher) {
+	if err := watcher.Start(ctx); err != nil {
+		watcher.logger.Error(err, "Watcher loop is failed")
+	}
+}
+
+// check returns error if any registered watcher is stuck
+func (wg *watcherGroup) check() error {
+	wg.lock.Lock()
+	defer wg.lock.Unlock()
+	for watcher := range wg.watchers {
+		if err := watcher.check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resetTimer(timer *time.Timer, duration time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+	timer.Reset(duration)
+}
@@ -0,0 +1,159 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	watcherTestTimeout = 2 * time.Second
+	watcherTestTick    = 5 * time.Millisecond
+)
+
+// startWatcher runs the loop of the watcher and returns the channel which is closed when the loop is finished
+func startWatcher(ctx context.Context, watcher *backgroundWatcher) chan struct{} {
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		_ = watcher.Start(ctx)
+	}()
+	return finished
+}
+
+func isClosed(channel chan struct{}) bool {
+	select {
+	case <-channel:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestBackgroundWatcherRunsIterationsUntilHalted(t *testing.T) {
+	watcher := newBackgroundWatcher("test-watcher")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	finished := startWatcher(ctx, watcher)
+
+	var iterations atomic.Int32
+	watcher.run(10*time.Millisecond, func(ctx context.Context) {
+		iterations.Add(1)
+	})
+	assert.Eventually(t, func() bool { return iterations.Load() >= 3 }, watcherTestTimeout, watcherTestTick)
+	assert.True(t, watcher.isRunning())
+
+	watcher.halt()
+	assert.False(t, watcher.isRunning())
+	time.Sleep(30 * time.Millisecond)
+	halted := iterations.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, halted, iterations.Load())
+	assert.False(t, isClosed(finished))
+
+	watcher.terminate()
+	assert.Eventually(t, func() bool { return isClosed(finished) }, watcherTestTimeout, watcherTestTick)
+}
+
+func TestBackgroundWatcherReplacesIteration(t *testing.T) {
+	watcher := newBackgroundWatcher("test-watcher")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	startWatcher(ctx, watcher)
+	defer watcher.terminate()
+
+	var first, second atomic.Int32
+	watcher.run(10*time.Millisecond, func(ctx context.Context) {
+		first.Add(1)
+	})
+	assert.Eventually(t, func() bool { return first.Load() > 0 }, watcherTestTimeout, watcherTestTick)
+	watcher.run(10*time.Millisecond, func(ctx context.Context) {
+		second.Add(1)
+	})
+	assert.Eventually(t, func() bool { return second.Load() >= 2 }, watcherTestTimeout, watcherTestTick)
+	replaced := first.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, replaced, first.Load())
+}
+
+func TestBackgroundWatcherCancelsIterationInProgress(t *testing.T) {
+	watcher := newBackgroundWatcher("test-watcher")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	finished := startWatcher(ctx, watcher)
+
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	watcher.run(time.Hour, func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+	})
+	<-started
+	watcher.terminate()
+	assert.Eventually(t, func() bool { return isClosed(cancelled) && isClosed(finished) }, watcherTestTimeout, watcherTestTick)
+}
+
+func TestBackgroundWatcherStopsWithManager(t *testing.T) {
+	watcher := newBackgroundWatcher("test-watcher")
+	ctx, cancel := context.WithCancel(context.Background())
+	finished := startWatcher(ctx, watcher)
+	watcher.run(time.Hour, func(ctx context.Context) {})
+	assert.Eventually(t, watcher.isRunning, watcherTestTimeout, watcherTestTick)
+
+	cancel()
+	assert.Eventually(t, func() bool { return isClosed(finished) }, watcherTestTimeout, watcherTestTick)
+	assert.False(t, watcher.isRunning())
+	// Signals sent after the loop is finished are skipped without waiting
+	watcher.run(time.Hour, func(ctx context.Context) {})
+	watcher.halt()
+	watcher.terminate()
+}
+
+func TestBackgroundWatcherSendKeepsTermination(t *testing.T) {
+	var iterations atomic.Int32
+	watcher := newBackgroundWatcher("test-watcher")
+	watcher.terminate()
+	watcher.run(time.Hour, func(ctx context.Context) {
+		iterations.Add(1)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	finished := startWatcher(ctx, watcher)
+	assert.Eventually(t, func() bool { return isClosed(finished) }, watcherTestTimeout, watcherTestTick)
+	assert.Zero(t, iterations.Load())
+}
+
+func TestBackgroundWatcherCheck(t *testing.T) {
+	watcher := newBackgroundWatcher("test-watcher")
+	assert.NoError(t, watcher.check())
+
+	watcher.running.Store(true)
+	watcher.interval.Store(int64(time.Minute))
+	watcher.lastActivity.Store(time.Now().Add(-5 * time.Minute).UnixNano())
+	assert.NoError(t, watcher.check())
+
+	watcher.lastActivity.Store(time.Now().Add(-time.Minute - watcherStuckTimeout - time.Second).UnixNano())
+	assert.Error(t, watcher.check())
+
+	watcher.running.Store(false)
+	assert.NoError(t, watcher.check())
+}
@@ -0,0 +1,174 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const flatClusterSettingsPath = "_cluster/settings?flat_settings=true"
+
+// reconcileClusterSettings applies persistent cluster settings from the spec, resets settings removed from the spec
+// and reverts settings changed outside of the operator
+func (r OpenSearchReconciler) reconcileClusterSettings(restClient *util.RestClient) error {
+	desired := map[string]interface{}{}
+	for name, value := range r.cr.Spec.OpenSearch.ClusterSettings {
+		var parsed interface{}
+		if err := json.Unmarshal(value.Raw, &parsed); err != nil {
+			return fmt.Errorf("value of [%s] cluster setting is incorrect: %w", name, err)
+		}
+		desired[name] = parsed
+	}
+	specHash, err := util.Hash(desired)
+	if err != nil {
+		return err
+	}
+	previous := r.cr.Status.ClusterSettingsStatus
+	if previous == nil {
+		previous = &opensearchservice.ClusterSettingsStatus{}
+	}
+
+	observed, err := r.getPersistentSettings(restClient)
+	if err != nil {
+		return r.updateClusterSettingsStatus(previous, nil, specHash, err)
+	}
+	settings := map[string]interface{}{}
+	var driftedKeys []string
+	for name, value := range desired {
+		if reflect.DeepEqual(normalizeSettingValue(value), normalizeSettingValue(observed[name])) {
+			continue
+		}
+		settings[name] = value
+		if previous.SpecHash == specHash && previous.Status == managedResourceAppliedStatus {
+			driftedKeys = append(driftedKeys, name)
+		}
+	}
+	for _, name := range previous.AppliedKeys {
+		if _, ok := desired[name]; !ok && observed[name] != nil {
+			settings[name] = nil
+		}
+	}
+	if len(settings) > 0 {
+		if len(driftedKeys) > 0 {
+			sort.Strings(driftedKeys)
+			r.logger.Info(fmt.Sprintf("Cluster settings %v were changed outside of the operator, the changes are reverted", driftedKeys))
+		}
+		body, err := json.Marshal(map[string]interface{}{"persistent": settings})
+		if err != nil {
+			return err
+		}
+		r.logger.Info(fmt.Sprintf("Applying cluster settings: %s", body))
+		if err = r.updateSettings(restClient, strings.NewReader(string(body))); err != nil {
+			return r.updateClusterSettingsStatus(previous, driftedKeys, specHash, err)
+		}
+	}
+	return r.updateClusterSettingsStatus(previous, driftedKeys, specHash, nil)
+}
+
+// getPersistentSettings returns persistent cluster settings with flat names
+func (r OpenSearchReconciler) getPersistentSettings(restClient *util.RestClient) (map[string]interface{}, error) {
+	responseBody, err := restClient.SendRequestWithStatusCodeCheck(http.MethodGet, flatClusterSettingsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	var settings struct {
+		Persistent map[string]interface{} `json:"persistent"`
+	}
+	if err = json.Unmarshal(responseBody, &settings); err != nil {
+		return nil, err
+	}
+	return settings.Persistent, nil
+}
+
+func (r OpenSearchReconciler) updateClusterSettingsStatus(previous *opensearchservice.ClusterSettingsStatus,
+	driftedKeys []string, specHash string, applyErr error) error {
+	status := opensearchservice.ClusterSettingsStatus{
+		Status:             managedResourceAppliedStatus,
+		SpecHash:           specHash,
+		DriftedKeys:        driftedKeys,
+		LastDriftTime:      previous.LastDriftTime,
+		LastTransitionTime: previous.LastTransitionTime,
+	}
+	for name := range r.cr.Spec.OpenSearch.ClusterSettings {
+		status.AppliedKeys = append(status.AppliedKeys, name)
+	}
+	sort.Strings(status.AppliedKeys)
+	if len(driftedKeys) > 0 {
+		now := metav1.Now()
+		status.LastDriftTime = &now
+	}
+	if applyErr != nil {
+		// Keys of previously applied settings are kept to reset them when the cluster is available
+		status.Status = managedResourceFailedStatus
+		status.Message = applyErr.Error()
+		status.SpecHash = previous.SpecHash
+		status.AppliedKeys = mergeSettingKeys(previous.AppliedKeys, status.AppliedKeys)
+	}
+	if status.Status != previous.Status || status.SpecHash != previous.SpecHash {
+		status.LastTransitionTime = metav1.Now()
+	}
+	if err := util.NewStatusUpdater(r.reconciler.Client, r.cr).UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
+		if status.Status == managedResourceAppliedStatus && len(status.AppliedKeys) == 0 {
+			// All settings are removed from the spec and reset, so there is nothing to maintain
+			instance.Status.ClusterSettingsStatus = nil
+			return
+		}
+		instance.Status.ClusterSettingsStatus = &status
+	}); err != nil {
+		return err
+	}
+	return applyErr
+}
+
+// normalizeSettingValue converts setting value to the form returned by OpenSearch where all scalars are strings
+func normalizeSettingValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return typed
+	case []interface{}:
+		normalized := make([]interface{}, 0, len(typed))
+		for _, item := range typed {
+			normalized = append(normalized, normalizeSettingValue(item))
+		}
+		return normalized
+	default:
+		data, _ := json.Marshal(typed)
+		return string(data)
+	}
+}
+
+func mergeSettingKeys(first []string, second []string) []string {
+	keys := map[string]bool{}
+	for _, key := range append(append([]string{}, first...), second...) {
+		keys[key] = true
+	}
+	merged := make([]string, 0, len(keys))
+	for key := range keys {
+		merged = append(merged, key)
+	}
+	sort.Strings(merged)
+	return merged
+}
@@ -0,0 +1,162 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReconcileClusterSettings(t *testing.T) {
+	clusterSettings := map[string]apiextensionsv1.JSON{
+		"cluster.max_shards_per_node":                   {Raw: []byte(`2000`)},
+		"cluster.routing.allocation.disk.watermark.low": {Raw: []byte(`"85%"`)},
+	}
+	specHash, err := util.Hash(map[string]interface{}{
+		"cluster.max_shards_per_node":                   float64(2000),
+		"cluster.routing.allocation.disk.watermark.low": "85%",
+	})
+	assert.NoError(t, err)
+	reducedSpecHash, err := util.Hash(map[string]interface{}{"cluster.max_shards_per_node": float64(2000)})
+	assert.NoError(t, err)
+	appliedKeys := []string{"cluster.max_shards_per_node", "cluster.routing.allocation.disk.watermark.low"}
+	appliedSettings := `{"persistent":{"cluster.max_shards_per_node":"2000","cluster.routing.allocation.disk.watermark.low":"85%"}}`
+
+	tests := []struct {
+		name             string
+		settings         map[string]apiextensionsv1.JSON
+		observed         string
+		putStatusCode    int
+		previous         *opensearchservice.ClusterSettingsStatus
+		expectedRequest  string
+		expectedError    bool
+		expectedStatus   *opensearchservice.ClusterSettingsStatus
+		expectedDrifting bool
+	}{
+		{name: "new settings are applied", settings: clusterSettings, observed: `{"persistent":{}}`,
+			expectedRequest: `{"persistent":{"cluster.max_shards_per_node":2000,"cluster.routing.allocation.disk.watermark.low":"85%"}}`,
+			expectedStatus: &opensearchservice.ClusterSettingsStatus{Status: managedResourceAppliedStatus,
+				AppliedKeys: appliedKeys, SpecHash: specHash}},
+		{name: "applied settings are not sent again", settings: clusterSettings, observed: appliedSettings,
+			previous: &opensearchservice.ClusterSettingsStatus{Status: managedResourceAppliedStatus,
+				AppliedKeys: appliedKeys, SpecHash: specHash},
+			expectedStatus: &opensearchservice.ClusterSettingsStatus{Status: managedResourceAppliedStatus,
+				AppliedKeys: appliedKeys, SpecHash: specHash}},
+		{name: "settings changed outside of the operator are reverted", settings: clusterSettings,
+			observed: `{"persistent":{"cluster.max_shards_per_node":"1000","cluster.routing.allocation.disk.watermark.low":"85%"}}`,
+			previous: &opensearchservice.ClusterSettingsStatus{Status: managedResourceAppliedStatus,
+				AppliedKeys: appliedKeys, SpecHash: specHash},
+			expectedRequest: `{"persistent":{"cluster.max_shards_per_node":2000}}`,
+			expectedStatus: &opensearchservice.ClusterSettingsStatus{Status: managedResourceAppliedStatus,
+				AppliedKeys: appliedKeys, SpecHash: specHash, DriftedKeys: []string{"cluster.max_shards_per_node"}},
+			expectedDrifting: true},
+		{name: "settings removed from the spec are reset",
+			settings: map[string]apiextensionsv1.JSON{"cluster.max_shards_per_node": {Raw: []byte(`2000`)}},
+			observed: appliedSettings,
+			previous: &opensearchservice.ClusterSettingsStatus{Status: managedResourceAppliedStatus,
+				AppliedKeys: appliedKeys, SpecHash: specHash},
+			expectedRequest: `{"persistent":{"cluster.routing.allocation.disk.watermark.low":null}}`,
+			expectedStatus: &opensearchservice.ClusterSettingsStatus{Status: managedResourceAppliedStatus,
+				AppliedKeys: []string{"cluster.max_shards_per_node"}, SpecHash: reducedSpecHash}},
+		{name: "status is removed when all settings are reset", observed: appliedSettings,
+			previous: &opensearchservice.ClusterSettingsStatus{Status: managedResourceAppliedStatus,
+				AppliedKeys: appliedKeys, SpecHash: specHash},
+			expectedRequest: `{"persistent":{"cluster.max_shards_per_node":null,"cluster.routing.allocation.disk.watermark.low":null}}`},
+		{name: "rejected settings keep previously applied keys",
+			settings: map[string]apiextensionsv1.JSON{"cluster.blocks.read_only": {Raw: []byte(`true`)}},
+			observed: appliedSettings, putStatusCode: http.StatusBadRequest,
+			previous: &opensearchservice.ClusterSettingsStatus{Status: managedResourceAppliedStatus,
+				AppliedKeys: appliedKeys, SpecHash: specHash},
+			expectedRequest: `{"persistent":{"cluster.blocks.read_only":true,"cluster.max_shards_per_node":null,"cluster.routing.allocation.disk.watermark.low":null}}`,
+			expectedError:   true,
+			expectedStatus: &opensearchservice.ClusterSettingsStatus{Status: managedResourceFailedStatus,
+				AppliedKeys: append([]string{"cluster.blocks.read_only"}, appliedKeys...), SpecHash: specHash}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			putStatusCode := test.putStatusCode
+			if putStatusCode == 0 {
+				putStatusCode = http.StatusOK
+			}
+			mock := newOpenSearchMock(t).
+				on(http.MethodGet, flatClusterSettingsPath, http.StatusOK, test.observed).
+				on(http.MethodPut, clusterSettingsPath, putStatusCode, `{"acknowledged":true}`)
+			cr := &opensearchservice.OpenSearchService{
+				ObjectMeta: metav1.ObjectMeta{Name: "opensearch", Namespace: "opensearch-service"},
+				Spec: opensearchservice.OpenSearchServiceSpec{
+					OpenSearch: &opensearchservice.OpenSearch{ClusterSettings: test.settings},
+				},
+				Status: opensearchservice.OpenSearchServiceStatus{ClusterSettingsStatus: test.previous},
+			}
+			r := OpenSearchReconciler{
+				cr:         cr,
+				logger:     logr.Discard(),
+				reconciler: &OpenSearchServiceReconciler{Client: newFakeClient(cr)},
+			}
+
+			err := r.reconcileClusterSettings(mock.restClient())
+			assert.Equal(t, test.expectedError, err != nil, err)
+
+			received := mock.received(http.MethodPut, clusterSettingsPath)
+			if test.expectedRequest == "" {
+				assert.Empty(t, received)
+			} else if assert.Len(t, received, 1) {
+				assert.JSONEq(t, test.expectedRequest, received[0])
+			}
+			updated := &opensearchservice.OpenSearchService{}
+			assert.NoError(t, r.reconciler.Client.Get(context.TODO(), client.ObjectKeyFromObject(cr), updated))
+			status := updated.Status.ClusterSettingsStatus
+			if test.expectedStatus == nil {
+				assert.Nil(t, status)
+				return
+			}
+			if assert.NotNil(t, status) {
+				assert.Equal(t, test.expectedStatus.Status, status.Status)
+				assert.Equal(t, test.expectedStatus.AppliedKeys, status.AppliedKeys)
+				assert.Equal(t, test.expectedStatus.DriftedKeys, status.DriftedKeys)
+				assert.Equal(t, test.expectedDrifting, status.LastDriftTime != nil)
+				assert.Equal(t, test.expectedStatus.SpecHash, status.SpecHash)
+				if test.expectedError {
+					assert.NotEmpty(t, status.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeSettingValue(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected interface{}
+	}{
+		{value: nil, expected: nil},
+		{value: "85%", expected: "85%"},
+		{value: float64(2000), expected: "2000"},
+		{value: true, expected: "true"},
+		{value: []interface{}{"_local", float64(1)}, expected: []interface{}{"_local", "1"}},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, normalizeSettingValue(test.value))
+	}
+}
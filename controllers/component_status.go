@@ -0,0 +1,125 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	unknownClusterHealth       = "unknown"
+	statusRefreshPeriodEnvVar  = "STATUS_REFRESH_PERIOD"
+	defaultStatusRefreshPeriod = 300
+)
+
+// ClusterInfo is a part of OpenSearch root endpoint response
+type ClusterInfo struct {
+	Version struct {
+		Number string `json:"number"`
+	} `json:"version"`
+}
+
+// getClusterStatus collects health, version and shard counts of OpenSearch cluster.
+// Unavailable cluster is reported with "unknown" health and the error in the message.
+func getClusterStatus(restClient *util.RestClient) *opensearchservice.ClusterStatus {
+	status := &opensearchservice.ClusterStatus{
+		Health:         unknownClusterHealth,
+		LastUpdateTime: metav1.Now(),
+	}
+	body, err := restClient.SendRequestWithStatusCodeCheck(http.MethodGet, clusterHealthPath, nil)
+	if err != nil {
+		status.Message = fmt.Sprintf("Unable to get cluster health: %v", err)
+		return status
+	}
+	var health OpenSearchHealth
+	if err = json.Unmarshal(body, &health); err != nil {
+		status.Message = fmt.Sprintf("Unable to parse cluster health: %v", err)
+		return status
+	}
+	status.Health = health.Status
+	status.NumberOfNodes = health.NumberOfNodes
+	status.NumberOfDataNodes = health.NumberOfDataNodes
+	status.ActivePrimaryShards = health.ActivePrimaryShards
+	status.ActiveShards = health.ActiveShards
+	status.RelocatingShards = health.RelocatingShards
+	status.InitializingShards = health.InitializingShards
+	status.UnassignedShards = health.UnassignedShards
+
+	body, err = restClient.SendRequestWithStatusCodeCheck(http.MethodGet, "", nil)
+	if err != nil {
+		status.Message = fmt.Sprintf("Unable to get cluster version: %v", err)
+		return status
+	}
+	var info ClusterInfo
+	if err = json.Unmarshal(body, &info); err != nil {
+		status.Message = fmt.Sprintf("Unable to parse cluster version: %v", err)
+		return status
+	}
+	status.Version = info.Version.Number
+	return status
+}
+
+// getComponentStatus returns readiness of component deployment
+func (r *OpenSearchServiceReconciler) getComponentStatus(name string, namespace string,
+	logger logr.Logger) *opensearchservice.ComponentStatus {
+	deployment, err := r.findDeployment(name, namespace, logger)
+	if err != nil {
+		return &opensearchservice.ComponentStatus{Message: fmt.Sprintf("Unable to get deployment: %v", err)}
+	}
+	var replicas int32
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	readyReplicas := util.Min(deployment.Status.ReadyReplicas, deployment.Status.UpdatedReplicas)
+	status := &opensearchservice.ComponentStatus{
+		Ready:         replicas == readyReplicas,
+		Replicas:      replicas,
+		ReadyReplicas: readyReplicas,
+	}
+	if !status.Ready {
+		status.Message = fmt.Sprintf("%d of %d replicas are ready", readyReplicas, replicas)
+	}
+	return status
+}
+
+// refreshStatuses collects status of all components, errors are logged and do not fail reconciliation cycle
+func (r *OpenSearchServiceReconciler) refreshStatuses(cr *opensearchservice.OpenSearchService,
+	reconcilers []ReconcileService, logger logr.Logger) {
+	for _, reconciler := range reconcilers {
+		start := time.Now()
+		err := reconciler.Status()
+		observeReconcile(cr, reconciler, statusPhase, start, err)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Unable to update status of `%T`", reconciler))
+		}
+	}
+}
+
+// getStatusRefreshPeriod returns period in seconds after which status of OpenSearch service is refreshed
+func getStatusRefreshPeriod() int {
+	period, _ := util.GetIntEnvironmentVariable(statusRefreshPeriodEnvVar, defaultStatusRefreshPeriod)
+	if period <= 0 {
+		return defaultStatusRefreshPeriod
+	}
+	return period
+}
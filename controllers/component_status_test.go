@@ -0,0 +1,122 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetClusterStatus(t *testing.T) {
+	const health = `{"status":"yellow","number_of_nodes":3,"number_of_data_nodes":2,"active_primary_shards":10,` +
+		`"active_shards":18,"relocating_shards":1,"initializing_shards":2,"unassigned_shards":3}`
+	tests := []struct {
+		name            string
+		healthCode      int
+		infoCode        int
+		expected        opensearchservice.ClusterStatus
+		expectedMessage string
+	}{
+		{name: "available cluster", healthCode: http.StatusOK, infoCode: http.StatusOK,
+			expected: opensearchservice.ClusterStatus{Health: "yellow", Version: "2.11.0", NumberOfNodes: 3,
+				NumberOfDataNodes: 2, ActivePrimaryShards: 10, ActiveShards: 18, RelocatingShards: 1,
+				InitializingShards: 2, UnassignedShards: 3}},
+		{name: "unavailable cluster", healthCode: http.StatusServiceUnavailable, infoCode: http.StatusOK,
+			expected:        opensearchservice.ClusterStatus{Health: unknownClusterHealth},
+			expectedMessage: "Unable to get cluster health"},
+		{name: "unknown version", healthCode: http.StatusOK, infoCode: http.StatusUnauthorized,
+			expected: opensearchservice.ClusterStatus{Health: "yellow", NumberOfNodes: 3, NumberOfDataNodes: 2,
+				ActivePrimaryShards: 10, ActiveShards: 18, RelocatingShards: 1, InitializingShards: 2, UnassignedShards: 3},
+			expectedMessage: "Unable to get cluster version"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := newOpenSearchMock(t).
+				on(http.MethodGet, clusterHealthPath, test.healthCode, health).
+				on(http.MethodGet, "", test.infoCode, `{"version":{"number":"2.11.0"}}`)
+
+			status := getClusterStatus(mock.restClient())
+			assert.False(t, status.LastUpdateTime.IsZero())
+			assert.True(t, strings.HasPrefix(status.Message, test.expectedMessage), status.Message)
+			status.LastUpdateTime = metav1.Time{}
+			status.Message = ""
+			assert.Equal(t, test.expected, *status)
+		})
+	}
+}
+
+func TestGetComponentStatus(t *testing.T) {
+	deployment := func(replicas int32, readyReplicas int32, updatedReplicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "dashboards", Namespace: "opensearch-service"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: readyReplicas, UpdatedReplicas: updatedReplicas},
+		}
+	}
+	tests := []struct {
+		name       string
+		deployment *appsv1.Deployment
+		expected   opensearchservice.ComponentStatus
+	}{
+		{name: "ready deployment", deployment: deployment(2, 2, 2),
+			expected: opensearchservice.ComponentStatus{Ready: true, Replicas: 2, ReadyReplicas: 2}},
+		{name: "deployment is not ready", deployment: deployment(2, 1, 2),
+			expected: opensearchservice.ComponentStatus{Replicas: 2, ReadyReplicas: 1, Message: "1 of 2 replicas are ready"}},
+		{name: "deployment is being updated", deployment: deployment(2, 2, 1),
+			expected: opensearchservice.ComponentStatus{Replicas: 2, ReadyReplicas: 1, Message: "1 of 2 replicas are ready"}},
+		{name: "scaled down deployment", deployment: deployment(0, 0, 0),
+			expected: opensearchservice.ComponentStatus{Ready: true}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reconciler := &OpenSearchServiceReconciler{Client: newFakeClient(test.deployment)}
+			status := reconciler.getComponentStatus("dashboards", "opensearch-service", logr.Discard())
+			assert.Equal(t, test.expected, *status)
+		})
+	}
+
+	t.Run("deployment does not exist", func(t *testing.T) {
+		reconciler := &OpenSearchServiceReconciler{Client: newFakeClient()}
+		status := reconciler.getComponentStatus("dashboards", "opensearch-service", logr.Discard())
+		assert.False(t, status.Ready)
+		assert.True(t, strings.HasPrefix(status.Message, "Unable to get deployment"), status.Message)
+	})
+}
+
+func TestGetStatusRefreshPeriod(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int
+	}{
+		{value: "60", expected: 60},
+		{value: "", expected: defaultStatusRefreshPeriod},
+		{value: "0", expected: defaultStatusRefreshPeriod},
+		{value: "-10", expected: defaultStatusRefreshPeriod},
+		{value: "5m", expected: defaultStatusRefreshPeriod},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			t.Setenv(statusRefreshPeriodEnvVar, test.value)
+			assert.Equal(t, test.expected, getStatusRefreshPeriod())
+		})
+	}
+}
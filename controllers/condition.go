@@ -16,6 +16,7 @@ package controllers
 
 import (
 	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -36,8 +37,9 @@ func NewCondition(conditionStatus string, conditionType string, conditionReason
 	}
 }
 
-func (r *OpenSearchServiceReconciler) updateConditions(condition opensearchservice.StatusCondition) error {
-	return r.StatusUpdater.UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
+func (r *OpenSearchServiceReconciler) updateConditions(cr *opensearchservice.OpenSearchService,
+	condition opensearchservice.StatusCondition) error {
+	return util.NewStatusUpdater(r.Client, cr).UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
 		currentConditions := instance.Status.Conditions
 		condition.LastTransitionTime = metav1.Now().String()
 		currentConditions = addCondition(currentConditions, condition)
@@ -0,0 +1,55 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestUpdateConditions(t *testing.T) {
+	first := &opensearchservice.OpenSearchService{ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "opensearch-service"}}
+	second := &opensearchservice.OpenSearchService{ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "opensearch-service"}}
+	reconciler := &OpenSearchServiceReconciler{Client: newFakeClient(first, second)}
+
+	// Conditions are written to the custom resource they are passed with, regardless of the order of reconciliations
+	assert.NoError(t, reconciler.updateConditions(first, NewCondition(statusFalse, typeInProgress,
+		opensearchServiceConditionReason, "Reconciliation cycle started")))
+	assert.NoError(t, reconciler.updateConditions(second, NewCondition(statusFalse, typeInProgress,
+		opensearchServiceConditionReason, "Reconciliation cycle started")))
+	assert.NoError(t, reconciler.updateConditions(first, NewCondition(statusTrue, typeSuccessful,
+		opensearchServiceConditionReason, "Reconciliation cycle is successfully finished")))
+
+	for _, expected := range []struct {
+		cr      *opensearchservice.OpenSearchService
+		status  string
+		message string
+	}{
+		{cr: first, status: statusTrue, message: "Reconciliation cycle is successfully finished"},
+		{cr: second, status: statusFalse, message: "Reconciliation cycle started"},
+	} {
+		updated := &opensearchservice.OpenSearchService{}
+		assert.NoError(t, reconciler.Client.Get(context.TODO(), client.ObjectKeyFromObject(expected.cr), updated))
+		if assert.Len(t, updated.Status.Conditions, 1, expected.cr.Name) {
+			assert.Equal(t, expected.status, updated.Status.Conditions[0].Status, expected.cr.Name)
+			assert.Equal(t, expected.message, updated.Status.Conditions[0].Message, expected.cr.Name)
+		}
+	}
+}
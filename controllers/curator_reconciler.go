@@ -16,6 +16,7 @@ package controllers
 
 import (
 	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
 	"github.com/go-logr/logr"
 )
 
@@ -27,6 +28,7 @@ type CuratorReconciler struct {
 	cr         *opensearchservice.OpenSearchService
 	logger     logr.Logger
 	reconciler *OpenSearchServiceReconciler
+	state      *InstanceState
 }
 
 func NewCuratorReconciler(r *OpenSearchServiceReconciler, cr *opensearchservice.OpenSearchService,
@@ -35,6 +37,7 @@ func NewCuratorReconciler(r *OpenSearchServiceReconciler, cr *opensearchservice.
 		cr:         cr,
 		logger:     logger,
 		reconciler: r,
+		state:      r.getState(cr),
 	}
 }
 
@@ -45,10 +48,10 @@ func (r CuratorReconciler) Reconcile() error {
 		return err
 	}
 
-	if r.reconciler.ResourceHashes[opensearchSecretHashName] != "" && r.reconciler.ResourceHashes[opensearchSecretHashName] != opensearchSecretHash ||
-		r.reconciler.ResourceHashes[curatorSecretHashName] != "" && r.reconciler.ResourceHashes[curatorSecretHashName] != curatorSecretHash {
+	if r.state.ResourceHashes[opensearchSecretHashName] != "" && r.state.ResourceHashes[opensearchSecretHashName] != r.state.opensearchSecretHash ||
+		r.state.ResourceHashes[curatorSecretHashName] != "" && r.state.ResourceHashes[curatorSecretHashName] != curatorSecretHash {
 		annotations := map[string]string{
-			opensearchSecretHashName: opensearchSecretHash,
+			opensearchSecretHashName: r.state.opensearchSecretHash,
 			curatorSecretHashName:    curatorSecretHash,
 		}
 
@@ -57,12 +60,15 @@ func (r CuratorReconciler) Reconcile() error {
 		}
 	}
 
-	r.reconciler.ResourceHashes[curatorSecretHashName] = curatorSecretHash
+	r.state.ResourceHashes[curatorSecretHashName] = curatorSecretHash
 	return nil
 }
 
 func (r CuratorReconciler) Status() error {
-	return nil
+	componentStatus := r.reconciler.getComponentStatus(r.cr.Spec.Curator.Name, r.cr.Namespace, r.logger)
+	return util.NewStatusUpdater(r.reconciler.Client, r.cr).UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
+		instance.Status.CuratorStatus = componentStatus
+	})
 }
 
 func (r CuratorReconciler) Configure() error {
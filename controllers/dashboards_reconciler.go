@@ -16,6 +16,7 @@ package controllers
 
 import (
 	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
 	"github.com/go-logr/logr"
 )
 
@@ -27,6 +28,7 @@ type DashboardsReconciler struct {
 	cr         *opensearchservice.OpenSearchService
 	logger     logr.Logger
 	reconciler *OpenSearchServiceReconciler
+	state      *InstanceState
 }
 
 func NewDashboardsReconciler(r *OpenSearchServiceReconciler, cr *opensearchservice.OpenSearchService,
@@ -35,6 +37,7 @@ func NewDashboardsReconciler(r *OpenSearchServiceReconciler, cr *opensearchservi
 		cr:         cr,
 		logger:     logger,
 		reconciler: r,
+		state:      r.getState(cr),
 	}
 }
 
@@ -49,10 +52,10 @@ func (r DashboardsReconciler) Reconcile() error {
 		}
 	}
 
-	if r.reconciler.ResourceHashes[opensearchSecretHashName] != "" && r.reconciler.ResourceHashes[opensearchSecretHashName] != opensearchSecretHash ||
-		r.reconciler.ResourceHashes[dashboardsSecretHashName] != "" && r.reconciler.ResourceHashes[dashboardsSecretHashName] != dashboardsSecretHash {
+	if r.state.ResourceHashes[opensearchSecretHashName] != "" && r.state.ResourceHashes[opensearchSecretHashName] != r.state.opensearchSecretHash ||
+		r.state.ResourceHashes[dashboardsSecretHashName] != "" && r.state.ResourceHashes[dashboardsSecretHashName] != dashboardsSecretHash {
 		annotations := map[string]string{
-			opensearchSecretHashName: opensearchSecretHash,
+			opensearchSecretHashName: r.state.opensearchSecretHash,
 			dashboardsSecretHashName: dashboardsSecretHash,
 		}
 
@@ -61,12 +64,15 @@ func (r DashboardsReconciler) Reconcile() error {
 		}
 	}
 
-	r.reconciler.ResourceHashes[dashboardsSecretHashName] = dashboardsSecretHash
+	r.state.ResourceHashes[dashboardsSecretHashName] = dashboardsSecretHash
 	return nil
 }
 
 func (r DashboardsReconciler) Status() error {
-	return nil
+	componentStatus := r.reconciler.getComponentStatus(r.cr.Spec.Dashboards.Name, r.cr.Namespace, r.logger)
+	return util.NewStatusUpdater(r.reconciler.Client, r.cr).UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
+		instance.Status.DashboardsStatus = componentStatus
+	})
 }
 
 func (r DashboardsReconciler) Configure() error {
@@ -16,6 +16,7 @@ package controllers
 
 import (
 	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
 	"github.com/go-logr/logr"
 )
 
@@ -28,6 +29,7 @@ type DbaasAdapterReconciler struct {
 	cr         *opensearchservice.OpenSearchService
 	logger     logr.Logger
 	reconciler *OpenSearchServiceReconciler
+	state      *InstanceState
 }
 
 func NewDbaasAdapterReconciler(r *OpenSearchServiceReconciler, cr *opensearchservice.OpenSearchService,
@@ -36,6 +38,7 @@ func NewDbaasAdapterReconciler(r *OpenSearchServiceReconciler, cr *opensearchser
 		cr:         cr,
 		logger:     logger,
 		reconciler: r,
+		state:      r.getState(cr),
 	}
 }
 
@@ -45,10 +48,10 @@ func (r DbaasAdapterReconciler) Reconcile() error {
 	if err != nil {
 		return err
 	}
-	if r.reconciler.ResourceHashes[opensearchSecretHashName] != "" && r.reconciler.ResourceHashes[opensearchSecretHashName] != opensearchSecretHash ||
-		r.reconciler.ResourceHashes[dbaasAdapterSecretHashName] != "" && r.reconciler.ResourceHashes[dbaasAdapterSecretHashName] != dbaasAdapterSecretHash {
+	if r.state.ResourceHashes[opensearchSecretHashName] != "" && r.state.ResourceHashes[opensearchSecretHashName] != r.state.opensearchSecretHash ||
+		r.state.ResourceHashes[dbaasAdapterSecretHashName] != "" && r.state.ResourceHashes[dbaasAdapterSecretHashName] != dbaasAdapterSecretHash {
 		annotations := map[string]string{
-			opensearchSecretHashName:   opensearchSecretHash,
+			opensearchSecretHashName:   r.state.opensearchSecretHash,
 			dbaasAdapterSecretHashName: dbaasAdapterSecretHash,
 		}
 
@@ -57,12 +60,15 @@ func (r DbaasAdapterReconciler) Reconcile() error {
 		}
 	}
 
-	r.reconciler.ResourceHashes[dbaasAdapterSecretHashName] = dbaasAdapterSecretHash
+	r.state.ResourceHashes[dbaasAdapterSecretHashName] = dbaasAdapterSecretHash
 	return nil
 }
 
 func (r DbaasAdapterReconciler) Status() error {
-	return nil
+	componentStatus := r.reconciler.getComponentStatus(r.cr.Spec.DbaasAdapter.Name, r.cr.Namespace, r.logger)
+	return util.NewStatusUpdater(r.reconciler.Client, r.cr).UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
+		instance.Status.DbaasAdapterStatus = componentStatus
+	})
 }
 
 func (r DbaasAdapterReconciler) Configure() error {
@@ -15,6 +15,8 @@
 package controllers
 
 import (
+	"context"
+	goerrors "errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -25,7 +27,23 @@ import (
 	"github.com/Netcracker/opensearch-service/disasterrecovery"
 	"github.com/Netcracker/opensearch-service/util"
 	"github.com/go-logr/logr"
-	"k8s.io/apimachinery/pkg/util/wait"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Phases of the switchover which are performed in several reconciliation cycles
+const (
+	// switchoverReplicationRemovalPhase removes the previous replication before the switchover to standby mode
+	switchoverReplicationRemovalPhase = "replicationRemoval"
+	// switchoverReplicationSetupPhase removes indices replicated from the active side
+	switchoverReplicationSetupPhase = "replicationSetup"
+	switchoverReplicationStartPhase = "replicationStart"
+	switchoverReplicationCheckPhase = "replicationCheck"
+	// switchoverReplicationStopPhase stops the replication during the switchover to active or disable mode,
+	// switchoverReplicationVerificationPhase also checks that all indices are replicated before it
+	switchoverReplicationStopPhase         = "replicationStop"
+	switchoverReplicationVerificationPhase = "replicationVerification"
+	switchoverUsersRecoveryPhase           = "usersRecovery"
 )
 
 const (
@@ -39,14 +57,23 @@ const (
 	usersRecoveryFailedState    = "failed"
 	usersRecoveryIdleState      = "idle"
 	usersRecoveryRunningState   = "running"
-	opensearchGKEServiceEnvVar  = "OPENSEARCH_GKE_SERVICE"
+	usersRecoveryCheckInterval  = 5 * time.Second
+	// clientServicesDrainPeriod is the time for clients to disconnect after client services are disabled
+	clientServicesDrainPeriod = 2 * time.Second
+	// replicationStartDelay is the time for OpenSearch to remove indices before the replication is started
+	replicationStartDelay = 2 * time.Second
+	// replicationCheckPeriod is the time for replicated indices to be synchronized before the switchover to active mode
+	replicationCheckPeriod     = replicationAttemptsNumber * replicationCheckTimeout
+	replicationVerifiedMessage = "All indices are replicated, stopping the replication"
+	opensearchGKEServiceEnvVar = "OPENSEARCH_GKE_SERVICE"
 )
 
 type DisasterRecoveryReconciler struct {
 	cr                       *opensearchservice.OpenSearchService
 	logger                   logr.Logger
 	reconciler               *OpenSearchServiceReconciler
-	replicationWatcher       ReplicationWatcher
+	state                    *InstanceState
+	replicationWatcher       *ReplicationWatcher
 	opensearchGKEServiceName string
 }
 
@@ -64,11 +91,13 @@ type LeaderStats struct {
 
 func NewDisasterRecoveryReconciler(r *OpenSearchServiceReconciler, cr *opensearchservice.OpenSearchService,
 	logger logr.Logger) DisasterRecoveryReconciler {
+	state := r.getState(cr)
 	return DisasterRecoveryReconciler{
 		cr:                       cr,
 		logger:                   logger,
 		reconciler:               r,
-		replicationWatcher:       r.ReplicationWatcher,
+		state:                    state,
+		replicationWatcher:       state.ReplicationWatcher,
 		opensearchGKEServiceName: os.Getenv(opensearchGKEServiceEnvVar),
 	}
 }
@@ -78,128 +107,348 @@ func (r DisasterRecoveryReconciler) Reconcile() error {
 }
 
 func (r DisasterRecoveryReconciler) Status() error {
-	return nil
+	replicationHealth := ""
+	if r.cr.Spec.DisasterRecovery.Mode == "standby" &&
+		r.cr.Status.DisasterRecoveryStatus.Mode == "standby" &&
+		r.cr.Status.DisasterRecoveryStatus.Status == "done" {
+		replicationManager := r.getReplicationManager()
+		replicationChecker := disasterrecovery.NewReplicationCheckerWithClient(replicationManager.restClient)
+		var err error
+		replicationHealth, err = replicationChecker.CheckReplication(context.TODO())
+		if err != nil {
+			r.logger.Error(err, "Unable to get replication state")
+			replicationHealth = disasterrecovery.DOWN
+		}
+	}
+	statusUpdater := util.NewStatusUpdater(r.reconciler.Client, r.cr)
+	return statusUpdater.UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
+		instance.Status.DisasterRecoveryStatus.ReplicationHealth = replicationHealth
+	})
 }
 
 func (r DisasterRecoveryReconciler) Configure() error {
-	crCondition := r.cr.Spec.DisasterRecovery.Mode != r.cr.Status.DisasterRecoveryStatus.Mode ||
-		r.cr.Status.DisasterRecoveryStatus.Status == "running" ||
-		r.cr.Status.DisasterRecoveryStatus.Status == "failed" ||
-		r.cr.Status.DisasterRecoveryStatus.Status == "queue"
+	drStatus := r.cr.Status.DisasterRecoveryStatus
+	// The switchover which waits for replication or users recovery is continued from the persisted phase
+	inProgress := drStatus.Status == "running" && drStatus.Phase != "" &&
+		r.cr.Spec.DisasterRecovery.Mode == drStatus.Mode
+	crCondition := r.cr.Spec.DisasterRecovery.Mode != drStatus.Mode ||
+		drStatus.Status == "running" ||
+		drStatus.Status == "failed" ||
+		drStatus.Status == "queue"
 
 	drConfigHash, err :=
 		r.reconciler.calculateConfigDataHash(r.cr.Spec.DisasterRecovery.ConfigMapName, drConfigHashName, r.cr, r.logger)
 	if err != nil {
 		return err
 	}
-	drConfigHashChanged := r.reconciler.ResourceHashes[drConfigHashName] != "" && r.reconciler.ResourceHashes[drConfigHashName] != drConfigHash
+	drConfigHashChanged := r.state.ResourceHashes[drConfigHashName] != "" && r.state.ResourceHashes[drConfigHashName] != drConfigHash
 
-	message := ""
-	usersRecoveryState := usersRecoveryDoneState
-
-	defer func() {
-		status := "done"
-		if err != nil {
-			status = "failed"
-			message = fmt.Sprintf("Error occurred during OpenSearch switching: %v", err)
-		}
-		_ = r.updateDisasterRecoveryStatus(status, message, usersRecoveryState)
+	if inProgress {
+		err = r.continueSwitchover()
+	} else if crCondition || drConfigHashChanged {
+		err = r.startSwitchover(crCondition)
+	} else {
+		_ = r.updateDisasterRecoveryStatus("done", "", usersRecoveryDoneState)
 		if r.cr.Spec.DisasterRecovery.Mode == "active" {
 			_ = r.enableClientServices()
 		}
-		r.logger.Info("Disaster recovery status was updated.")
-	}()
+	}
+	r.state.ResourceHashes[drConfigHashName] = drConfigHash
 
-	needReturnError := true
-	if crCondition || drConfigHashChanged {
+	var requeueError RequeueError
+	if goerrors.As(err, &requeueError) {
+		return err
+	}
+	if r.cr.Spec.DisasterRecovery.ReplicationWatcherEnabled {
+		r.replicationWatcher.start(r, r.logger)
+	} else {
 		r.replicationWatcher.pause(r.logger)
-		r.replicationWatcher.Lock.Lock()
-		defer r.replicationWatcher.Lock.Unlock()
-		checkNeeded := isReplicationCheckNeeded(r.cr)
-		usersRecoveryState = r.cr.Status.DisasterRecoveryStatus.UsersRecoveryState
-		if usersRecoveryState != usersRecoveryRunningState {
-			usersRecoveryState = usersRecoveryIdleState
+	}
+	return err
+}
+
+// startSwitchover switches OpenSearch to the mode from the spec. Client services are disabled here,
+// other steps are performed in the phases the switchover is moved to.
+func (r DisasterRecoveryReconciler) startSwitchover(crCondition bool) error {
+	r.replicationWatcher.pause(r.logger)
+	r.replicationWatcher.Lock.Lock()
+	defer r.replicationWatcher.Lock.Unlock()
+
+	previousMode := r.cr.Status.DisasterRecoveryStatus.Mode
+	checkNeeded := isReplicationCheckNeeded(r.cr)
+	usersRecoveryState := r.cr.Status.DisasterRecoveryStatus.UsersRecoveryState
+	// The idle state marks that users recovery is required after the switchover to active mode
+	usersRecoveryRequired := crCondition && r.cr.Spec.DbaasAdapter != nil
+	if usersRecoveryRequired && usersRecoveryState != usersRecoveryRunningState {
+		usersRecoveryState = usersRecoveryIdleState
+	} else if !usersRecoveryRequired &&
+		(usersRecoveryState == usersRecoveryIdleState || usersRecoveryState == usersRecoveryRunningState) {
+		usersRecoveryState = usersRecoveryDoneState
+	}
+	now := metav1.Now()
+	if err := r.updateSwitchoverStatus(func(status *opensearchservice.DisasterRecoveryStatus) {
+		status.Mode = r.cr.Spec.DisasterRecovery.Mode
+		status.Status = "running"
+		status.Message = "The switchover process for OpenSearch has been started"
+		status.Phase = ""
+		status.StartTime = &now
+		status.PhaseStartTime = nil
+		status.ReplicationCheckpoints = nil
+		if r.cr.Spec.DbaasAdapter != nil {
+			status.UsersRecoveryState = usersRecoveryState
 		}
-		if err = r.updateDisasterRecoveryStatus("running",
-			"The switchover process for OpenSearch has been started", usersRecoveryState); err != nil {
-			return err
+	}); err != nil {
+		return r.finishSwitchover(err, "", usersRecoveryDoneState)
+	}
+	r.reconciler.recordEvent(r.cr, corev1.EventTypeNormal, switchoverStartedReason,
+		"Switchover to %s mode is started", r.cr.Spec.DisasterRecovery.Mode)
+
+	if err := r.disableClientServices(); err != nil {
+		return r.finishSwitchover(err, "", usersRecoveryDoneState)
+	}
+
+	phase := switchoverReplicationStopPhase
+	if r.cr.Spec.DisasterRecovery.Mode == "standby" {
+		phase = switchoverReplicationSetupPhase
+		if previousMode != "active" {
+			phase = switchoverReplicationRemovalPhase
 		}
-		usersRecoveryState = usersRecoveryDoneState
+	} else if checkNeeded {
+		phase = switchoverReplicationVerificationPhase
+	}
+	return r.moveSwitchoverToPhase(phase, "Client services are disabled", usersRecoveryState)
+}
 
-		if err = r.disableClientServices(); err != nil {
-			return err
+// continueSwitchover performs one step of the switchover phase persisted in the status
+func (r DisasterRecoveryReconciler) continueSwitchover() error {
+	r.replicationWatcher.Lock.Lock()
+	defer r.replicationWatcher.Lock.Unlock()
+	switch phase := r.cr.Status.DisasterRecoveryStatus.Phase; phase {
+	case switchoverReplicationRemovalPhase, switchoverReplicationSetupPhase:
+		return r.setUpReplication()
+	case switchoverReplicationStartPhase:
+		return r.startReplication()
+	case switchoverReplicationCheckPhase:
+		return r.checkReplicationHealth()
+	case switchoverReplicationVerificationPhase:
+		return r.verifyReplication()
+	case switchoverReplicationStopPhase:
+		return r.stopReplicationForSwitchover()
+	case switchoverUsersRecoveryPhase:
+		return r.recoverUsers()
+	default:
+		return r.finishSwitchover(fmt.Errorf("unknown switchover phase [%s]", phase), "", usersRecoveryDoneState)
+	}
+}
+
+// setUpReplication removes the previous replication if it is required and indices replicated from the active side
+// during the switchover to standby mode. The replication is started in the next phase when indices are removed.
+func (r DisasterRecoveryReconciler) setUpReplication() error {
+	if err := r.delaySwitchoverPhase(clientServicesDrainPeriod); err != nil {
+		return err
+	}
+	replicationManager := r.getReplicationManager()
+	if r.cr.Status.DisasterRecoveryStatus.Phase == switchoverReplicationRemovalPhase {
+		r.logger.Info("Removing previous replication rule")
+		if err := r.removePreviousReplication(replicationManager); err != nil {
+			return r.finishSwitchover(err, "", usersRecoveryDoneState)
 		}
-		time.Sleep(time.Second * 2)
+	}
+	// If there is no connection with other side, then don't return err to avoid reconcile re-calling
+	if err := r.checkConnectionWithOtherSide(); err != nil {
+		_ = r.finishSwitchover(err, "", usersRecoveryDoneState)
+		return nil
+	}
+	r.logger.Info("Delete replication indices")
+	if err := replicationManager.DeleteIndices(); err != nil {
+		r.logger.Error(err, "can not delete OpenSearch indices by pattern during switchover process to `standby` state.")
+		return r.finishSwitchover(err, "", usersRecoveryDoneState)
+	}
+	return r.moveSwitchoverToPhase(switchoverReplicationStartPhase, "Replicated indices are removed", usersRecoveryDoneState)
+}
 
-		replicationManager := r.getReplicationManager()
-		if r.cr.Spec.DisasterRecovery.Mode == "standby" {
-			message = "The replication has started successfully"
-			if r.cr.Status.DisasterRecoveryStatus.Mode != "active" {
-				r.logger.Info("Removing previous replication rule")
-				err = r.removePreviousReplication(replicationManager)
-			}
-			if err == nil {
-				// If there is no connection with other side, then don't return err to avoid reconcile re-calling
-				if err = r.checkConnectionWithOtherSide(); err != nil {
-					needReturnError = false
-				}
-			}
-			if err == nil {
-				err = r.runReplicationProcess(replicationManager)
-			}
-			if err == nil {
-				err = r.checkReplication(replicationManager.restClient)
-			}
+// startReplication starts the replication from the active side and moves the switchover to the health check
+func (r DisasterRecoveryReconciler) startReplication() error {
+	if err := r.delaySwitchoverPhase(replicationStartDelay); err != nil {
+		return err
+	}
+	if err := r.configureReplication(r.getReplicationManager()); err != nil {
+		return r.finishSwitchover(err, "", usersRecoveryDoneState)
+	}
+	return r.moveSwitchoverToPhase(switchoverReplicationCheckPhase,
+		"The replication has been started, waiting for it to become healthy", usersRecoveryDoneState)
+}
+
+// verifyReplication checks once per reconciliation cycle that all replicated indices are synchronized
+// with the active side, leader checkpoints of indices in progress are kept in the status between checks
+func (r DisasterRecoveryReconciler) verifyReplication() error {
+	if err := r.delaySwitchoverPhase(clientServicesDrainPeriod); err != nil {
+		return err
+	}
+	replicationManager := r.getReplicationManager()
+	inProgressIndices := r.cr.Status.DisasterRecoveryStatus.ReplicationCheckpoints
+	var err error
+	if inProgressIndices == nil {
+		if err = r.replicationWatcher.checkReplication(r, true, r.logger); err != nil {
+			return r.finishSwitchover(err, "", usersRecoveryDoneState)
 		}
+		r.logger.Info("Start replication check")
+		var indexNames []string
+		if indexNames, err = replicationManager.getReplicatedIndices(); err != nil {
+			r.logger.Error(err, "Can not get replication indices. Replication check is failed.")
+			return r.finishSwitchover(err, "", usersRecoveryDoneState)
+		}
+		inProgressIndices, err = replicationManager.getInProgressIndices(indexNames)
+	} else {
+		inProgressIndices, err = replicationManager.updateInProgressIndices(inProgressIndices)
+	}
+	if err != nil {
+		r.logger.Error(err, "Replication check is failed.")
+		return r.finishSwitchover(err, "", usersRecoveryDoneState)
+	}
+	if len(inProgressIndices) == 0 {
+		r.logger.Info("Replication check is done")
+		return r.moveSwitchoverToPhase(switchoverReplicationStopPhase, replicationVerifiedMessage,
+			r.cr.Status.DisasterRecoveryStatus.UsersRecoveryState)
+	}
+	r.logger.Info(fmt.Sprintf("The rest replicated indices in progress are [%v]", inProgressIndices))
+	if phaseStartTime := r.cr.Status.DisasterRecoveryStatus.PhaseStartTime; phaseStartTime == nil ||
+		time.Since(phaseStartTime.Time) > replicationCheckPeriod {
+		return r.finishSwitchover(fmt.Errorf("replication check was failed, indices are not replicated within %s",
+			replicationCheckPeriod), "", usersRecoveryDoneState)
+	}
+	if err = r.updateSwitchoverStatus(func(status *opensearchservice.DisasterRecoveryStatus) {
+		status.ReplicationCheckpoints = inProgressIndices
+	}); err != nil {
+		return r.finishSwitchover(err, "", usersRecoveryDoneState)
+	}
+	return r.requeueSwitchover(replicationCheckTimeout)
+}
 
-		if r.cr.Spec.DisasterRecovery.Mode == "active" || r.cr.Spec.DisasterRecovery.Mode == "disable" {
-			message = "The replication has stopped successfully"
-			err = r.replicationWatcher.checkReplication(r, true, r.logger)
-			if err == nil && checkNeeded {
-				var indexNames []string
-				indexNames, err = replicationManager.getReplicatedIndices()
-				if err != nil {
-					r.logger.Error(err, "Can not get replication indices. Replication check is failed.")
-				}
-				r.logger.Info("Start replication check")
-				if err = replicationManager.executeReplicationCheck(indexNames); err != nil {
-					r.logger.Error(err, "Replication check is failed.")
-				}
-			} else {
-				message = "Switchover mode has been changed without replication check"
-			}
+// stopReplicationForSwitchover stops the replication during the switchover to active or disable mode,
+// all indices are checked to be replicated before it in "replicationVerification" phase
+func (r DisasterRecoveryReconciler) stopReplicationForSwitchover() error {
+	if err := r.delaySwitchoverPhase(clientServicesDrainPeriod); err != nil {
+		return err
+	}
+	replicationManager := r.getReplicationManager()
+	var err error
+	message := "The replication has stopped successfully"
+	if r.cr.Status.DisasterRecoveryStatus.Message != replicationVerifiedMessage {
+		message = "Switchover mode has been changed without replication check"
+		err = r.replicationWatcher.checkReplication(r, true, r.logger)
+	}
+	if err == nil {
+		err = r.stopReplication(replicationManager)
+	}
+	if r.cr.Spec.DisasterRecovery.Mode == "active" {
+		if err == nil {
+			err = r.enableClientServices()
+		}
+		usersRecoveryState := r.cr.Status.DisasterRecoveryStatus.UsersRecoveryState
+		if err == nil && r.cr.Spec.DbaasAdapter != nil &&
+			(usersRecoveryState == usersRecoveryIdleState || usersRecoveryState == usersRecoveryRunningState) {
+			// DBaaS adapter is waited for in the users recovery phase
+			err = r.reconciler.scaleDeployment(r.cr.Spec.DbaasAdapter.Name, r.cr.Namespace, 1, r.logger)
 			if err == nil {
-				err = r.stopReplication(replicationManager)
-			}
-			if r.cr.Spec.DisasterRecovery.Mode == "active" {
-				if err == nil {
-					err = r.enableClientServices()
-				}
-				if err == nil && crCondition && r.cr.Spec.DbaasAdapter != nil {
-					err = r.reconciler.scaleDeploymentForNoWait(r.cr.Spec.DbaasAdapter.Name, r.cr.Namespace, 1, false, r.logger)
-					if err == nil {
-						r.logger.Info("Start users recovery")
-						usersRecoveryState, err = r.recoverUsers()
-					}
-				}
+				r.logger.Info("Start users recovery")
+				return r.moveSwitchoverToPhase(switchoverUsersRecoveryPhase, message, usersRecoveryState)
 			}
 		}
 	}
+	return r.finishSwitchover(err, message, usersRecoveryDoneState)
+}
 
-	r.reconciler.ResourceHashes[drConfigHashName] = drConfigHash
+// moveSwitchoverToPhase persists the phase the switchover waits for and requeues the reconciliation
+func (r DisasterRecoveryReconciler) moveSwitchoverToPhase(phase string, message string, usersRecoveryState string) error {
+	now := metav1.Now()
+	if err := r.updateSwitchoverStatus(func(status *opensearchservice.DisasterRecoveryStatus) {
+		status.Phase = phase
+		status.PhaseStartTime = &now
+		status.Message = message
+		status.ReplicationCheckpoints = nil
+		if r.cr.Spec.DbaasAdapter != nil {
+			status.UsersRecoveryState = usersRecoveryState
+		}
+	}); err != nil {
+		return r.finishSwitchover(err, "", usersRecoveryDoneState)
+	}
+	return r.requeueSwitchover(time.Second)
+}
 
-	if r.cr.Spec.DisasterRecovery.ReplicationWatcherEnabled {
-		r.replicationWatcher.start(r, r.logger)
-	} else {
-		r.replicationWatcher.pause(r.logger)
+// requeueSwitchover returns error to repeat the reconciliation while the switchover phase is not finished
+func (r DisasterRecoveryReconciler) requeueSwitchover(after time.Duration) error {
+	return RequeueError{
+		After: after,
+		Message: fmt.Sprintf("switchover to %s mode is in [%s] phase", r.cr.Spec.DisasterRecovery.Mode,
+			r.cr.Status.DisasterRecoveryStatus.Phase),
 	}
+}
 
-	if needReturnError {
-		return err
+// delaySwitchoverPhase returns error to requeue the reconciliation if the current phase is started
+// less than the delay ago
+func (r DisasterRecoveryReconciler) delaySwitchoverPhase(delay time.Duration) error {
+	phaseStartTime := r.cr.Status.DisasterRecoveryStatus.PhaseStartTime
+	if phaseStartTime == nil {
+		return nil
+	}
+	if remaining := delay - time.Since(phaseStartTime.Time); remaining > 0 {
+		return r.requeueSwitchover(remaining)
 	}
 	return nil
 }
 
+// isSwitchoverPhaseExpired returns true if the current switchover phase lasts longer than the timeout
+func (r DisasterRecoveryReconciler) isSwitchoverPhaseExpired() bool {
+	phaseStartTime := r.cr.Status.DisasterRecoveryStatus.PhaseStartTime
+	return phaseStartTime == nil || time.Since(phaseStartTime.Time) > timeout
+}
+
+// finishSwitchover sets the final status of the switchover and returns the error it is failed with.
+// RequeueError keeps the current phase running until the phase timeout is exceeded.
+func (r DisasterRecoveryReconciler) finishSwitchover(err error, message string, usersRecoveryState string) error {
+	var requeueError RequeueError
+	if goerrors.As(err, &requeueError) {
+		if !r.isSwitchoverPhaseExpired() {
+			return err
+		}
+		err = fmt.Errorf("switchover phase [%s] is not finished within %s: %s",
+			r.cr.Status.DisasterRecoveryStatus.Phase, timeout, requeueError.Message)
+	}
+	status := "done"
+	if err != nil {
+		status = "failed"
+		message = fmt.Sprintf("Error occurred during OpenSearch switching: %v", err)
+		r.reconciler.recordEvent(r.cr, corev1.EventTypeWarning, switchoverFailedReason,
+			"Switchover to %s mode is failed: %v", r.cr.Spec.DisasterRecovery.Mode, err)
+	} else {
+		if message == "" {
+			message = r.cr.Status.DisasterRecoveryStatus.Message
+		}
+		r.reconciler.recordEvent(r.cr, corev1.EventTypeNormal, switchoverSucceededReason,
+			"Switchover to %s mode is finished: %s", r.cr.Spec.DisasterRecovery.Mode, message)
+	}
+	if startTime := r.cr.Status.DisasterRecoveryStatus.StartTime; startTime != nil {
+		recordSwitchoverDuration(r.cr, startTime.Time)
+	}
+	_ = r.updateSwitchoverStatus(func(drStatus *opensearchservice.DisasterRecoveryStatus) {
+		drStatus.Mode = r.cr.Spec.DisasterRecovery.Mode
+		drStatus.Status = status
+		drStatus.Message = message
+		drStatus.Phase = ""
+		drStatus.PhaseStartTime = nil
+		drStatus.ReplicationCheckpoints = nil
+		if r.cr.Spec.DbaasAdapter != nil {
+			drStatus.UsersRecoveryState = usersRecoveryState
+		}
+	})
+	if r.cr.Spec.DisasterRecovery.Mode == "active" {
+		_ = r.enableClientServices()
+	}
+	r.logger.Info("Disaster recovery status was updated.")
+	return err
+}
+
 func (r DisasterRecoveryReconciler) enableClientServices() error {
 	r.logger.Info("Enable client service")
 	if err := r.reconciler.enableClientService(r.cr.Name, r.cr.Namespace, r.logger); err != nil {
@@ -230,23 +479,35 @@ func (r DisasterRecoveryReconciler) disableClientServices() error {
 
 // updateDisasterRecoveryStatus updates state of Disaster Recovery switchover
 func (r DisasterRecoveryReconciler) updateDisasterRecoveryStatus(status string, message string, usersRecoveryState string) error {
-	statusUpdater := util.NewStatusUpdater(r.reconciler.Client, r.cr)
-	return statusUpdater.UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
-		instance.Status.DisasterRecoveryStatus.Mode = r.cr.Spec.DisasterRecovery.Mode
-		instance.Status.DisasterRecoveryStatus.Status = status
+	return r.updateSwitchoverStatus(func(drStatus *opensearchservice.DisasterRecoveryStatus) {
+		drStatus.Mode = r.cr.Spec.DisasterRecovery.Mode
+		drStatus.Status = status
 		if message != "" {
-			instance.Status.DisasterRecoveryStatus.Message = message
+			drStatus.Message = message
 		}
 		if r.cr.Spec.DbaasAdapter != nil {
-			instance.Status.DisasterRecoveryStatus.UsersRecoveryState = usersRecoveryState
+			drStatus.UsersRecoveryState = usersRecoveryState
 		}
 	})
 }
 
 func (r DisasterRecoveryReconciler) updateUsersRecoveryStatus(state string) error {
+	return r.updateSwitchoverStatus(func(drStatus *opensearchservice.DisasterRecoveryStatus) {
+		drStatus.UsersRecoveryState = state
+	})
+}
+
+// updateSwitchoverStatus applies the change to Disaster Recovery status of the custom resource
+// and to the local copy used during the switchover
+func (r DisasterRecoveryReconciler) updateSwitchoverStatus(change func(drStatus *opensearchservice.DisasterRecoveryStatus)) error {
+	change(&r.cr.Status.DisasterRecoveryStatus)
+	recordDisasterRecoveryMetrics(r.cr, r.cr.Status.DisasterRecoveryStatus.Status)
+	if r.cr.Spec.DbaasAdapter != nil {
+		recordUsersRecoveryState(r.cr, r.cr.Status.DisasterRecoveryStatus.UsersRecoveryState)
+	}
 	statusUpdater := util.NewStatusUpdater(r.reconciler.Client, r.cr)
-	return statusUpdater.UpdateStatusWithRetry(func(cr *opensearchservice.OpenSearchService) {
-		cr.Status.DisasterRecoveryStatus.UsersRecoveryState = state
+	return statusUpdater.UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
+		change(&instance.Status.DisasterRecoveryStatus)
 	})
 }
 
@@ -277,13 +538,20 @@ func (r DisasterRecoveryReconciler) removePreviousReplication(replicationManager
 	return nil
 }
 
+// runReplicationProcess removes replicated indices and starts the replication again,
+// it is used by the replication watcher outside of reconciliation cycles
 func (r DisasterRecoveryReconciler) runReplicationProcess(replicationManager ReplicationManager) error {
 	r.logger.Info("Delete replication indices")
 	if err := replicationManager.DeleteIndices(); err != nil {
 		r.logger.Error(err, "can not delete OpenSearch indices by pattern during switchover process to `standby` state.")
 		return err
 	}
-	time.Sleep(time.Second * 2)
+	time.Sleep(replicationStartDelay)
+	return r.configureReplication(replicationManager)
+}
+
+// configureReplication configures the connection with the active side and creates autofollow replication rule
+func (r DisasterRecoveryReconciler) configureReplication(replicationManager ReplicationManager) error {
 	r.logger.Info("Configure replication connection between clusters")
 	if err := replicationManager.Configure(); err != nil {
 		r.logger.Error(err, "can not configure replication connection between DR OpenSearch clusters.")
@@ -298,22 +566,24 @@ func (r DisasterRecoveryReconciler) runReplicationProcess(replicationManager Rep
 	return nil
 }
 
-func (r DisasterRecoveryReconciler) checkReplication(restClient util.RestClient) error {
-	replicationChecker := disasterrecovery.NewReplicationCheckerWithClient(restClient)
-	err := wait.Poll(interval, timeout, func() (bool, error) {
-		status, err := replicationChecker.CheckReplication()
-		if err != nil {
-			r.logger.Error(err, "Unable to get replication state")
-			return false, nil
-		}
-		if status != disasterrecovery.UP {
-			r.logger.Info("Replication is not healthy yet")
-			return false, nil
-		}
+// checkReplicationHealth checks once whether the replication started during the switchover to standby mode is healthy
+func (r DisasterRecoveryReconciler) checkReplicationHealth() error {
+	replicationChecker := disasterrecovery.NewReplicationCheckerWithClient(r.getReplicationManager().restClient)
+	status, err := replicationChecker.CheckReplication(context.TODO())
+	if err == nil && status == disasterrecovery.UP {
 		r.logger.Info("Replication is healthy")
-		return true, nil
-	})
-	return err
+		return r.finishSwitchover(nil, "The replication has started successfully", usersRecoveryDoneState)
+	}
+	if err != nil {
+		r.logger.Error(err, "Unable to get replication state")
+	} else {
+		r.logger.Info("Replication is not healthy yet")
+	}
+	if r.isSwitchoverPhaseExpired() {
+		return r.finishSwitchover(fmt.Errorf("replication is not healthy after %s", timeout), "",
+			usersRecoveryDoneState)
+	}
+	return r.requeueSwitchover(interval)
 }
 
 func (r DisasterRecoveryReconciler) stopReplication(replicationManager ReplicationManager) error {
@@ -327,9 +597,9 @@ func (r DisasterRecoveryReconciler) stopReplication(replicationManager Replicati
 	return nil
 }
 
-func (r DisasterRecoveryReconciler) recoverUsers() (string, error) {
-	aggregatorRestClient := r.buildAggregatorRestClient()
-	adapterRestClient := r.buildAdapterRestClient()
+// recoverUsers performs one step of users recovery: requests the recovery from DBaaS aggregator in "idle" state
+// and checks the state of the recovery in DBaaS adapter in "running" state
+func (r DisasterRecoveryReconciler) recoverUsers() error {
 	data := fmt.Sprintf(`{
 		"physicalDbId": "%s",
 		"type": "opensearch",
@@ -337,58 +607,69 @@ func (r DisasterRecoveryReconciler) recoverUsers() (string, error) {
 	}`, r.cr.Spec.DbaasAdapter.PhysicalDatabaseIdentifier)
 
 	state := r.cr.Status.DisasterRecoveryStatus.UsersRecoveryState
-	if state == "" {
-		r.logger.Info("Users recovery is not run during installation")
-		return usersRecoveryDoneState, nil
-	}
-	if state != usersRecoveryRunningState {
-		state = usersRecoveryIdleState
-	}
-	for state != usersRecoveryDoneState && state != usersRecoveryFailedState {
-		if state == usersRecoveryIdleState {
-			err := wait.PollImmediate(interval, timeout, func() (bool, error) {
-				statusCode, response, err := aggregatorRestClient.SendRequest(http.MethodPost,
-					"api/v3/dbaas/internal/physical_databases/users/restore-password", strings.NewReader(data))
-				if err != nil || statusCode != http.StatusOK {
-					r.logger.Error(err, fmt.Sprintf("Unable to restore user passwords via DBaaS aggregator: [%d] %s",
-						statusCode, string(response)))
-					return false, nil
-				}
-				return true, nil
-			})
-			if err != nil {
-				state = usersRecoveryFailedState
-				continue
-			}
-			if err = r.updateUsersRecoveryStatus(usersRecoveryRunningState); err != nil {
-				return usersRecoveryRunningState, err
+	if state == usersRecoveryIdleState {
+		if !r.reconciler.isDeploymentReady(r.cr.Spec.DbaasAdapter.Name, r.cr.Namespace, r.logger) {
+			r.logger.Info("Waiting for DBaaS adapter to become ready before users recovery")
+			if r.isSwitchoverPhaseExpired() {
+				return r.finishUsersRecovery(usersRecoveryFailedState)
 			}
+			return r.requeueSwitchover(interval)
 		}
-		time.Sleep(time.Second * 5)
-		statusCode, response, err := adapterRestClient.SendRequest(http.MethodGet,
-			"api/v2/dbaas/adapter/opensearch/users/restore-password/state", nil)
+		statusCode, response, err := r.buildAggregatorRestClient().SendRequest(http.MethodPost,
+			"api/v3/dbaas/internal/physical_databases/users/restore-password", strings.NewReader(data))
 		if err != nil || statusCode != http.StatusOK {
-			r.logger.Error(err, fmt.Sprintf("Unable to get state of procedure: %s", string(response)))
-			continue
+			r.logger.Error(err, fmt.Sprintf("Unable to restore user passwords via DBaaS aggregator: [%d] %s",
+				statusCode, string(response)))
+			if r.isSwitchoverPhaseExpired() {
+				return r.finishUsersRecovery(usersRecoveryFailedState)
+			}
+			return r.requeueSwitchover(interval)
+		}
+		if err = r.updateUsersRecoveryStatus(usersRecoveryRunningState); err != nil {
+			return err
+		}
+		return r.requeueSwitchover(usersRecoveryCheckInterval)
+	}
+	statusCode, response, err := r.buildAdapterRestClient().SendRequest(http.MethodGet,
+		"api/v2/dbaas/adapter/opensearch/users/restore-password/state", nil)
+	if err != nil || statusCode != http.StatusOK {
+		r.logger.Error(err, fmt.Sprintf("Unable to get state of procedure: %s", string(response)))
+		return r.requeueSwitchover(usersRecoveryCheckInterval)
+	}
+	state = string(response)
+	if state == usersRecoveryDoneState || state == usersRecoveryFailedState {
+		return r.finishUsersRecovery(state)
+	}
+	if state != r.cr.Status.DisasterRecoveryStatus.UsersRecoveryState {
+		if err = r.updateUsersRecoveryStatus(state); err != nil {
+			return err
 		}
-		state = string(response)
 	}
+	return r.requeueSwitchover(usersRecoveryCheckInterval)
+}
+
+// finishUsersRecovery finishes the switchover with the final state of users recovery
+func (r DisasterRecoveryReconciler) finishUsersRecovery(state string) error {
 	r.logger.Info(fmt.Sprintf("Users recovery is finished with [%s] state", state))
 	if state == usersRecoveryFailedState {
-		return usersRecoveryFailedState, fmt.Errorf("unable to restore OpenSearch users during switchover")
+		r.reconciler.recordEvent(r.cr, corev1.EventTypeWarning, usersRecoveryFailedReason,
+			"Users recovery is finished with [%s] state", state)
+		return r.finishSwitchover(fmt.Errorf("unable to restore OpenSearch users during switchover"), "", state)
 	}
-	return state, nil
+	r.reconciler.recordEvent(r.cr, corev1.EventTypeNormal, usersRecoverySucceededReason,
+		"Users recovery is finished with [%s] state", state)
+	return r.finishSwitchover(nil, "", state)
 }
 
 func (r DisasterRecoveryReconciler) buildAggregatorRestClient() *util.RestClient {
-	client, _ := r.reconciler.configureClientWithCertificate(dbaasCertificateFilePath)
+	client, _ := configureClientWithCertificate(dbaasCertificateFilePath)
 	credentials := r.reconciler.parseSecretCredentialsByKeys(r.cr.Spec.DbaasAdapter.SecretName, r.cr.Namespace,
 		"registration-auth-username", "registration-auth-password", r.logger)
 	return util.NewRestClient(r.cr.Spec.DbaasAdapter.AggregatorAddress, client, credentials)
 }
 
 func (r DisasterRecoveryReconciler) buildAdapterRestClient() *util.RestClient {
-	client, _ := r.reconciler.configureClientWithCertificate(dbaasCertificateFilePath)
+	client, _ := configureClientWithCertificate(dbaasCertificateFilePath)
 	credentials := r.reconciler.parseSecretCredentials(r.cr.Spec.DbaasAdapter.SecretName, r.cr.Namespace, r.logger)
 	return util.NewRestClient(r.cr.Spec.DbaasAdapter.AdapterAddress, client, credentials)
 }
@@ -427,8 +708,8 @@ func (r DisasterRecoveryReconciler) getReplicationManager() ReplicationManager {
 	remoteService := configMap.Data[replicationRemoteServiceKey]
 	pattern := configMap.Data[replicationPatternKey]
 	credentials := r.reconciler.parseOpenSearchCredentials(r.cr, r.logger)
-	url := r.reconciler.createUrl(r.cr.Name, opensearchHttpPort)
-	client, _ := r.reconciler.configureClient()
+	url := createUrl(r.cr.Name, opensearchHttpPort)
+	client, _ := configureClient()
 	restClient := util.NewRestClient(url, client, credentials)
 	return *NewReplicationManager(*restClient, remoteService, pattern, r.logger)
 }
@@ -16,6 +16,7 @@ package controllers
 
 import (
 	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
 	"github.com/go-logr/logr"
 )
 
@@ -27,6 +28,7 @@ type ElasticsearchDbaasAdapterReconciler struct {
 	cr         *opensearchservice.OpenSearchService
 	logger     logr.Logger
 	reconciler *OpenSearchServiceReconciler
+	state      *InstanceState
 }
 
 func NewElasticsearchDbaasAdapterReconciler(r *OpenSearchServiceReconciler,
@@ -35,6 +37,7 @@ func NewElasticsearchDbaasAdapterReconciler(r *OpenSearchServiceReconciler,
 		cr:         cr,
 		logger:     logger,
 		reconciler: r,
+		state:      r.getState(cr),
 	}
 }
 
@@ -46,10 +49,10 @@ func (r ElasticsearchDbaasAdapterReconciler) Reconcile() error {
 		return err
 	}
 
-	if r.reconciler.ResourceHashes[opensearchSecretHashName] != "" && r.reconciler.ResourceHashes[opensearchSecretHashName] != opensearchSecretHash ||
-		r.reconciler.ResourceHashes[elasticsearchDbaasAdapterSecretHashName] != "" && r.reconciler.ResourceHashes[elasticsearchDbaasAdapterSecretHashName] != elasticsearchDbaasAdapterSecretHash {
+	if r.state.ResourceHashes[opensearchSecretHashName] != "" && r.state.ResourceHashes[opensearchSecretHashName] != r.state.opensearchSecretHash ||
+		r.state.ResourceHashes[elasticsearchDbaasAdapterSecretHashName] != "" && r.state.ResourceHashes[elasticsearchDbaasAdapterSecretHashName] != elasticsearchDbaasAdapterSecretHash {
 		annotations := map[string]string{
-			opensearchSecretHashName:                opensearchSecretHash,
+			opensearchSecretHashName:                r.state.opensearchSecretHash,
 			elasticsearchDbaasAdapterSecretHashName: elasticsearchDbaasAdapterSecretHash,
 		}
 
@@ -58,12 +61,15 @@ func (r ElasticsearchDbaasAdapterReconciler) Reconcile() error {
 		}
 	}
 
-	r.reconciler.ResourceHashes[elasticsearchDbaasAdapterSecretHashName] = elasticsearchDbaasAdapterSecretHash
+	r.state.ResourceHashes[elasticsearchDbaasAdapterSecretHashName] = elasticsearchDbaasAdapterSecretHash
 	return nil
 }
 
 func (r ElasticsearchDbaasAdapterReconciler) Status() error {
-	return nil
+	componentStatus := r.reconciler.getComponentStatus(r.cr.Spec.ElasticsearchDbaasAdapter.Name, r.cr.Namespace, r.logger)
+	return util.NewStatusUpdater(r.reconciler.Client, r.cr).UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
+		instance.Status.ElasticsearchDbaasAdapterStatus = componentStatus
+	})
 }
 
 func (r ElasticsearchDbaasAdapterReconciler) Configure() error {
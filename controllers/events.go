@@ -0,0 +1,45 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+)
+
+// Reasons of Kubernetes events emitted by the operator for OpenSearchService custom resource
+const (
+	switchoverStartedReason          = "SwitchoverStarted"
+	switchoverSucceededReason        = "SwitchoverSucceeded"
+	switchoverFailedReason           = "SwitchoverFailed"
+	usersRecoverySucceededReason     = "UsersRecoverySucceeded"
+	usersRecoveryFailedReason        = "UsersRecoveryFailed"
+	podRestartedReason               = "PodRestarted"
+	allocationEnabledReason          = "AllocationEnabled"
+	allocationDisabledReason         = "AllocationDisabled"
+	credentialsRotatedReason         = "CredentialsRotated"
+	credentialsRotationFailedReason  = "CredentialsRotationFailed"
+	securityConfigUpdatedReason      = "SecurityConfigurationUpdated"
+	securityConfigUpdateFailedReason = "SecurityConfigurationUpdateFailed"
+	snapshotRepositoryFailedReason   = "SnapshotRepositoryFailed"
+)
+
+// recordEvent emits Kubernetes event for the custom resource, events are skipped if the recorder is not configured
+func (r *OpenSearchServiceReconciler) recordEvent(cr *opensearchservice.OpenSearchService, eventType string,
+	reason string, messageFmt string, args ...interface{}) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(cr, eventType, reason, messageFmt, args...)
+}
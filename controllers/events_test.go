@@ -0,0 +1,149 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"net/http"
+	"testing"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestRecordEvent(t *testing.T) {
+	cr := &opensearchservice.OpenSearchService{ObjectMeta: metav1.ObjectMeta{Name: "opensearch", Namespace: "opensearch-service"}}
+	recorder := record.NewFakeRecorder(10)
+	r := &OpenSearchServiceReconciler{Recorder: recorder}
+
+	r.recordEvent(cr, corev1.EventTypeNormal, podRestartedReason, "Pod %s is restarted by the rolling update", "opensearch-0")
+
+	assert.Equal(t, []string{"Normal PodRestarted Pod opensearch-0 is restarted by the rolling update"}, receivedEvents(recorder))
+	assert.NotPanics(t, func() {
+		(&OpenSearchServiceReconciler{}).recordEvent(cr, corev1.EventTypeNormal, podRestartedReason, "Pod is restarted")
+	})
+}
+
+func TestChangeAllocationSettingEvents(t *testing.T) {
+	tests := []struct {
+		name             string
+		enableAllocation bool
+		settings         string
+		expected         string
+	}{
+		{name: "allocation is enabled", enableAllocation: true, settings: `{"persistent":{}}`,
+			expected: "Normal AllocationEnabled Shard allocation is changed to all"},
+		{name: "allocation is disabled", settings: `{"persistent":{"cluster":{"routing":{"allocation":{"enable":"primaries"}}}}}`,
+			expected: "Normal AllocationDisabled Shard allocation is changed to primaries"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := newOpenSearchMock(t).
+				on(http.MethodPut, clusterSettingsPath, http.StatusOK, `{"acknowledged":true}`).
+				on(http.MethodGet, clusterSettingsPath, http.StatusOK, test.settings)
+			recorder := record.NewFakeRecorder(10)
+			r := OpenSearchReconciler{
+				cr:         &opensearchservice.OpenSearchService{},
+				logger:     logr.Discard(),
+				reconciler: &OpenSearchServiceReconciler{Recorder: recorder},
+			}
+
+			assert.NoError(t, r.changeAllocationSetting(test.enableAllocation, mock.restClient()))
+			assert.Equal(t, []string{test.expected}, receivedEvents(recorder))
+		})
+	}
+}
+
+func TestFinishUsersRecoveryEvents(t *testing.T) {
+	tests := []struct {
+		state    string
+		expected []string
+	}{
+		{state: usersRecoveryDoneState, expected: []string{
+			"Normal UsersRecoverySucceeded Users recovery is finished with [done] state",
+			"Normal SwitchoverSucceeded Switchover to standby mode is finished: Switching to standby mode",
+		}},
+		{state: usersRecoveryFailedState, expected: []string{
+			"Warning UsersRecoveryFailed Users recovery is finished with [failed] state",
+			"Warning SwitchoverFailed Switchover to standby mode is failed: unable to restore OpenSearch users during switchover",
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.state, func(t *testing.T) {
+			cr := &opensearchservice.OpenSearchService{
+				ObjectMeta: metav1.ObjectMeta{Name: "opensearch", Namespace: "opensearch-service"},
+				Spec: opensearchservice.OpenSearchServiceSpec{
+					DisasterRecovery: &opensearchservice.DisasterRecovery{Mode: "standby"},
+				},
+				Status: opensearchservice.OpenSearchServiceStatus{
+					DisasterRecoveryStatus: opensearchservice.DisasterRecoveryStatus{
+						Mode: "active", Status: "running", Message: "Switching to standby mode"},
+				},
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := DisasterRecoveryReconciler{
+				cr:         cr,
+				logger:     logr.Discard(),
+				reconciler: &OpenSearchServiceReconciler{Client: newFakeClient(cr), Recorder: recorder},
+			}
+
+			_ = r.finishUsersRecovery(test.state)
+
+			assert.Equal(t, test.expected, receivedEvents(recorder))
+		})
+	}
+}
+
+func TestSnapshotRepositoryFailedEvent(t *testing.T) {
+	mock := newOpenSearchMock(t).
+		on(http.MethodPut, "_snapshot/"+opensearchservice.DefaultSnapshotRepositoryName, http.StatusBadRequest,
+			`{"error":"repository_exception"}`)
+	cr := &opensearchservice.OpenSearchService{
+		ObjectMeta: metav1.ObjectMeta{Name: "opensearch", Namespace: "opensearch-service"},
+		Spec: opensearchservice.OpenSearchServiceSpec{
+			OpenSearch: &opensearchservice.OpenSearch{Snapshots: &opensearchservice.Snapshots{}},
+		},
+	}
+	recorder := record.NewFakeRecorder(10)
+	r := OpenSearchReconciler{
+		cr:         cr,
+		logger:     logr.Discard(),
+		reconciler: &OpenSearchServiceReconciler{Client: newFakeClient(cr), Recorder: recorder},
+	}
+
+	assert.Error(t, r.reconcileSnapshotRepositories(mock.restClient()))
+
+	events := receivedEvents(recorder)
+	if assert.Len(t, events, 1) {
+		assert.Contains(t, events[0], "Warning SnapshotRepositoryFailed Snapshot repository snapshots is not registered")
+		assert.Contains(t, events[0], "repository_exception")
+	}
+}
+
+// receivedEvents returns events emitted to the recorder so far
+func receivedEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
 	"github.com/Netcracker/opensearch-service/util"
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"net/http"
 	"strings"
 )
@@ -32,6 +33,8 @@ type ExternalOpenSearchReconciler struct {
 	cr         *opensearchservice.OpenSearchService
 	logger     logr.Logger
 	reconciler *OpenSearchServiceReconciler
+	state      *InstanceState
+	opensearch OpenSearchReconciler
 }
 
 func NewExternalOpenSearchReconciler(r *OpenSearchServiceReconciler, cr *opensearchservice.OpenSearchService,
@@ -40,35 +43,84 @@ func NewExternalOpenSearchReconciler(r *OpenSearchServiceReconciler, cr *opensea
 		cr:         cr,
 		logger:     logger,
 		reconciler: r,
+		state:      r.getState(cr),
+		opensearch: NewOpenSearchReconciler(r, cr, logger),
 	}
 }
 
 func (r ExternalOpenSearchReconciler) Reconcile() error {
-	externalOpenSearchSpecHash, err := util.Hash(r.cr.Spec.ExternalOpenSearch)
+	return nil
+}
+
+func (r ExternalOpenSearchReconciler) Status() error {
+	restClient, err := r.reconciler.createOpenSearchRestClient(r.cr, r.logger)
 	if err != nil {
 		return err
 	}
-	if r.reconciler.ResourceHashes[externalSpecHashName] != externalOpenSearchSpecHash {
-		if err := r.performExternalOpenSearchConfiguration(); err != nil {
-			return err
-		}
+	clusterStatus := getClusterStatus(restClient)
+	var explanations []SnapshotPolicyExplanation
+	var verifications []SnapshotRepositoryVerification
+	if clusterStatus.Health != unknownClusterHealth {
+		explanations = r.opensearch.explainSnapshotPolicies(restClient, r.cr.Status.SnapshotPolicies)
+		verifications = r.opensearch.verifySnapshotRepositories(restClient, r.cr.Status.SnapshotRepositories)
 	}
-	r.reconciler.ResourceHashes[externalSpecHashName] = externalOpenSearchSpecHash
-	return nil
+	return util.NewStatusUpdater(r.reconciler.Client, r.cr).UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
+		instance.Status.ExternalOpenSearchStatus = clusterStatus
+		updateSnapshotPolicyExecutions(instance.Status.SnapshotPolicies, explanations)
+		updateSnapshotRepositoryVerifications(instance.Status.SnapshotRepositories, verifications)
+	})
 }
 
-func (r ExternalOpenSearchReconciler) Status() error {
-	return nil
+// Configure applies cluster settings, snapshot repository with its schedules and LDAP role mappings to external OpenSearch.
+// Procedures shared with managed OpenSearch are performed by OpenSearchReconciler.
+func (r ExternalOpenSearchReconciler) Configure() error {
+	restClient, err := r.reconciler.createOpenSearchRestClient(r.cr, r.logger)
+	if err != nil {
+		return err
+	}
+	externalOpenSearchSpecHash, err := util.Hash(r.cr.Spec.ExternalOpenSearch.Config)
+	if err != nil {
+		return err
+	}
+	if len(r.cr.Spec.ExternalOpenSearch.Config) > 0 && r.state.ResourceHashes[externalSpecHashName] != externalOpenSearchSpecHash {
+		if err = r.performExternalOpenSearchConfiguration(restClient); err != nil {
+			return err
+		}
+		r.state.ResourceHashes[externalSpecHashName] = externalOpenSearchSpecHash
+	}
+	if r.cr.Spec.ExternalOpenSearch.Snapshots != nil || len(r.cr.Status.SnapshotRepositories) > 0 {
+		if err = r.opensearch.reconcileSnapshotRepositories(restClient); err != nil {
+			return err
+		}
+	}
+	if r.cr.Spec.ExternalOpenSearch.Snapshots != nil || len(r.cr.Status.SnapshotPolicies) > 0 {
+		if err = r.opensearch.reconcileSnapshotPolicies(restClient); err != nil {
+			return err
+		}
+	}
+	return r.updateRoleMappings(restClient)
 }
 
-func (r ExternalOpenSearchReconciler) Configure() error {
+// updateRoleMappings applies role mappings from LDAP role mappings secret if it exists and is changed
+func (r ExternalOpenSearchReconciler) updateRoleMappings(restClient *util.RestClient) error {
+	roleMappingsHash, err := r.reconciler.calculateSecretDataHash(fmt.Sprintf("%s-ldap-rolemappings", r.cr.Name),
+		opensearchRoleMappingsHashName, r.cr, r.logger)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if r.state.ResourceHashes[opensearchRoleMappingsHashName] != roleMappingsHash {
+		if err = r.opensearch.updateLdapRolesmapping(restClient); err != nil {
+			return err
+		}
+		r.state.ResourceHashes[opensearchRoleMappingsHashName] = roleMappingsHash
+	}
 	return nil
 }
 
-func (r ExternalOpenSearchReconciler) performExternalOpenSearchConfiguration() error {
-	client, _ := r.reconciler.configureClient()
-	credentials := r.reconciler.parseSecretCredentials(fmt.Sprintf(secretPattern, r.cr.Name), r.cr.Namespace, r.logger)
-	restClient := util.NewRestClient(r.cr.Spec.ExternalOpenSearch.Url, client, credentials)
+func (r ExternalOpenSearchReconciler) performExternalOpenSearchConfiguration(restClient *util.RestClient) error {
 	jsonConfig, err := json.Marshal(r.cr.Spec.ExternalOpenSearch.Config)
 	if err != nil {
 		return err
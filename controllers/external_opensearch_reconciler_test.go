@@ -0,0 +1,215 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestExternalOpenSearchConfigure(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          map[string]string
+		appliedHash     bool
+		putStatusCode   int
+		expectedRequest string
+		expectedError   bool
+	}{
+		{name: "config is applied", config: map[string]string{"cluster.max_shards_per_node": "2000"},
+			putStatusCode: http.StatusOK, expectedRequest: `{"persistent":{"cluster.max_shards_per_node":"2000"}}`},
+		{name: "applied config is not sent again", config: map[string]string{"cluster.max_shards_per_node": "2000"},
+			appliedHash: true, putStatusCode: http.StatusOK},
+		{name: "rejected config is failed", config: map[string]string{"cluster.unknown": "true"},
+			putStatusCode: http.StatusBadRequest, expectedRequest: `{"persistent":{"cluster.unknown":"true"}}`,
+			expectedError: true},
+		{name: "empty config is not applied", putStatusCode: http.StatusOK},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := newOpenSearchMock(t).
+				on(http.MethodPut, clusterSettingsPath, test.putStatusCode, `{"acknowledged":true}`)
+			cr := newExternalOpenSearchService(mock)
+			cr.Spec.ExternalOpenSearch.Config = test.config
+			configHash, err := util.Hash(test.config)
+			assert.NoError(t, err)
+			r := newTestExternalOpenSearchReconciler(cr)
+			if test.appliedHash {
+				r.state.ResourceHashes[externalSpecHashName] = configHash
+			}
+
+			err = r.Configure()
+			assert.Equal(t, test.expectedError, err != nil, err)
+
+			received := mock.received(http.MethodPut, clusterSettingsPath)
+			if test.expectedRequest == "" {
+				assert.Empty(t, received)
+			} else if assert.Len(t, received, 1) {
+				assert.JSONEq(t, test.expectedRequest, received[0])
+			}
+			if len(test.config) > 0 && !test.expectedError {
+				assert.Equal(t, configHash, r.state.ResourceHashes[externalSpecHashName])
+			} else {
+				assert.Empty(t, r.state.ResourceHashes[externalSpecHashName])
+			}
+		})
+	}
+}
+
+func TestExternalOpenSearchStatus(t *testing.T) {
+	mock := newOpenSearchMock(t).
+		on(http.MethodGet, clusterHealthPath, http.StatusOK, `{"status":"green","number_of_nodes":3,"number_of_data_nodes":3}`).
+		on(http.MethodGet, "", http.StatusOK, `{"version":{"number":"2.11.0"}}`)
+	cr := newExternalOpenSearchService(mock)
+	r := newTestExternalOpenSearchReconciler(cr)
+
+	assert.NoError(t, r.Status())
+
+	updated := &opensearchservice.OpenSearchService{}
+	assert.NoError(t, r.reconciler.Client.Get(context.TODO(), client.ObjectKeyFromObject(cr), updated))
+	status := updated.Status.ExternalOpenSearchStatus
+	if assert.NotNil(t, status) {
+		assert.Equal(t, "green", status.Health)
+		assert.Equal(t, "2.11.0", status.Version)
+		assert.Equal(t, 3, status.NumberOfNodes)
+		assert.Equal(t, 3, status.NumberOfDataNodes)
+	}
+}
+
+func TestConfigureOpenSearchClientWithCASecret(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	tests := []struct {
+		name          string
+		secret        *corev1.Secret
+		expectedError string
+	}{
+		{name: "OpenSearch certificate is trusted",
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "opensearch-ca", Namespace: "opensearch-service"},
+				Data: map[string][]byte{"ca.crt": caCert}}},
+		{name: "secret does not exist", expectedError: "unable to get secret [opensearch-ca] with CA certificates"},
+		{name: "secret does not contain the key",
+			secret: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "opensearch-ca", Namespace: "opensearch-service"},
+				Data: map[string][]byte{"tls.crt": caCert}},
+			expectedError: "secret [opensearch-ca] does not contain CA certificates in [ca.crt] key"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cr := &opensearchservice.OpenSearchService{
+				ObjectMeta: metav1.ObjectMeta{Name: "opensearch", Namespace: "opensearch-service"},
+				Spec: opensearchservice.OpenSearchServiceSpec{ExternalOpenSearch: &opensearchservice.ExternalOpenSearch{
+					Url: server.URL,
+					CASecret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "opensearch-ca"}, Key: "ca.crt"},
+				}},
+			}
+			objects := []client.Object{cr}
+			if test.secret != nil {
+				objects = append(objects, test.secret)
+			}
+
+			httpClient, err := configureOpenSearchClient(newFakeClient(objects...), cr, logr.Discard())
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			response, err := httpClient.Get(server.URL)
+			if assert.NoError(t, err) {
+				_ = response.Body.Close()
+				assert.Equal(t, http.StatusOK, response.StatusCode)
+			}
+		})
+	}
+}
+
+func TestGetExternalOpenSearchCredentials(t *testing.T) {
+	secret := func(name string, username string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "opensearch-service"},
+			Data:       map[string][]byte{"username": []byte(username), "password": []byte("password")},
+		}
+	}
+	tests := []struct {
+		name                  string
+		credentialsSecretName string
+		expected              util.Credentials
+	}{
+		{name: "secret by convention", expected: util.NewCredentials("admin", "password")},
+		{name: "specified secret", credentialsSecretName: "external-credentials",
+			expected: util.NewCredentials("external", "password")},
+		{name: "specified secret does not exist", credentialsSecretName: "unknown"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cr := &opensearchservice.OpenSearchService{
+				ObjectMeta: metav1.ObjectMeta{Name: "opensearch", Namespace: "opensearch-service"},
+				Spec: opensearchservice.OpenSearchServiceSpec{ExternalOpenSearch: &opensearchservice.ExternalOpenSearch{
+					CredentialsSecretName: test.credentialsSecretName,
+				}},
+			}
+			k8sClient := newFakeClient(secret("opensearch-secret", "admin"), secret("external-credentials", "external"))
+
+			assert.Equal(t, test.expected, getOpenSearchCredentials(k8sClient, cr, logr.Discard()))
+		})
+	}
+}
+
+func TestGetReadinessTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     opensearchservice.OpenSearchServiceSpec
+		expected string
+	}{
+		{name: "managed OpenSearch", expected: "15m0s",
+			spec: opensearchservice.OpenSearchServiceSpec{OpenSearch: &opensearchservice.OpenSearch{ReadinessTimeout: "15m0s"}}},
+		{name: "external OpenSearch", expected: "5m",
+			spec: opensearchservice.OpenSearchServiceSpec{ExternalOpenSearch: &opensearchservice.ExternalOpenSearch{ReadinessTimeout: "5m"}}},
+		{name: "default timeout", expected: opensearchservice.DefaultReadinessTimeout.String(),
+			spec: opensearchservice.OpenSearchServiceSpec{ExternalOpenSearch: &opensearchservice.ExternalOpenSearch{}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, getReadinessTimeout(&opensearchservice.OpenSearchService{Spec: test.spec}))
+		})
+	}
+}
+
+func newTestExternalOpenSearchReconciler(cr *opensearchservice.OpenSearchService) ExternalOpenSearchReconciler {
+	reconciler := &OpenSearchServiceReconciler{Client: newFakeClient(cr), Instances: newTestInstanceStates()}
+	state := reconciler.getState(cr)
+	return ExternalOpenSearchReconciler{
+		cr:         cr,
+		logger:     logr.Discard(),
+		reconciler: reconciler,
+		state:      state,
+		opensearch: OpenSearchReconciler{cr: cr, logger: logr.Discard(), reconciler: reconciler, state: state},
+	}
+}
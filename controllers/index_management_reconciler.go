@@ -0,0 +1,199 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	"github.com/go-logr/logr"
+)
+
+const (
+	ismAddPolicyPathPattern      = "_plugins/_ism/add/%s"
+	ismPolicyAlreadyAttachedText = "already has a policy"
+)
+
+var componentTemplateKind = managedResourceKind{
+	name:        "component template",
+	pathPattern: "_component_template/%s",
+	extract: func(body []byte) (*managedResourceDefinition, error) {
+		var response struct {
+			ComponentTemplates []struct {
+				ComponentTemplate json.RawMessage `json:"component_template"`
+			} `json:"component_templates"`
+		}
+		if err := json.Unmarshal(body, &response); err != nil || len(response.ComponentTemplates) == 0 {
+			return nil, err
+		}
+		return &managedResourceDefinition{Definition: response.ComponentTemplates[0].ComponentTemplate}, nil
+	},
+}
+
+var indexTemplateKind = managedResourceKind{
+	name:        "index template",
+	pathPattern: "_index_template/%s",
+	extract: func(body []byte) (*managedResourceDefinition, error) {
+		var response struct {
+			IndexTemplates []struct {
+				IndexTemplate json.RawMessage `json:"index_template"`
+			} `json:"index_templates"`
+		}
+		if err := json.Unmarshal(body, &response); err != nil || len(response.IndexTemplates) == 0 {
+			return nil, err
+		}
+		return &managedResourceDefinition{Definition: response.IndexTemplates[0].IndexTemplate}, nil
+	},
+}
+
+var ismPolicyKind = managedResourceKind{
+	name:        "ISM policy",
+	pathPattern: "_plugins/_ism/policies/%s",
+	extract: func(body []byte) (*managedResourceDefinition, error) {
+		var response struct {
+			SeqNo       *int64          `json:"_seq_no"`
+			PrimaryTerm *int64          `json:"_primary_term"`
+			Policy      json.RawMessage `json:"policy"`
+		}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, err
+		}
+		return &managedResourceDefinition{
+			Definition:  response.Policy,
+			SeqNo:       response.SeqNo,
+			PrimaryTerm: response.PrimaryTerm,
+		}, nil
+	},
+}
+
+type IndexManagementReconciler struct {
+	cr         *opensearchservice.OpenSearchService
+	logger     logr.Logger
+	reconciler *OpenSearchServiceReconciler
+}
+
+func NewIndexManagementReconciler(r *OpenSearchServiceReconciler, cr *opensearchservice.OpenSearchService,
+	logger logr.Logger) IndexManagementReconciler {
+	return IndexManagementReconciler{
+		cr:         cr,
+		logger:     logger,
+		reconciler: r,
+	}
+}
+
+func (r IndexManagementReconciler) Reconcile() error {
+	return nil
+}
+
+func (r IndexManagementReconciler) Status() error {
+	return nil
+}
+
+// Configure creates and updates index templates, component templates and ISM policies from the spec in OpenSearch,
+// reverts their changes made outside of the operator and removes the ones deleted from the spec
+func (r IndexManagementReconciler) Configure() error {
+	restClient, err := r.reconciler.createOpenSearchRestClient(r.cr, r.logger)
+	if err != nil {
+		return err
+	}
+	spec := r.cr.Spec.IndexManagement
+	if spec == nil {
+		spec = &opensearchservice.IndexManagement{}
+	}
+	previous := r.cr.Status.IndexManagementStatus
+	status := opensearchservice.IndexManagementStatus{}
+
+	// Component templates are applied first and deleted last because index templates are composed of them
+	status.ComponentTemplates = applyManagedResources(restClient, r.logger, componentTemplateKind, spec.ComponentTemplates, previous.ComponentTemplates)
+	status.IndexTemplates = applyManagedResources(restClient, r.logger, indexTemplateKind, spec.IndexTemplates, previous.IndexTemplates)
+	var policies []opensearchservice.IndexManagementResource
+	for _, policy := range spec.ISMPolicies {
+		policies = append(policies, opensearchservice.IndexManagementResource{Name: policy.Name, Body: policy.Body})
+	}
+	status.ISMPolicies = applyManagedResources(restClient, r.logger, ismPolicyKind, policies, previous.ISMPolicies)
+	r.attachPolicies(restClient, spec.ISMPolicies, status.ISMPolicies)
+
+	status.ISMPolicies = append(status.ISMPolicies, deleteRemovedManagedResources(restClient, r.logger, ismPolicyKind, policies, previous.ISMPolicies)...)
+	status.IndexTemplates = append(status.IndexTemplates, deleteRemovedManagedResources(restClient, r.logger, indexTemplateKind, spec.IndexTemplates, previous.IndexTemplates)...)
+	status.ComponentTemplates = append(status.ComponentTemplates, deleteRemovedManagedResources(restClient, r.logger, componentTemplateKind, spec.ComponentTemplates, previous.ComponentTemplates)...)
+
+	statusUpdater := util.NewStatusUpdater(r.reconciler.Client, r.cr)
+	if err = statusUpdater.UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
+		instance.Status.IndexManagementStatus = status
+	}); err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, resourceStatuses := range [][]opensearchservice.ManagedResourceStatus{status.ComponentTemplates, status.IndexTemplates, status.ISMPolicies} {
+		for _, resourceStatus := range resourceStatuses {
+			if resourceStatus.Status == managedResourceFailedStatus {
+				failed = append(failed, resourceStatus.Name)
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("unable to apply index management resources %v, see status for details", failed)
+	}
+	return nil
+}
+
+// attachPolicies applies ISM policies to existing indices matching their patterns.
+// Indices which already have a policy are left as is.
+func (r IndexManagementReconciler) attachPolicies(restClient *util.RestClient, policies []opensearchservice.ISMPolicy,
+	statuses []opensearchservice.ManagedResourceStatus) {
+	for _, policy := range policies {
+		policyStatus := findManagedResourceStatus(statuses, policy.Name)
+		if policyStatus == nil || policyStatus.Status != managedResourceAppliedStatus || len(policy.IndexPatterns) == 0 {
+			continue
+		}
+		body := fmt.Sprintf(`{"policy_id": "%s"}`, policy.Name)
+		path := fmt.Sprintf(ismAddPolicyPathPattern, strings.Join(policy.IndexPatterns, ","))
+		responseBody, err := restClient.SendRequestWithStatusCodeCheck(http.MethodPost, path, strings.NewReader(body))
+		if err != nil {
+			r.logger.Error(err, fmt.Sprintf("Unable to attach [%s] ISM policy to indices", policy.Name))
+			continue
+		}
+		var response struct {
+			UpdatedIndices int `json:"updated_indices"`
+			FailedIndices  []struct {
+				IndexName string `json:"index_name"`
+				Reason    string `json:"reason"`
+			} `json:"failed_indices"`
+		}
+		if err = json.Unmarshal(responseBody, &response); err != nil {
+			r.logger.Error(err, "Unable to parse response of ISM add policy request")
+			continue
+		}
+		if response.UpdatedIndices > 0 {
+			r.logger.Info(fmt.Sprintf("[%s] ISM policy is attached to %d indices", policy.Name, response.UpdatedIndices))
+		}
+		for _, failedIndex := range response.FailedIndices {
+			if !strings.Contains(failedIndex.Reason, ismPolicyAlreadyAttachedText) {
+				r.logger.Info(fmt.Sprintf("Unable to attach [%s] ISM policy to [%s] index: %s",
+					policy.Name, failedIndex.IndexName, failedIndex.Reason))
+			}
+		}
+	}
+}
+
+// isIndexManagementStatusEmpty returns true if there are no resources maintained by the operator in OpenSearch
+func isIndexManagementStatusEmpty(status opensearchservice.IndexManagementStatus) bool {
+	return len(status.ComponentTemplates) == 0 && len(status.IndexTemplates) == 0 && len(status.ISMPolicies) == 0
+}
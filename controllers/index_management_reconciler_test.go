@@ -0,0 +1,57 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"net/http"
+	"testing"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachPolicies(t *testing.T) {
+	mock := newOpenSearchMock(t).
+		on(http.MethodPost, "_plugins/_ism/add/logs-*,audit-*", http.StatusOK, `{"updated_indices":2,"failures":true,
+			"failed_indices":[{"index_name":"logs-1","reason":"This index already has a policy, use the update policy API"}]}`)
+	policies := []opensearchservice.ISMPolicy{
+		{Name: "logs", IndexPatterns: []string{"logs-*", "audit-*"}},
+		{Name: "failed", IndexPatterns: []string{"failed-*"}},
+		{Name: "without-patterns"},
+	}
+	statuses := []opensearchservice.ManagedResourceStatus{
+		{Name: "logs", Status: managedResourceAppliedStatus},
+		{Name: "failed", Status: managedResourceFailedStatus},
+		{Name: "without-patterns", Status: managedResourceAppliedStatus},
+	}
+	r := IndexManagementReconciler{logger: logr.Discard()}
+
+	r.attachPolicies(mock.restClient(), policies, statuses)
+
+	received := mock.received(http.MethodPost, "_plugins/_ism/add/logs-*,audit-*")
+	assert.Len(t, received, 1)
+	if len(received) == 1 {
+		assert.JSONEq(t, `{"policy_id": "logs"}`, received[0])
+	}
+	assert.Empty(t, mock.received(http.MethodPost, "_plugins/_ism/add/failed-*"))
+}
+
+func TestIsIndexManagementStatusEmpty(t *testing.T) {
+	assert.True(t, isIndexManagementStatusEmpty(opensearchservice.IndexManagementStatus{}))
+	assert.False(t, isIndexManagementStatusEmpty(opensearchservice.IndexManagementStatus{
+		ISMPolicies: []opensearchservice.ManagedResourceStatus{{Name: "logs"}},
+	}))
+}
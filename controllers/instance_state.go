@@ -0,0 +1,110 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// InstanceState holds reconciliation state of a single OpenSearchService custom resource
+type InstanceState struct {
+	ResourceHashes        map[string]string
+	ReplicationWatcher    *ReplicationWatcher
+	SlowLogIndicesWatcher *SlowLogIndicesWatcher
+	// opensearchSecretHash is the hash of OpenSearch credentials calculated in the current reconciliation cycle
+	opensearchSecretHash string
+}
+
+func NewInstanceState(name types.NamespacedName) *InstanceState {
+	return &InstanceState{
+		ResourceHashes:        map[string]string{},
+		ReplicationWatcher:    NewReplicationWatcher(fmt.Sprintf("replication-watcher/%s", name), &sync.Mutex{}),
+		SlowLogIndicesWatcher: NewSlowLogIndicesWatcher(fmt.Sprintf("slowlog-indices-watcher/%s", name)),
+	}
+}
+
+// InstanceStates keeps state of all OpenSearchService custom resources managed by the operator
+type InstanceStates struct {
+	lock   *sync.Mutex
+	states map[types.NamespacedName]*InstanceState
+	// replicationWatchers and slowLogIndicesWatchers run background watchers of all custom resources,
+	// each group is added to the manager once
+	replicationWatchers    *watcherGroup
+	slowLogIndicesWatchers *watcherGroup
+}
+
+// NewInstanceStates creates states of custom resources whose background watchers are run by the manager
+func NewInstanceStates(mgr manager.Manager) (InstanceStates, error) {
+	is := InstanceStates{
+		lock:                   &sync.Mutex{},
+		states:                 map[types.NamespacedName]*InstanceState{},
+		replicationWatchers:    newWatcherGroup("replication-watchers"),
+		slowLogIndicesWatchers: newWatcherGroup("slowlog-indices-watchers"),
+	}
+	for _, group := range []*watcherGroup{is.replicationWatchers, is.slowLogIndicesWatchers} {
+		if err := mgr.Add(group); err != nil {
+			return is, fmt.Errorf("unable to run %s: %w", group.name, err)
+		}
+	}
+	return is, nil
+}
+
+// get returns state of specified custom resource creating it on the first call
+func (is InstanceStates) get(name types.NamespacedName) *InstanceState {
+	is.lock.Lock()
+	defer is.lock.Unlock()
+	state, ok := is.states[name]
+	if !ok {
+		state = NewInstanceState(name)
+		is.replicationWatchers.add(state.ReplicationWatcher.backgroundWatcher)
+		is.slowLogIndicesWatchers.add(state.SlowLogIndicesWatcher.backgroundWatcher)
+		is.states[name] = state
+	}
+	return state
+}
+
+// remove terminates watchers of specified custom resource and forgets its state
+func (is InstanceStates) remove(name types.NamespacedName, logger logr.Logger) {
+	is.lock.Lock()
+	state, ok := is.states[name]
+	delete(is.states, name)
+	is.lock.Unlock()
+	if !ok {
+		return
+	}
+	logger.Info("Terminate background watchers")
+	is.replicationWatchers.remove(state.ReplicationWatcher.backgroundWatcher)
+	is.slowLogIndicesWatchers.remove(state.SlowLogIndicesWatcher.backgroundWatcher)
+}
+
+// CheckWatchers is the readiness check which fails if background watcher of any custom resource is stuck
+func (is InstanceStates) CheckWatchers(_ *http.Request) error {
+	if err := is.replicationWatchers.check(); err != nil {
+		return err
+	}
+	return is.slowLogIndicesWatchers.check()
+}
+
+// getState returns reconciliation state of specified custom resource
+func (r *OpenSearchServiceReconciler) getState(cr *opensearchservice.OpenSearchService) *InstanceState {
+	return r.Instances.get(types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name})
+}
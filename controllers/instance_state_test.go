@@ -0,0 +1,95 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestInstanceStates(t *testing.T) {
+	states := newTestInstanceStates()
+	first := types.NamespacedName{Namespace: "first", Name: "opensearch"}
+	second := types.NamespacedName{Namespace: "second", Name: "opensearch"}
+
+	firstState := states.get(first)
+	firstState.ResourceHashes["secret"] = "hash"
+	assert.Same(t, firstState, states.get(first))
+	secondState := states.get(second)
+	assert.NotSame(t, firstState, secondState)
+	assert.Empty(t, secondState.ResourceHashes)
+	assert.NotSame(t, firstState.ReplicationWatcher, secondState.ReplicationWatcher)
+	assert.Len(t, states.replicationWatchers.watchers, 2)
+	assert.Len(t, states.slowLogIndicesWatchers.watchers, 2)
+
+	states.remove(first, logr.Discard())
+	assert.NotContains(t, states.states, first)
+	assert.NotContains(t, states.replicationWatchers.watchers, firstState.ReplicationWatcher.backgroundWatcher)
+	assert.NotContains(t, states.slowLogIndicesWatchers.watchers, firstState.SlowLogIndicesWatcher.backgroundWatcher)
+	assert.Len(t, states.replicationWatchers.watchers, 1)
+	// State of the custom resource created again with the same name starts from scratch
+	assert.NotSame(t, firstState, states.get(first))
+}
+
+func TestWatcherGroup(t *testing.T) {
+	group := newWatcherGroup("test-watchers")
+	var beforeStart, afterStart, removed atomic.Int32
+	addWatcher := func(counter *atomic.Int32) *backgroundWatcher {
+		watcher := newBackgroundWatcher("test-watcher")
+		group.add(watcher)
+		watcher.run(10*time.Millisecond, func(ctx context.Context) {
+			counter.Add(1)
+		})
+		return watcher
+	}
+
+	addWatcher(&beforeStart)
+	removedWatcher := addWatcher(&removed)
+	group.remove(removedWatcher)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = group.Start(ctx)
+	}()
+	assert.Eventually(t, func() bool { return beforeStart.Load() > 0 }, watcherTestTimeout, watcherTestTick)
+
+	addWatcher(&afterStart)
+	assert.Eventually(t, func() bool { return afterStart.Load() > 0 }, watcherTestTimeout, watcherTestTick)
+	assert.Zero(t, removed.Load())
+	assert.Len(t, group.watchers, 2)
+	assert.NoError(t, group.check())
+}
+
+func TestCheckWatchers(t *testing.T) {
+	states := newTestInstanceStates()
+	state := states.get(types.NamespacedName{Namespace: "opensearch-service", Name: "opensearch"})
+	assert.NoError(t, states.CheckWatchers(nil))
+
+	watcher := state.SlowLogIndicesWatcher.backgroundWatcher
+	watcher.running.Store(true)
+	watcher.lastActivity.Store(time.Now().Add(-watcherStuckTimeout - time.Minute).UnixNano())
+	assert.Error(t, states.CheckWatchers(nil))
+
+	// Watchers of removed custom resources are not checked
+	states.remove(types.NamespacedName{Namespace: "opensearch-service", Name: "opensearch"}, logr.Discard())
+	watcher.running.Store(true)
+	assert.NoError(t, states.CheckWatchers(nil))
+}
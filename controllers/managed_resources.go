@@ -0,0 +1,180 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	managedResourceAppliedStatus = "applied"
+	managedResourceFailedStatus  = "failed"
+)
+
+// managedResourceKind describes how resources of one type are maintained in OpenSearch
+type managedResourceKind struct {
+	name        string
+	pathPattern string
+	// createMethod is HTTP method used to create the resource, resources are created with PUT if it is empty
+	createMethod string
+	// extract returns definition of the resource from OpenSearch GET response
+	extract func(body []byte) (*managedResourceDefinition, error)
+}
+
+type managedResourceDefinition struct {
+	Definition  json.RawMessage
+	SeqNo       *int64
+	PrimaryTerm *int64
+}
+
+func applyManagedResources(restClient *util.RestClient, logger logr.Logger, kind managedResourceKind,
+	resources []opensearchservice.IndexManagementResource,
+	previous []opensearchservice.ManagedResourceStatus) []opensearchservice.ManagedResourceStatus {
+	var statuses []opensearchservice.ManagedResourceStatus
+	for _, resource := range resources {
+		statuses = append(statuses, applyManagedResource(restClient, logger, kind, resource, findManagedResourceStatus(previous, resource.Name)))
+	}
+	return statuses
+}
+
+// applyManagedResource puts the resource to OpenSearch if it is new, changed in the spec or drifted in OpenSearch
+func applyManagedResource(restClient *util.RestClient, logger logr.Logger, kind managedResourceKind,
+	resource opensearchservice.IndexManagementResource,
+	previous *opensearchservice.ManagedResourceStatus) opensearchservice.ManagedResourceStatus {
+	path := fmt.Sprintf(kind.pathPattern, resource.Name)
+	status := opensearchservice.ManagedResourceStatus{
+		Name:               resource.Name,
+		LastTransitionTime: metav1.Now(),
+	}
+	specHash, err := util.Hash(resource.Body)
+	if err != nil {
+		return failedManagedResourceStatus(status, err)
+	}
+	status.SpecHash = specHash
+
+	existing, err := getManagedResource(restClient, kind, path)
+	if err != nil {
+		return failedManagedResourceStatus(status, err)
+	}
+	if existing != nil && previous != nil && previous.Status == managedResourceAppliedStatus && previous.SpecHash == specHash {
+		observedHash, err := util.Hash(existing.Definition)
+		if err != nil {
+			return failedManagedResourceStatus(status, err)
+		}
+		if observedHash == previous.ObservedHash {
+			unchanged := *previous
+			unchanged.Drifted = false
+			unchanged.Message = ""
+			return unchanged
+		}
+	}
+	if previous != nil && previous.Status == managedResourceAppliedStatus && previous.SpecHash == specHash {
+		status.Drifted = true
+		status.Message = fmt.Sprintf("The %s was changed outside of the operator, the changes are reverted", kind.name)
+		logger.Info(fmt.Sprintf("Drift of [%s] %s is detected", resource.Name, kind.name))
+	}
+
+	logger.Info(fmt.Sprintf("Applying [%s] %s", resource.Name, kind.name))
+	requestPath := path
+	method := http.MethodPut
+	if existing != nil && existing.SeqNo != nil && existing.PrimaryTerm != nil {
+		requestPath = fmt.Sprintf("%s?if_seq_no=%d&if_primary_term=%d", path, *existing.SeqNo, *existing.PrimaryTerm)
+	} else if existing == nil && kind.createMethod != "" {
+		method = kind.createMethod
+	}
+	if _, err = restClient.SendRequestWithStatusCodeCheck(method, requestPath, bytes.NewReader(resource.Body.Raw)); err != nil {
+		return failedManagedResourceStatus(status, err)
+	}
+	applied, err := getManagedResource(restClient, kind, path)
+	if err != nil {
+		return failedManagedResourceStatus(status, err)
+	}
+	if applied != nil {
+		status.ObservedHash, _ = util.Hash(applied.Definition)
+	}
+	status.Status = managedResourceAppliedStatus
+	return status
+}
+
+// getManagedResource returns definition of the resource in OpenSearch or nil if it does not exist
+func getManagedResource(restClient *util.RestClient, kind managedResourceKind,
+	path string) (*managedResourceDefinition, error) {
+	statusCode, body, err := restClient.SendRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if statusCode >= 400 {
+		return nil, fmt.Errorf("unable to get %s, status code - [%d], response - [%s]", kind.name, statusCode, string(body))
+	}
+	return kind.extract(body)
+}
+
+// deleteRemovedManagedResources deletes resources that were applied before but are removed from the spec.
+// Resources which are failed to be deleted are kept in the status to retry deletion later.
+func deleteRemovedManagedResources(restClient *util.RestClient, logger logr.Logger, kind managedResourceKind,
+	resources []opensearchservice.IndexManagementResource,
+	previous []opensearchservice.ManagedResourceStatus) []opensearchservice.ManagedResourceStatus {
+	var statuses []opensearchservice.ManagedResourceStatus
+	for _, previousStatus := range previous {
+		removed := true
+		for _, resource := range resources {
+			if resource.Name == previousStatus.Name {
+				removed = false
+				break
+			}
+		}
+		if !removed {
+			continue
+		}
+		logger.Info(fmt.Sprintf("Deleting [%s] %s", previousStatus.Name, kind.name))
+		statusCode, body, err := restClient.SendRequest(http.MethodDelete, fmt.Sprintf(kind.pathPattern, previousStatus.Name), nil)
+		if err == nil && statusCode >= 400 && statusCode != http.StatusNotFound {
+			err = fmt.Errorf("unable to delete %s, status code - [%d], response - [%s]", kind.name, statusCode, string(body))
+		}
+		if err != nil {
+			previousStatus.LastTransitionTime = metav1.Now()
+			statuses = append(statuses, failedManagedResourceStatus(previousStatus, err))
+		}
+	}
+	return statuses
+}
+
+func findManagedResourceStatus(statuses []opensearchservice.ManagedResourceStatus,
+	name string) *opensearchservice.ManagedResourceStatus {
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+func failedManagedResourceStatus(status opensearchservice.ManagedResourceStatus,
+	err error) opensearchservice.ManagedResourceStatus {
+	status.Status = managedResourceFailedStatus
+	status.Message = err.Error()
+	return status
+}
@@ -0,0 +1,144 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestApplyManagedResource(t *testing.T) {
+	templateBody := `{"index_patterns":["logs-*"],"template":{"settings":{"number_of_shards":1}}}`
+	specHash, err := util.Hash(apiextensionsv1.JSON{Raw: []byte(templateBody)})
+	assert.NoError(t, err)
+	observedHash, err := util.Hash(json.RawMessage(templateBody))
+	assert.NoError(t, err)
+	existingTemplate := `{"index_templates":[{"name":"logs","index_template":` + templateBody + `}]}`
+	templatePath := "_index_template/logs"
+
+	policyBody := `{"policy":{"description":"Delete old logs","states":[]}}`
+	existingPolicy := `{"_id":"logs","_seq_no":5,"_primary_term":2,"policy":{"description":"Delete old logs","states":[]}}`
+	policyPath := "_plugins/_ism/policies/logs"
+	conditionalPolicyPath := policyPath + "?if_seq_no=5&if_primary_term=2"
+
+	tests := []struct {
+		name                 string
+		kind                 managedResourceKind
+		body                 string
+		existing             string
+		putStatusCode        int
+		previous             *opensearchservice.ManagedResourceStatus
+		expectedPath         string
+		expectedStatus       string
+		expectedDrifted      bool
+		expectedObservedHash string
+	}{
+		{name: "new index template is created", kind: indexTemplateKind, body: templateBody,
+			putStatusCode: http.StatusOK, expectedPath: templatePath, expectedStatus: managedResourceAppliedStatus},
+		{name: "unchanged index template is not applied again", kind: indexTemplateKind, body: templateBody,
+			existing: existingTemplate,
+			previous: &opensearchservice.ManagedResourceStatus{Name: "logs", Status: managedResourceAppliedStatus,
+				SpecHash: specHash, ObservedHash: observedHash},
+			expectedStatus: managedResourceAppliedStatus, expectedObservedHash: observedHash},
+		{name: "changed index template is updated", kind: indexTemplateKind, body: templateBody,
+			existing: existingTemplate, putStatusCode: http.StatusOK,
+			previous: &opensearchservice.ManagedResourceStatus{Name: "logs", Status: managedResourceAppliedStatus,
+				SpecHash: "previous", ObservedHash: observedHash},
+			expectedPath: templatePath, expectedStatus: managedResourceAppliedStatus, expectedObservedHash: observedHash},
+		{name: "index template changed outside of the operator is reverted", kind: indexTemplateKind, body: templateBody,
+			existing: existingTemplate, putStatusCode: http.StatusOK,
+			previous: &opensearchservice.ManagedResourceStatus{Name: "logs", Status: managedResourceAppliedStatus,
+				SpecHash: specHash, ObservedHash: "changed"},
+			expectedPath: templatePath, expectedStatus: managedResourceAppliedStatus, expectedDrifted: true,
+			expectedObservedHash: observedHash},
+		{name: "rejected index template is failed", kind: indexTemplateKind, body: templateBody,
+			putStatusCode: http.StatusBadRequest, expectedPath: templatePath, expectedStatus: managedResourceFailedStatus},
+		{name: "existing ISM policy is updated with sequence number", kind: ismPolicyKind, body: policyBody,
+			existing: existingPolicy, putStatusCode: http.StatusOK,
+			previous: &opensearchservice.ManagedResourceStatus{Name: "logs", Status: managedResourceAppliedStatus,
+				SpecHash: "previous"},
+			expectedPath: conditionalPolicyPath, expectedStatus: managedResourceAppliedStatus},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := newOpenSearchMock(t)
+			if test.putStatusCode != 0 {
+				mock.on(http.MethodPut, test.expectedPath, test.putStatusCode, `{}`)
+			}
+			if test.existing != "" {
+				mock.on(http.MethodGet, "_index_template/logs", http.StatusOK, test.existing).
+					on(http.MethodGet, policyPath, http.StatusOK, test.existing)
+			}
+			resource := opensearchservice.IndexManagementResource{Name: "logs", Body: apiextensionsv1.JSON{Raw: []byte(test.body)}}
+
+			status := applyManagedResource(mock.restClient(), logr.Discard(), test.kind, resource, test.previous)
+
+			assert.Equal(t, "logs", status.Name)
+			assert.Equal(t, test.expectedStatus, status.Status)
+			assert.Equal(t, test.expectedDrifted, status.Drifted)
+			if test.expectedStatus == managedResourceAppliedStatus {
+				expectedSpecHash, err := util.Hash(resource.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedSpecHash, status.SpecHash)
+				if test.kind.name == indexTemplateKind.name {
+					assert.Equal(t, test.expectedObservedHash, status.ObservedHash)
+				}
+			} else {
+				assert.NotEmpty(t, status.Message)
+			}
+			if test.expectedPath == "" {
+				assert.Empty(t, mock.received(http.MethodPut, templatePath))
+				assert.Empty(t, mock.received(http.MethodPut, policyPath))
+			} else {
+				received := mock.received(http.MethodPut, test.expectedPath)
+				assert.Len(t, received, 1)
+				if len(received) == 1 {
+					assert.JSONEq(t, test.body, received[0])
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteRemovedManagedResources(t *testing.T) {
+	mock := newOpenSearchMock(t).
+		on(http.MethodDelete, "_component_template/removed", http.StatusOK, `{"acknowledged":true}`).
+		on(http.MethodDelete, "_component_template/in-use", http.StatusBadRequest, `{"error":"component template is in use"}`)
+	resources := []opensearchservice.IndexManagementResource{{Name: "kept"}}
+	previous := []opensearchservice.ManagedResourceStatus{
+		{Name: "kept", Status: managedResourceAppliedStatus},
+		{Name: "removed", Status: managedResourceAppliedStatus},
+		{Name: "in-use", Status: managedResourceAppliedStatus},
+		{Name: "already-deleted", Status: managedResourceAppliedStatus},
+	}
+
+	statuses := deleteRemovedManagedResources(mock.restClient(), logr.Discard(), componentTemplateKind, resources, previous)
+
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, "in-use", statuses[0].Name)
+	assert.Equal(t, managedResourceFailedStatus, statuses[0].Status)
+	assert.Contains(t, statuses[0].Message, "component template is in use")
+	assert.Empty(t, mock.received(http.MethodDelete, "_component_template/kept"))
+	for _, name := range []string{"removed", "in-use", "already-deleted"} {
+		assert.Len(t, mock.received(http.MethodDelete, "_component_template/"+name), 1, name)
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	goerrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace     = "opensearch_operator"
+	reconcilePhase       = "reconcile"
+	configurePhase       = "configure"
+	statusPhase          = "status"
+	reconcileSuccessful  = "success"
+	reconcileFailed      = "failure"
+	reconcileRequeued    = "requeue"
+	replicationRestarted = "success"
+	replicationFailed    = "failure"
+)
+
+var (
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_duration_seconds",
+		Help:      "Duration of reconcile phases of OpenSearchService components in seconds",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600},
+	}, []string{"namespace", "name", "reconciler", "phase"})
+	reconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_total",
+		Help:      "Number of reconcile phases of OpenSearchService components by result",
+	}, []string{"namespace", "name", "reconciler", "phase", "result"})
+	disasterRecoveryMode = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "disaster_recovery_mode",
+		Help:      "Current disaster recovery mode of OpenSearch, the series with the current mode has value 1",
+	}, []string{"namespace", "name", "mode"})
+	switchoverStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "disaster_recovery_switchover_status",
+		Help:      "Status of the last switchover of OpenSearch, the series with the current status has value 1",
+	}, []string{"namespace", "name", "status"})
+	switchoverDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "disaster_recovery_switchover_duration_seconds",
+		Help:      "Duration of the last switchover of OpenSearch in seconds",
+	}, []string{"namespace", "name", "mode"})
+	usersRecovery = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "users_recovery_state",
+		Help:      "State of OpenSearch users recovery during the switchover, the series with the current state has value 1",
+	}, []string{"namespace", "name", "state"})
+	replicationWatcherRestarts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "replication_watcher_restarts_total",
+		Help:      "Number of replication restarts performed by the replication watcher by result",
+	}, []string{"namespace", "name", "result"})
+	rollingUpdateStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "rolling_update_status",
+		Help:      "Status of the operator rolling update, the series with the current status has value 1",
+	}, []string{"namespace", "name", "status"})
+	rollingUpdatePods = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "rolling_update_pods",
+		Help:      "Number of pods to restart during the operator rolling update by state",
+	}, []string{"namespace", "name", "state"})
+	snapshotRepositoryVerified = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "snapshot_repository_verified",
+		Help:      "Result of the last verification of the snapshot repository, 1 if the repository is verified and 0 otherwise",
+	}, []string{"namespace", "name", "repository"})
+	clusterHealth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "cluster_health",
+		Help:      "Last observed health of OpenSearch cluster, the series with the current health has value 1",
+	}, []string{"namespace", "name", "health"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileDuration, reconcileTotal, disasterRecoveryMode, switchoverStatus,
+		switchoverDuration, usersRecovery, replicationWatcherRestarts, rollingUpdateStatus, rollingUpdatePods,
+		snapshotRepositoryVerified, clusterHealth)
+}
+
+// customResourceLabels returns labels identifying metrics of the custom resource
+func customResourceLabels(cr *opensearchservice.OpenSearchService) prometheus.Labels {
+	return prometheus.Labels{"namespace": cr.Namespace, "name": cr.Name}
+}
+
+// getReconcilerName returns name of the reconcile service used in metrics, for example, "OpenSearchReconciler"
+func getReconcilerName(reconciler ReconcileService) string {
+	name := fmt.Sprintf("%T", reconciler)
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// observeReconcile records duration and result of the reconcile phase of the reconcile service
+func observeReconcile(cr *opensearchservice.OpenSearchService, reconciler ReconcileService, phase string,
+	start time.Time, err error) {
+	name := getReconcilerName(reconciler)
+	reconcileDuration.WithLabelValues(cr.Namespace, cr.Name, name, phase).Observe(time.Since(start).Seconds())
+	result := reconcileSuccessful
+	var requeueError RequeueError
+	if goerrors.As(err, &requeueError) {
+		result = reconcileRequeued
+	} else if err != nil {
+		result = reconcileFailed
+	}
+	reconcileTotal.WithLabelValues(cr.Namespace, cr.Name, name, phase, result).Inc()
+}
+
+// setStateMetric sets 1 to the series with the state and removes series with other states of the custom resource
+func setStateMetric(gauge *prometheus.GaugeVec, cr *opensearchservice.OpenSearchService, state string) {
+	gauge.DeletePartialMatch(customResourceLabels(cr))
+	if state != "" {
+		gauge.WithLabelValues(cr.Namespace, cr.Name, state).Set(1)
+	}
+}
+
+func recordDisasterRecoveryMetrics(cr *opensearchservice.OpenSearchService, status string) {
+	setStateMetric(disasterRecoveryMode, cr, cr.Spec.DisasterRecovery.Mode)
+	setStateMetric(switchoverStatus, cr, status)
+}
+
+func recordSwitchoverDuration(cr *opensearchservice.OpenSearchService, start time.Time) {
+	switchoverDuration.DeletePartialMatch(customResourceLabels(cr))
+	switchoverDuration.WithLabelValues(cr.Namespace, cr.Name, cr.Spec.DisasterRecovery.Mode).
+		Set(time.Since(start).Seconds())
+}
+
+func recordUsersRecoveryState(cr *opensearchservice.OpenSearchService, state string) {
+	setStateMetric(usersRecovery, cr, state)
+}
+
+func recordReplicationRestart(cr *opensearchservice.OpenSearchService, err error) {
+	result := replicationRestarted
+	if err != nil {
+		result = replicationFailed
+	}
+	replicationWatcherRestarts.WithLabelValues(cr.Namespace, cr.Name, result).Inc()
+}
+
+func recordRollingUpdateMetrics(cr *opensearchservice.OpenSearchService) {
+	status := cr.Status.RollingUpdateStatus
+	setStateMetric(rollingUpdateStatus, cr, status.Status)
+	rollingUpdatePods.WithLabelValues(cr.Namespace, cr.Name, "total").Set(float64(len(status.RestartOrder)))
+	rollingUpdatePods.WithLabelValues(cr.Namespace, cr.Name, "restarting").Set(float64(len(status.CurrentPods)))
+	rollingUpdatePods.WithLabelValues(cr.Namespace, cr.Name, "completed").Set(float64(len(status.CompletedPods)))
+}
+
+func recordSnapshotRepositoryVerifications(cr *opensearchservice.OpenSearchService,
+	verifications []SnapshotRepositoryVerification) {
+	snapshotRepositoryVerified.DeletePartialMatch(customResourceLabels(cr))
+	for _, verification := range verifications {
+		value := 0.0
+		if verification.Error == "" {
+			value = 1
+		}
+		snapshotRepositoryVerified.WithLabelValues(cr.Namespace, cr.Name, verification.Name).Set(value)
+	}
+}
+
+func recordClusterHealth(cr *opensearchservice.OpenSearchService, health string) {
+	setStateMetric(clusterHealth, cr, health)
+}
+
+// deleteMetrics removes all series of the deleted custom resource
+func deleteMetrics(namespace string, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+	collectors := []interface {
+		DeletePartialMatch(labels prometheus.Labels) int
+	}{reconcileDuration, reconcileTotal, disasterRecoveryMode, switchoverStatus, switchoverDuration,
+		usersRecovery, replicationWatcherRestarts, rollingUpdateStatus, rollingUpdatePods,
+		snapshotRepositoryVerified, clusterHealth}
+	for _, collector := range collectors {
+		collector.DeletePartialMatch(labels)
+	}
+}
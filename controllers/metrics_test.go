@@ -0,0 +1,182 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestObserveReconcile(t *testing.T) {
+	cr := newMetricsCR(t, "observe-reconcile")
+	reconciler := OpenSearchReconciler{}
+	start := time.Now()
+
+	observeReconcile(cr, reconciler, reconcilePhase, start, nil)
+	observeReconcile(cr, reconciler, reconcilePhase, start, fmt.Errorf("cluster is not available"))
+	observeReconcile(cr, reconciler, reconcilePhase, start,
+		fmt.Errorf("unable to restart pods: %w", RequeueError{After: time.Second, Message: "waiting for pods"}))
+	observeReconcile(cr, reconciler, statusPhase, start, nil)
+
+	for _, test := range []struct {
+		phase    string
+		result   string
+		expected float64
+	}{
+		{phase: reconcilePhase, result: reconcileSuccessful, expected: 1},
+		{phase: reconcilePhase, result: reconcileFailed, expected: 1},
+		{phase: reconcilePhase, result: reconcileRequeued, expected: 1},
+		{phase: statusPhase, result: reconcileSuccessful, expected: 1},
+		{phase: configurePhase, result: reconcileSuccessful, expected: 0},
+	} {
+		assert.Equal(t, test.expected, testutil.ToFloat64(reconcileTotal.WithLabelValues(cr.Namespace, cr.Name,
+			"OpenSearchReconciler", test.phase, test.result)), test.phase+" "+test.result)
+	}
+	assert.Equal(t, 2, countSeries(reconcileDuration, cr))
+}
+
+func TestGetReconcilerName(t *testing.T) {
+	assert.Equal(t, "OpenSearchReconciler", getReconcilerName(OpenSearchReconciler{}))
+	assert.Equal(t, "ExternalOpenSearchReconciler", getReconcilerName(ExternalOpenSearchReconciler{}))
+}
+
+func TestSetStateMetric(t *testing.T) {
+	cr := newMetricsCR(t, "state-metric")
+
+	recordClusterHealth(cr, "green")
+	recordClusterHealth(cr, "yellow")
+
+	assert.Equal(t, 1, countSeries(clusterHealth, cr))
+	assert.Equal(t, float64(1), testutil.ToFloat64(clusterHealth.WithLabelValues(cr.Namespace, cr.Name, "yellow")))
+
+	recordClusterHealth(cr, "")
+	assert.Equal(t, 0, countSeries(clusterHealth, cr))
+}
+
+func TestRecordDisasterRecoveryMetrics(t *testing.T) {
+	cr := newMetricsCR(t, "disaster-recovery")
+	cr.Spec.DisasterRecovery = &opensearchservice.DisasterRecovery{Mode: "standby"}
+
+	recordDisasterRecoveryMetrics(cr, "running")
+	recordDisasterRecoveryMetrics(cr, "done")
+	recordSwitchoverDuration(cr, time.Now().Add(-time.Minute))
+	recordUsersRecoveryState(cr, usersRecoveryDoneState)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(disasterRecoveryMode.WithLabelValues(cr.Namespace, cr.Name, "standby")))
+	assert.Equal(t, 1, countSeries(switchoverStatus, cr))
+	assert.Equal(t, float64(1), testutil.ToFloat64(switchoverStatus.WithLabelValues(cr.Namespace, cr.Name, "done")))
+	assert.GreaterOrEqual(t, testutil.ToFloat64(switchoverDuration.WithLabelValues(cr.Namespace, cr.Name, "standby")), float64(60))
+	assert.Equal(t, float64(1), testutil.ToFloat64(usersRecovery.WithLabelValues(cr.Namespace, cr.Name, usersRecoveryDoneState)))
+}
+
+func TestRecordReplicationRestart(t *testing.T) {
+	cr := newMetricsCR(t, "replication-restart")
+
+	recordReplicationRestart(cr, nil)
+	recordReplicationRestart(cr, nil)
+	recordReplicationRestart(cr, fmt.Errorf("replication is not started"))
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(replicationWatcherRestarts.WithLabelValues(cr.Namespace, cr.Name, replicationRestarted)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(replicationWatcherRestarts.WithLabelValues(cr.Namespace, cr.Name, replicationFailed)))
+}
+
+func TestRecordRollingUpdateMetrics(t *testing.T) {
+	cr := newMetricsCR(t, "rolling-update")
+	cr.Status.RollingUpdateStatus = opensearchservice.RollingUpdateStatus{
+		Status:        "running",
+		RestartOrder:  []string{"opensearch-0", "opensearch-1", "opensearch-2"},
+		CurrentPods:   []string{"opensearch-1"},
+		CompletedPods: []string{"opensearch-0"},
+	}
+
+	recordRollingUpdateMetrics(cr)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(rollingUpdateStatus.WithLabelValues(cr.Namespace, cr.Name, "running")))
+	for state, expected := range map[string]float64{"total": 3, "restarting": 1, "completed": 1} {
+		assert.Equal(t, expected, testutil.ToFloat64(rollingUpdatePods.WithLabelValues(cr.Namespace, cr.Name, state)), state)
+	}
+}
+
+func TestRecordSnapshotRepositoryVerifications(t *testing.T) {
+	cr := newMetricsCR(t, "snapshot-repositories")
+	recordSnapshotRepositoryVerifications(cr, []SnapshotRepositoryVerification{{Name: "removed"}})
+
+	recordSnapshotRepositoryVerifications(cr, []SnapshotRepositoryVerification{
+		{Name: "snapshots", Nodes: []string{"opensearch-0"}},
+		{Name: "archive", Error: "Access denied"},
+	})
+
+	assert.Equal(t, 2, countSeries(snapshotRepositoryVerified, cr))
+	assert.Equal(t, float64(1), testutil.ToFloat64(snapshotRepositoryVerified.WithLabelValues(cr.Namespace, cr.Name, "snapshots")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(snapshotRepositoryVerified.WithLabelValues(cr.Namespace, cr.Name, "archive")))
+}
+
+func TestDeleteMetrics(t *testing.T) {
+	cr := newMetricsCR(t, "deleted")
+	other := newMetricsCR(t, "kept")
+	for _, resource := range []*opensearchservice.OpenSearchService{cr, other} {
+		recordClusterHealth(resource, "green")
+		recordReplicationRestart(resource, nil)
+		observeReconcile(resource, OpenSearchReconciler{}, reconcilePhase, time.Now(), nil)
+	}
+
+	deleteMetrics(cr.Namespace, cr.Name)
+
+	assert.Equal(t, 0, countSeries(clusterHealth, cr))
+	assert.Equal(t, 0, countSeries(replicationWatcherRestarts, cr))
+	assert.Equal(t, 0, countSeries(reconcileTotal, cr))
+	assert.Equal(t, 1, countSeries(clusterHealth, other))
+	assert.Equal(t, 1, countSeries(reconcileTotal, other))
+}
+
+// newMetricsCR returns custom resource with the unique name, so that series of tests do not affect each other,
+// the series are removed when the test is finished
+func newMetricsCR(t *testing.T, name string) *opensearchservice.OpenSearchService {
+	cr := &opensearchservice.OpenSearchService{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "metrics-test"}}
+	t.Cleanup(func() {
+		deleteMetrics(cr.Namespace, cr.Name)
+	})
+	return cr
+}
+
+// countSeries returns the number of series of the collector with labels of the custom resource
+func countSeries(collector prometheus.Collector, cr *opensearchservice.OpenSearchService) int {
+	metricsChannel := make(chan prometheus.Metric)
+	go func() {
+		collector.Collect(metricsChannel)
+		close(metricsChannel)
+	}()
+	count := 0
+	for metric := range metricsChannel {
+		written := &dto.Metric{}
+		_ = metric.Write(written)
+		labels := map[string]string{}
+		for _, label := range written.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		if labels["namespace"] == cr.Namespace && labels["name"] == cr.Name {
+			count++
+		}
+	}
+	return count
+}
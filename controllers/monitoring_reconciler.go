@@ -30,6 +30,7 @@ type MonitoringReconciler struct {
 	cr         *opensearchservice.OpenSearchService
 	logger     logr.Logger
 	reconciler *OpenSearchServiceReconciler
+	state      *InstanceState
 }
 
 func NewMonitoringReconciler(r *OpenSearchServiceReconciler, cr *opensearchservice.OpenSearchService,
@@ -38,6 +39,7 @@ func NewMonitoringReconciler(r *OpenSearchServiceReconciler, cr *opensearchservi
 		cr:         cr,
 		logger:     logger,
 		reconciler: r,
+		state:      r.getState(cr),
 	}
 }
 
@@ -58,11 +60,11 @@ func (r MonitoringReconciler) Reconcile() error {
 		return err
 	}
 
-	if r.reconciler.ResourceHashes[opensearchSecretHashName] != "" && r.reconciler.ResourceHashes[opensearchSecretHashName] != opensearchSecretHash ||
-		r.reconciler.ResourceHashes[opensearchOldSecretHashName] != "" && r.reconciler.ResourceHashes[opensearchOldSecretHashName] != opensearchOldSecretHash ||
-		r.reconciler.ResourceHashes[monitoringSecretHashName] != "" && r.reconciler.ResourceHashes[monitoringSecretHashName] != monitoringSecretHash {
+	if r.state.ResourceHashes[opensearchSecretHashName] != "" && r.state.ResourceHashes[opensearchSecretHashName] != r.state.opensearchSecretHash ||
+		r.state.ResourceHashes[opensearchOldSecretHashName] != "" && r.state.ResourceHashes[opensearchOldSecretHashName] != opensearchOldSecretHash ||
+		r.state.ResourceHashes[monitoringSecretHashName] != "" && r.state.ResourceHashes[monitoringSecretHashName] != monitoringSecretHash {
 		annotations := map[string]string{
-			opensearchSecretHashName:    opensearchSecretHash,
+			opensearchSecretHashName:    r.state.opensearchSecretHash,
 			opensearchOldSecretHashName: opensearchOldSecretHash,
 			monitoringSecretHashName:    monitoringSecretHash,
 		}
@@ -76,28 +78,31 @@ func (r MonitoringReconciler) Reconcile() error {
 	if err != nil {
 		return err
 	}
-	if r.reconciler.ResourceHashes[opensearchSecretHashName] != "" && r.reconciler.ResourceHashes[opensearchSecretHashName] != opensearchSecretHash ||
-		r.reconciler.ResourceHashes[opensearchOldSecretHashName] != "" && r.reconciler.ResourceHashes[opensearchOldSecretHashName] != opensearchOldSecretHash ||
-		r.reconciler.ResourceHashes[monitoringSpecHashName] != monitoringSpecHash {
-		if r.cr.Spec.Monitoring.SlowQueries != nil || *r.reconciler.SlowLogIndicesWatcher.State != stoppedWatcherState {
+	if r.state.ResourceHashes[opensearchSecretHashName] != "" && r.state.ResourceHashes[opensearchSecretHashName] != r.state.opensearchSecretHash ||
+		r.state.ResourceHashes[opensearchOldSecretHashName] != "" && r.state.ResourceHashes[opensearchOldSecretHashName] != opensearchOldSecretHash ||
+		r.state.ResourceHashes[monitoringSpecHashName] != monitoringSpecHash {
+		if r.cr.Spec.Monitoring.SlowQueries != nil || r.state.SlowLogIndicesWatcher.isRunning() {
 			helper := r.prepareSlowLogIndicesHelper()
 			if r.cr.Spec.Monitoring.SlowQueries != nil {
-				r.reconciler.SlowLogIndicesWatcher.start(helper, r.cr.Spec.Monitoring.SlowQueries.IndicesPattern,
+				r.state.SlowLogIndicesWatcher.start(helper, r.cr.Spec.Monitoring.SlowQueries.IndicesPattern,
 					r.cr.Spec.Monitoring.SlowQueries.MinSeconds)
 			} else {
-				r.reconciler.SlowLogIndicesWatcher.stop(helper)
+				r.state.SlowLogIndicesWatcher.stop(helper)
 			}
 		}
 	}
 
-	r.reconciler.ResourceHashes[opensearchOldSecretHashName] = opensearchOldSecretHash
-	r.reconciler.ResourceHashes[monitoringSecretHashName] = monitoringSecretHash
-	r.reconciler.ResourceHashes[monitoringSpecHashName] = monitoringSpecHash
+	r.state.ResourceHashes[opensearchOldSecretHashName] = opensearchOldSecretHash
+	r.state.ResourceHashes[monitoringSecretHashName] = monitoringSecretHash
+	r.state.ResourceHashes[monitoringSpecHashName] = monitoringSpecHash
 	return nil
 }
 
 func (r MonitoringReconciler) Status() error {
-	return nil
+	componentStatus := r.reconciler.getComponentStatus(r.cr.Spec.Monitoring.Name, r.cr.Namespace, r.logger)
+	return util.NewStatusUpdater(r.reconciler.Client, r.cr).UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
+		instance.Status.MonitoringStatus = componentStatus
+	})
 }
 
 func (r MonitoringReconciler) Configure() error {
@@ -105,8 +110,8 @@ func (r MonitoringReconciler) Configure() error {
 }
 
 func (r MonitoringReconciler) prepareSlowLogIndicesHelper() SlowLogIndicesHelper {
-	url := r.reconciler.createUrl(r.cr.Name, opensearchHttpPort)
-	client, _ := r.reconciler.configureClient()
+	url := createUrl(r.cr.Name, opensearchHttpPort)
+	client, _ := configureClient()
 	credentials := r.reconciler.parseOpenSearchCredentials(r.cr, r.logger)
 	return SlowLogIndicesHelper{
 		logger:     r.logger,
@@ -0,0 +1,111 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// openSearchMock is the stub of OpenSearch REST API which returns configured responses by method and request URI
+// and records bodies of received requests. Requests without configured responses get 404 status code.
+type openSearchMock struct {
+	server    *httptest.Server
+	lock      sync.Mutex
+	responses map[string]mockResponse
+	requests  map[string][]string
+}
+
+type mockResponse struct {
+	statusCode int
+	body       string
+}
+
+func newOpenSearchMock(t *testing.T) *openSearchMock {
+	mock := &openSearchMock{responses: map[string]mockResponse{}, requests: map[string][]string{}}
+	mock.server = httptest.NewServer(http.HandlerFunc(mock.serve))
+	t.Cleanup(mock.server.Close)
+	return mock
+}
+
+// on sets the response to requests with the method and the URI relative to OpenSearch URL, for example "_cluster/health"
+func (m *openSearchMock) on(method string, uri string, statusCode int, body string) *openSearchMock {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.responses[method+" "+uri] = mockResponse{statusCode: statusCode, body: body}
+	return m
+}
+
+// received returns bodies of requests with the method and the URI in the order they are received
+func (m *openSearchMock) received(method string, uri string) []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.requests[method+" "+uri]
+}
+
+func (m *openSearchMock) restClient() *util.RestClient {
+	return util.NewRestClient(m.server.URL, http.Client{}, util.Credentials{}, util.WithRetryPolicy(util.RetryPolicy{}))
+}
+
+func (m *openSearchMock) serve(w http.ResponseWriter, r *http.Request) {
+	key := r.Method + " " + strings.TrimPrefix(r.URL.RequestURI(), "/")
+	body, _ := io.ReadAll(r.Body)
+	m.lock.Lock()
+	m.requests[key] = append(m.requests[key], string(body))
+	response, ok := m.responses[key]
+	m.lock.Unlock()
+	if !ok {
+		response = mockResponse{statusCode: http.StatusNotFound, body: `{}`}
+	}
+	w.WriteHeader(response.statusCode)
+	_, _ = w.Write([]byte(response.body))
+}
+
+// newFakeClient returns Kubernetes client which keeps the objects in memory, statuses of custom resources are updated
+// with status subresource as in the cluster
+func newFakeClient(objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = opensearchservice.AddToScheme(scheme)
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&opensearchservice.OpenSearchService{}, &opensearchservice.OpenSearchUser{},
+			&opensearchservice.OpenSearchRole{}, &opensearchservice.OpenSearchRoleMapping{}).
+		Build()
+}
+
+// newTestInstanceStates returns states of custom resources whose watchers are registered but never run,
+// because there is no manager to start them
+func newTestInstanceStates() InstanceStates {
+	return InstanceStates{
+		lock:                   &sync.Mutex{},
+		states:                 map[types.NamespacedName]*InstanceState{},
+		replicationWatchers:    newWatcherGroup("replication-watchers"),
+		slowLogIndicesWatchers: newWatcherGroup("slowlog-indices-watchers"),
+	}
+}
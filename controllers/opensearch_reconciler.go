@@ -17,6 +17,7 @@ package controllers
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -26,7 +27,7 @@ import (
 
 	"gopkg.in/yaml.v3"
 	v1 "k8s.io/api/apps/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/strings/slices"
 
 	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
@@ -47,11 +48,20 @@ const (
 	flushPath                      = "_flush"
 	clusterHealthPath              = "_cluster/health"
 	clusterSettingsPath            = "_cluster/settings"
+	authInfoPath                   = "_plugins/_security/authinfo"
 	allAccess                      = "all_access"
 )
 
 type OpenSearchHealth struct {
-	Status string `json:"status"`
+	Status               string `json:"status"`
+	NumberOfNodes        int    `json:"number_of_nodes"`
+	NumberOfDataNodes    int    `json:"number_of_data_nodes"`
+	ActivePrimaryShards  int    `json:"active_primary_shards"`
+	ActiveShards         int    `json:"active_shards"`
+	RelocatingShards     int    `json:"relocating_shards"`
+	InitializingShards   int    `json:"initializing_shards"`
+	UnassignedShards     int    `json:"unassigned_shards"`
+	NumberOfPendingTasks int    `json:"number_of_pending_tasks"`
 }
 
 type FlushResult struct {
@@ -86,6 +96,7 @@ type OpenSearchReconciler struct {
 	cr         *opensearchservice.OpenSearchService
 	logger     logr.Logger
 	reconciler *OpenSearchServiceReconciler
+	state      *InstanceState
 }
 
 type MappingAllAccess struct {
@@ -108,12 +119,14 @@ func NewOpenSearchReconciler(r *OpenSearchServiceReconciler, cr *opensearchservi
 		cr:         cr,
 		logger:     logger,
 		reconciler: r,
+		state:      r.getState(cr),
 	}
 }
 
 func (r OpenSearchReconciler) Reconcile() error {
-	if !r.cr.Spec.OpenSearch.RollingUpdate {
-		r.logger.Info("Rolling Update is disabled, so skip reconcile procedure")
+	action, reason := getStopReason(r.cr)
+	if action != "" && !isRollingUpdateStopRequired(r.cr, action) {
+		r.logger.Info(fmt.Sprintf("%s, so skip reconcile procedure", reason))
 		return nil
 	}
 
@@ -124,6 +137,20 @@ func (r OpenSearchReconciler) Reconcile() error {
 		r.logger.Error(err, "Error while creating rest client with old creds")
 		return err
 	}
+	// Pods which are already restarted are checked before the rolling update is stopped
+	if action != "" && len(r.cr.Status.RollingUpdateStatus.CurrentPods) == 0 {
+		return r.stopRollingUpdate(client, action, reason)
+	}
+	if r.cr.Status.RollingUpdateStatus.Status == rollingUpdateHaltedStatus {
+		if err = r.checkPreflightGates(client, false); err != nil {
+			var gateError *GateError
+			if errors.As(err, &gateError) {
+				return r.haltRollingUpdate(gateError)
+			}
+			return err
+		}
+		r.logger.Info("Rolling update gates are passed, halted rolling update is continued")
+	}
 
 	statefulSets, err := r.getStatefulSets()
 	if err != nil {
@@ -194,28 +221,6 @@ func (r OpenSearchReconciler) isOpenSearchHealthy(restClient *util.RestClient) (
 	return health.Status == "green", nil
 }
 
-func (r OpenSearchReconciler) checkOpenSearchHealth(restClient *util.RestClient) error {
-	r.logger.Info("Checking OpenSearch Health with retries...")
-
-	var lastError error
-	err := wait.PollImmediate(healthCheckInterval, healthCheckTimeout, func() (bool, error) {
-		var isHealthy bool
-		isHealthy, lastError = r.isOpenSearchHealthy(restClient)
-		if isHealthy {
-			r.logger.Info("OpenSearch is healthy.")
-			return true, nil
-		}
-		r.logger.Info("OpenSearch is not healthy yet ...")
-		return false, nil
-	})
-	if err != nil {
-		r.logger.Info(fmt.Sprintf("OpenSearch is not healthy. The last error: %o", lastError))
-		return err
-	}
-
-	return nil
-}
-
 func (r OpenSearchReconciler) needToPerformRollingUpdate(client *util.RestClient, statefulSets []*v1.StatefulSet) (bool, error) {
 	for _, statefulSet := range statefulSets {
 		if statefulSet.Spec.UpdateStrategy.Type != v1.OnDeleteStatefulSetStrategyType {
@@ -230,6 +235,10 @@ func (r OpenSearchReconciler) needToPerformRollingUpdate(client *util.RestClient
 		r.logger.Info("Operator Rolling Update state is running, need to continue upgrade")
 		return true, nil
 	}
+	if isRollingUpdateInterrupted(r.cr) {
+		r.logger.Info("Operator Rolling Update is resumed, need to continue upgrade")
+		return true, nil
+	}
 
 	allNodesAlreadyUpdated := true
 	for _, statefulSet := range statefulSets {
@@ -243,10 +252,13 @@ func (r OpenSearchReconciler) needToPerformRollingUpdate(client *util.RestClient
 		return false, nil
 	}
 
-	if err := r.checkOpenSearchHealth(client); err != nil {
+	healthy, err := r.isOpenSearchHealthy(client)
+	if err != nil {
 		return false, err
 	}
-
+	if !healthy {
+		return false, RequeueError{After: healthCheckInterval, Message: "waiting for OpenSearch to become green before the rolling update"}
+	}
 	return true, nil
 }
 
@@ -333,20 +345,12 @@ func (r OpenSearchReconciler) changeAllocationSetting(enableAllocation bool, cli
 		r.logger.Error(err, "Error while marshalling settings with allocation")
 		return err
 	}
-	body := strings.NewReader(string(bytes_))
-
-	r.logger.Info("Try to update settings request with retires...")
-	var requestError error
-	err = wait.PollImmediate(interval, timeout, func() (done bool, err error) {
-		if err = r.updateSettings(client, body); err != nil {
-			requestError = err
-			return false, nil
-		}
-		return true, nil
-	})
+	// The request is not retried here to keep the reconciliation short, it is repeated in the next cycle instead
+	_, err = client.WithRetryPolicy(util.RetryPolicy{}).
+		SendRequestWithStatusCodeCheck(http.MethodPut, clusterSettingsPath, bytes.NewReader(bytes_))
 	if err != nil {
-		log.Error(err, fmt.Sprintf("Error while sending request. The last error: %o", requestError))
-		return err
+		log.Error(err, "Error while updating OpenSearch settings")
+		return RequeueError{After: waitingInterval, Message: fmt.Sprintf("unable to change shard allocation: %v", err)}
 	}
 
 	returnedSettings, err := r.getSettings(client)
@@ -354,11 +358,17 @@ func (r OpenSearchReconciler) changeAllocationSetting(enableAllocation bool, cli
 		return err
 	}
 
-	enabled := returnedSettings.Persistent.Cluster.Routing.Allocation.Enable
-	if enabled != nil {
-		log.Info(fmt.Sprintf("New allocation status: %s", *enabled))
+	allocation := "all"
+	if enabled := returnedSettings.Persistent.Cluster.Routing.Allocation.Enable; enabled != nil {
+		allocation = *enabled
+	}
+	log.Info(fmt.Sprintf("New allocation status: %s", allocation))
+	if enableAllocation {
+		r.reconciler.recordEvent(r.cr, corev1.EventTypeNormal, allocationEnabledReason,
+			"Shard allocation is changed to %s", allocation)
 	} else {
-		log.Info("New allocation status: all")
+		r.reconciler.recordEvent(r.cr, corev1.EventTypeNormal, allocationDisabledReason,
+			"Shard allocation is changed to %s", allocation)
 	}
 
 	return nil
@@ -386,7 +396,7 @@ func (r OpenSearchReconciler) enableAllocationIfNecessary(client *util.RestClien
 func (r OpenSearchReconciler) execFlushProcedure(client *util.RestClient) error {
 	r.logger.Info("Sending the flush procedure request...")
 
-	responseBody, err := client.SendRequestWithStatusCodeCheck(http.MethodPost, flushPath, nil)
+	responseBody, err := client.WithNonIdempotentRetries().SendRequestWithStatusCodeCheck(http.MethodPost, flushPath, nil)
 	if err != nil {
 		r.logger.Error(err, "Error while requesting flush procedure")
 		return err
@@ -401,18 +411,31 @@ func (r OpenSearchReconciler) execFlushProcedure(client *util.RestClient) error
 	if result.Shards.Failed != 0 {
 		return fmt.Errorf("flush procedure finished with %d failed shards", result.Shards.Failed)
 	}
+	return r.enterRollingUpdatePhase(rollingUpdateFlushPhase, "")
+}
 
-	sleepPeriod := 20 * time.Second
-	r.logger.Info(fmt.Sprintf("Sleep for %s while OpenSearch process flush request ...", sleepPeriod))
-	time.Sleep(sleepPeriod)
-	return nil
+// waitForFlush gives OpenSearch time to process the flush request before pods are restarted
+func (r OpenSearchReconciler) waitForFlush() error {
+	status := r.cr.Status.RollingUpdateStatus
+	if status.Phase != rollingUpdateFlushPhase {
+		return nil
+	}
+	if status.PhaseStartTime != nil {
+		if remaining := flushWaitPeriod - time.Since(status.PhaseStartTime.Time); remaining > 0 {
+			return r.requeueRollingUpdate(remaining, "waiting for OpenSearch to process flush request")
+		}
+	}
+	return r.leaveRollingUpdatePhase()
 }
 
-func (r OpenSearchReconciler) runRollingUpdate(client *util.RestClient, statefulSets []*v1.StatefulSet) error {
+// runRollingUpdate performs the step of the rolling update, RequeueError is returned while the rolling update
+// waits for OpenSearch and shard allocation is kept disabled until the rolling update is finished or stopped
+func (r OpenSearchReconciler) runRollingUpdate(client *util.RestClient, statefulSets []*v1.StatefulSet) (err error) {
 	r.logger.Info("Running Rolling Update procedure...")
 	enabledAllocationAfterPodRestart := false
 	defer func() {
-		if enabledAllocationAfterPodRestart {
+		var requeueError RequeueError
+		if enabledAllocationAfterPodRestart || errors.As(err, &requeueError) {
 			return
 		}
 		if err := r.changeAllocationSetting(true, client); err != nil {
@@ -427,57 +450,46 @@ func (r OpenSearchReconciler) runRollingUpdate(client *util.RestClient, stateful
 		if err := r.execFlushProcedure(client); err != nil {
 			return err
 		}
-		if err := r.updateRollingUpdateStatus(rollingUpdateRunningStatus); err != nil {
+		if err := r.startRollingUpdate(); err != nil {
 			return err
 		}
 	}
 
-	if err := r.restartOpenSearchPods(statefulSets); err != nil {
-		r.logger.Error(err, "Error while OpenSearch pods restarting")
-		return err
-	}
-
-	enabledAllocationAfterPodRestart = true
-	if err := r.changeAllocationSetting(true, client); err != nil {
-		r.logger.Error(err, "Error while enabling location after rolling update.")
-		return err
-	}
-
-	if err := r.checkOpenSearchHealth(client); err != nil {
+	interrupted, err := r.restartOpenSearchPods(client, statefulSets)
+	if err != nil {
+		var requeueError RequeueError
+		if !errors.As(err, &requeueError) {
+			r.logger.Error(err, "Error while OpenSearch pods restarting")
+		}
 		return err
 	}
-
-	if err := r.updateRollingUpdateStatus(rollingUpdateDoneStatus); err != nil {
-		return err
+	if interrupted {
+		// Allocation is enabled in the deferred function
+		return nil
 	}
 
-	return nil
-}
-
-func (r OpenSearchReconciler) restartOpenSearchPods(statefulSets []*v1.StatefulSet) error {
-	for _, statefulSet := range statefulSets {
-		status, err := r.findStatefulSetStatus(statefulSet)
-		if err != nil {
+	enabledAllocationAfterPodRestart = true
+	if r.cr.Status.RollingUpdateStatus.Phase != rollingUpdateClusterHealthPhase {
+		if err := r.changeAllocationSetting(true, client); err != nil {
+			r.logger.Error(err, "Error while enabling location after rolling update.")
 			return err
 		}
-
-		if *statefulSet.Spec.Replicas != statefulSet.Status.UpdatedReplicas {
-			if err := r.restartOpenSearchPod(statefulSet, status); err != nil {
-				return err
-			}
-		} else {
-			r.logger.Info("All replicas are already updated")
+		if err := r.enterRollingUpdatePhase(rollingUpdateClusterHealthPhase, ""); err != nil {
+			return err
 		}
-
-		if len(status.UpdatedReplicas) != 0 {
-			r.logger.Info(fmt.Sprintf("Clear %s updated replicas slice in CR", statefulSet.Name))
-			status.UpdatedReplicas = []int32{}
-			if err := r.updateStatefulSetStatuses(); err != nil {
+	}
+	healthy, err := r.isOpenSearchHealthy(client)
+	if err != nil || !healthy {
+		if r.isRollingUpdatePhaseExpired(healthCheckTimeout) {
+			if err := r.leaveRollingUpdatePhase(); err != nil {
 				return err
 			}
+			return fmt.Errorf("OpenSearch is not green within %s after the rolling update", healthCheckTimeout)
 		}
+		return r.requeueRollingUpdate(healthCheckInterval, "waiting for OpenSearch to become green after the rolling update")
 	}
-	return nil
+
+	return r.completeRollingUpdate()
 }
 
 func (r OpenSearchReconciler) findStatefulSetStatus(statefulSet *v1.StatefulSet) (*opensearchservice.StatefulSetStatus, error) {
@@ -504,36 +516,6 @@ func (r OpenSearchReconciler) findStatefulSetStatus(statefulSet *v1.StatefulSet)
 	return newStatus, nil
 }
 
-func (r OpenSearchReconciler) restartOpenSearchPod(statefulSet *v1.StatefulSet, status *opensearchservice.StatefulSetStatus) error {
-	updatedReplicas, err := r.getUpdatedReplicasSlice(statefulSet, status)
-	if err != nil {
-		return err
-	}
-
-	for replica := *statefulSet.Spec.Replicas - 1; replica >= 0; replica-- {
-		if util.ArrayContains(updatedReplicas, replica) {
-			continue
-		}
-
-		podName := fmt.Sprintf("%s-%d", statefulSet.Name, replica)
-		r.logger.Info(fmt.Sprintf("Try to restart OpenSearch pod %s", podName))
-		if err := r.reconciler.deletePodByName(podName, r.cr.Namespace, r.logger); err != nil {
-			return err
-		}
-
-		if err := r.waitUntilOpenSearchPodIsReady(podName); err != nil {
-			return err
-		}
-
-		updatedReplicas = append(updatedReplicas, replica)
-		if err := r.uploadUpdatedReplicasSlice(updatedReplicas, status); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func (r OpenSearchReconciler) getUpdatedReplicasSlice(statefulSet *v1.StatefulSet, status *opensearchservice.StatefulSetStatus) ([]int32, error) {
 	if statefulSet.Generation != status.LastStatefulSetGeneration {
 		r.logger.Info("Current stateful set generation and generation in CR are different, so clear updated replicas slice and update the last generation.")
@@ -544,27 +526,28 @@ func (r OpenSearchReconciler) getUpdatedReplicasSlice(statefulSet *v1.StatefulSe
 	return status.UpdatedReplicas, nil
 }
 
-func (r OpenSearchReconciler) waitUntilOpenSearchPodIsReady(podName string) error {
-	r.logger.Info(fmt.Sprintf("Waiting until %s pod becomes ready...", podName))
-	return wait.Poll(podCheckInterval, podCheckTimeout, func() (bool, error) {
-		pod, err := r.reconciler.findPod(podName, r.cr.Namespace, r.logger)
-		if err != nil {
-			return false, nil
-		}
-
-		r.logger.Info(fmt.Sprintf("%s pod found, checking container status ...", podName))
-		if len(pod.Status.ContainerStatuses) == 0 {
-			r.logger.Info(fmt.Sprintf("%s pod doesn't have any container. Skip this check iteration", podName))
-			return false, nil
-		}
-
-		r.logger.Info(fmt.Sprintf("Container ready: %t", pod.Status.ContainerStatuses[0].Ready))
-		return pod.Status.ContainerStatuses[0].Ready, nil
-	})
-}
-
 func (r OpenSearchReconciler) Status() error {
-	return nil
+	url := createUrl(r.cr.Name, opensearchHttpPort)
+	client, err := configureClient()
+	if err != nil {
+		return err
+	}
+	credentials := r.reconciler.parseOpenSearchCredentials(r.cr, r.logger)
+	restClient := util.NewRestClient(url, client, credentials)
+	clusterStatus := getClusterStatus(restClient)
+	var explanations []SnapshotPolicyExplanation
+	var verifications []SnapshotRepositoryVerification
+	if clusterStatus.Health != unknownClusterHealth {
+		explanations = r.explainSnapshotPolicies(restClient, r.cr.Status.SnapshotPolicies)
+		verifications = r.verifySnapshotRepositories(restClient, r.cr.Status.SnapshotRepositories)
+		recordSnapshotRepositoryVerifications(r.cr, verifications)
+	}
+	recordClusterHealth(r.cr, clusterStatus.Health)
+	return util.NewStatusUpdater(r.reconciler.Client, r.cr).UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
+		instance.Status.OpenSearchStatus = clusterStatus
+		updateSnapshotPolicyExecutions(instance.Status.SnapshotPolicies, explanations)
+		updateSnapshotRepositoryVerifications(instance.Status.SnapshotRepositories, verifications)
+	})
 }
 
 func (r OpenSearchReconciler) Configure() error {
@@ -573,8 +556,31 @@ func (r OpenSearchReconciler) Configure() error {
 		return err
 	}
 
-	if r.cr.Spec.OpenSearch.Snapshots != nil {
-		if err = r.createSnapshotsRepository(restClient, 5); err != nil {
+	if err = r.reconcileSecureSettings(restClient); err != nil {
+		return err
+	}
+	if r.cr.Spec.OpenSearch.Snapshots != nil || len(r.cr.Status.SnapshotRepositories) > 0 {
+		if err = r.reconcileSnapshotRepositories(restClient); err != nil {
+			return err
+		}
+	}
+	if r.cr.Spec.OpenSearch.ClusterSettings != nil || r.cr.Status.ClusterSettingsStatus != nil {
+		if err = r.reconcileClusterSettings(restClient); err != nil {
+			return err
+		}
+	}
+	if r.cr.Spec.OpenSearch.Snapshots != nil || len(r.cr.Status.SnapshotPolicies) > 0 {
+		if err = r.reconcileSnapshotPolicies(restClient); err != nil {
+			return err
+		}
+	}
+	if r.cr.Spec.OpenSearch.TLSReload != nil {
+		if err = r.reconcileTLSReload(restClient); err != nil {
+			return err
+		}
+	}
+	if len(r.cr.Spec.OpenSearch.ScaleDown) > 0 || len(r.cr.Status.ScaleDownStatuses) > 0 {
+		if err = r.reconcileScaleDown(restClient); err != nil {
 			return err
 		}
 	}
@@ -583,12 +589,16 @@ func (r OpenSearchReconciler) Configure() error {
 }
 
 func (r OpenSearchReconciler) processSecurity() (*util.RestClient, error) {
-	url := r.reconciler.createUrl(r.cr.Name, opensearchHttpPort)
-	client, err := r.reconciler.configureClient()
+	url := createUrl(r.cr.Name, opensearchHttpPort)
+	client, err := configureClient()
 	if err != nil {
 		return nil, err
 	}
 	oldCredentials := r.reconciler.parseSecretCredentials(fmt.Sprintf(oldSecretPattern, r.cr.Name), r.cr.Namespace, r.logger)
+	oldCredentials, err = r.finishCredentialsRotation(url, client, oldCredentials)
+	if err != nil {
+		return nil, err
+	}
 
 	restClient := util.NewRestClient(url, client, oldCredentials)
 	allaccessRole, err := r.getRoleMapping(restClient, allAccess)
@@ -629,30 +639,34 @@ func (r OpenSearchReconciler) processSecurity() (*util.RestClient, error) {
 	if err != nil {
 		return restClient, err
 	}
-	if r.reconciler.ResourceHashes[opensearchConfigHashName] != "" && r.reconciler.ResourceHashes[opensearchConfigHashName] != opensearchConfigHash {
+	if r.state.ResourceHashes[opensearchConfigHashName] != "" && r.state.ResourceHashes[opensearchConfigHashName] != opensearchConfigHash {
 		err := r.updateSecurityConfiguration(restClient)
 		if err != nil {
+			r.reconciler.recordEvent(r.cr, corev1.EventTypeWarning, securityConfigUpdateFailedReason,
+				"Security configuration from %s secret is not applied: %v", r.cr.Spec.OpenSearch.SecurityConfigurationName, err)
 			return restClient, err
 		}
+		r.reconciler.recordEvent(r.cr, corev1.EventTypeNormal, securityConfigUpdatedReason,
+			"Security configuration from %s secret is applied", r.cr.Spec.OpenSearch.SecurityConfigurationName)
 	}
-	r.reconciler.ResourceHashes[opensearchConfigHashName] = opensearchConfigHash
+	r.state.ResourceHashes[opensearchConfigHashName] = opensearchConfigHash
 	opensearchRoleMappingHash, err :=
 		r.reconciler.calculateSecretDataHash(fmt.Sprintf("%s-ldap-rolemappings", r.cr.Name), opensearchRoleMappingsHashName, r.cr, r.logger)
 	if err == nil {
-		if r.reconciler.ResourceHashes[opensearchRoleMappingsHashName] == "" || (r.reconciler.ResourceHashes[opensearchRoleMappingsHashName] != "" && r.reconciler.ResourceHashes[opensearchRoleMappingsHashName] != opensearchRoleMappingHash) {
+		if r.state.ResourceHashes[opensearchRoleMappingsHashName] == "" || (r.state.ResourceHashes[opensearchRoleMappingsHashName] != "" && r.state.ResourceHashes[opensearchRoleMappingsHashName] != opensearchRoleMappingHash) {
 			err = r.updateLdapRolesmapping(restClient)
 			if err != nil {
 				return restClient, err
 			}
 		}
-		r.reconciler.ResourceHashes[opensearchRoleMappingsHashName] = opensearchRoleMappingHash
+		r.state.ResourceHashes[opensearchRoleMappingsHashName] = opensearchRoleMappingHash
 	}
 	return restClient, nil
 }
 
 func (r OpenSearchReconciler) createRestClientWithOldCreds() (*util.RestClient, error) {
-	url := r.reconciler.createUrl(r.cr.Name, opensearchHttpPort)
-	client, err := r.reconciler.configureClient()
+	url := createUrl(r.cr.Name, opensearchHttpPort)
+	client, err := configureClient()
 	if err != nil {
 		return nil, err
 	}
@@ -665,34 +679,76 @@ func (r OpenSearchReconciler) updateCredentials(url string, client http.Client,
 	restClient := util.NewRestClient(url, client, oldCredentials)
 	if newCredentials.Username != oldCredentials.Username ||
 		newCredentials.Password != oldCredentials.Password {
-		if newCredentials.Username != oldCredentials.Username {
-			if err := r.createNewUser(newCredentials.Username, newCredentials.Password, restClient); err != nil {
-				return restClient, err
+		if err := r.rotateCredentials(restClient, oldCredentials, newCredentials); err != nil {
+			var requeueError RequeueError
+			if !errors.As(err, &requeueError) {
+				r.reconciler.recordEvent(r.cr, corev1.EventTypeWarning, credentialsRotationFailedReason,
+					"Credentials of %s user are not rotated: %v", newCredentials.Username, err)
 			}
-			if err := r.removeUser(oldCredentials.Username, restClient); err != nil {
-				return restClient, err
-			}
-		} else {
-			if err := r.changeUserPassword(newCredentials.Username, newCredentials.Password, restClient); err != nil {
-				return restClient, err
-			}
-		}
-		err := wait.PollImmediate(waitingInterval, updateTimeout, func() (bool, error) {
-			err := r.reconciler.updateSecretWithCredentials(fmt.Sprintf(oldSecretPattern, r.cr.Name), r.cr.Namespace, newCredentials, r.logger)
-			if err != nil {
-				r.logger.Error(err, "Unable to update secret with credentials")
-				return false, nil
-			}
-			return true, nil
-		})
-		if err != nil {
 			return restClient, err
 		}
-		restClient = util.NewRestClient(url, client, newCredentials)
+		r.reconciler.recordEvent(r.cr, corev1.EventTypeNormal, credentialsRotatedReason,
+			"Credentials of %s user are rotated", newCredentials.Username)
+		restClient = restClient.WithCredentials(newCredentials)
 	}
 	return restClient, nil
 }
 
+// rotateCredentials changes credentials of the operator user in OpenSearch and saves them to the secret with old credentials
+func (r OpenSearchReconciler) rotateCredentials(restClient *util.RestClient, oldCredentials util.Credentials,
+	newCredentials util.Credentials) error {
+	if newCredentials.Username != oldCredentials.Username {
+		if err := r.createNewUser(newCredentials.Username, newCredentials.Password, restClient); err != nil {
+			return err
+		}
+		if err := r.removeUser(oldCredentials.Username, restClient); err != nil {
+			return err
+		}
+	} else {
+		if err := r.changeUserPassword(newCredentials.Username, newCredentials.Password, restClient); err != nil {
+			return err
+		}
+	}
+	return r.saveRotatedCredentials(newCredentials)
+}
+
+// saveRotatedCredentials writes credentials rotated in OpenSearch to the secret with old credentials,
+// the update is repeated in the next reconciliation cycle if it fails
+func (r OpenSearchReconciler) saveRotatedCredentials(credentials util.Credentials) error {
+	secretName := fmt.Sprintf(oldSecretPattern, r.cr.Name)
+	if err := r.reconciler.updateSecretWithCredentials(secretName, r.cr.Namespace, credentials, r.logger); err != nil {
+		r.logger.Error(err, "Unable to update secret with credentials")
+		return RequeueError{After: waitingInterval,
+			Message: fmt.Sprintf("waiting for rotated credentials to be saved to %s secret", secretName)}
+	}
+	return nil
+}
+
+// finishCredentialsRotation saves new credentials to the secret with old credentials if OpenSearch already accepts
+// only the new ones, it happens when the secret is not updated after the rotation in the previous reconciliation cycle
+func (r OpenSearchReconciler) finishCredentialsRotation(url string, client http.Client,
+	oldCredentials util.Credentials) (util.Credentials, error) {
+	newCredentials := r.reconciler.parseSecretCredentials(fmt.Sprintf(secretPattern, r.cr.Name), r.cr.Namespace, r.logger)
+	if newCredentials == oldCredentials {
+		return oldCredentials, nil
+	}
+	restClient := util.NewRestClient(url, client, oldCredentials)
+	if r.areCredentialsAccepted(restClient) || !r.areCredentialsAccepted(restClient.WithCredentials(newCredentials)) {
+		return oldCredentials, nil
+	}
+	r.logger.Info("Credentials are already rotated in OpenSearch, saving them to the secret with old credentials")
+	if err := r.saveRotatedCredentials(newCredentials); err != nil {
+		return oldCredentials, err
+	}
+	return newCredentials, nil
+}
+
+// areCredentialsAccepted checks if OpenSearch authenticates the client
+func (r OpenSearchReconciler) areCredentialsAccepted(restClient *util.RestClient) bool {
+	statusCode, _, err := restClient.SendRequest(http.MethodGet, authInfoPath, nil)
+	return err == nil && statusCode == http.StatusOK
+}
+
 func (r OpenSearchReconciler) getClusterManagerNode(restClient *util.RestClient) (string, error) {
 	requestPath := "_cat/cluster_manager?h=node"
 	statusCode, responseBody, err := restClient.SendBasicRequest(http.MethodGet, requestPath, nil, false)
@@ -974,40 +1030,6 @@ func (r OpenSearchReconciler) updateSecurityConfig(configuration interface{}, re
 	return err
 }
 
-// createSnapshotsRepository creates snapshots repository in OpenSearch
-func (r OpenSearchReconciler) createSnapshotsRepository(restClient *util.RestClient, attemptsNumber int) error {
-	r.logger.Info(fmt.Sprintf("Create a snapshot repository with name [%s]", r.cr.Spec.OpenSearch.Snapshots.RepositoryName))
-	requestPath := fmt.Sprintf("_snapshot/%s", r.cr.Spec.OpenSearch.Snapshots.RepositoryName)
-	requestBody := r.getSnapshotsRepositoryBody()
-	var statusCode int
-	var err error
-	var body []byte
-	for i := 0; i < attemptsNumber; i++ {
-		statusCode, body, err = restClient.SendRequest(http.MethodGet,
-			requestPath, nil)
-
-		// repository recreation is required if snapshots folder is changed not by OpenSearch
-		if err == nil && statusCode == http.StatusNotFound && strings.Contains(string(body), "repository_missing_exception") {
-			r.deleteSnapshotsRepository(restClient, requestPath)
-		}
-		statusCode, _, err = restClient.SendRequest(http.MethodPut, requestPath, strings.NewReader(requestBody))
-		if err == nil && statusCode == http.StatusOK {
-			r.logger.Info("Snapshot repository is created")
-			return nil
-		}
-		time.Sleep(5 * time.Second)
-	}
-	return fmt.Errorf("snapshots repository is not created; response status code is %d", statusCode)
-}
-
-// deleteSnapshotsRepository deletes snapshots repository in OpenSearch
-func (r OpenSearchReconciler) deleteSnapshotsRepository(restClient *util.RestClient, path string) {
-	body, err := restClient.SendRequestWithStatusCodeCheck(http.MethodDelete, path, nil)
-	if err != nil {
-		r.logger.Error(err, fmt.Sprintf("Unable to remove OpenSearch repository: %s", body))
-	}
-}
-
 func (r OpenSearchReconciler) updateCompatibilityMode(restClient *util.RestClient) error {
 	value := "null"
 	if r.cr.Spec.OpenSearch.CompatibilityModeEnabled {
@@ -1018,8 +1040,8 @@ func (r OpenSearchReconciler) updateCompatibilityMode(restClient *util.RestClien
 	return r.updateSettings(restClient, strings.NewReader(requestBody))
 }
 
-func (r OpenSearchReconciler) getS3Credentials() (string, string) {
-	secret, err := r.reconciler.findSecret(r.cr.Spec.OpenSearch.Snapshots.S3.SecretName, r.cr.Namespace, r.logger)
+func (r OpenSearchReconciler) getS3Credentials(s3 *opensearchservice.S3) (string, string) {
+	secret, err := r.reconciler.findSecret(s3.SecretName, r.cr.Namespace, r.logger)
 	if err != nil {
 		r.logger.Info("Can not find s3-credentials secret, use empty user/password")
 		return "", ""
@@ -1031,21 +1053,74 @@ func (r OpenSearchReconciler) getS3Credentials() (string, string) {
 	return string(keyId), string(keySecret)
 }
 
-func (r OpenSearchReconciler) getSnapshotsRepositoryBody() string {
-	if r.cr.Spec.OpenSearch.Snapshots.S3 != nil {
-		if r.cr.Spec.OpenSearch.Snapshots.S3.GcsEnabled {
-			s3Bucket := r.cr.Spec.OpenSearch.Snapshots.S3.Bucket
-			return fmt.Sprintf(`{"type": "gcs", "settings": {"bucket": "%s", "client": "default"}}`, s3Bucket)
+// SnapshotRepository is the body of OpenSearch snapshot repository registration request
+type SnapshotRepository struct {
+	Type     string                 `json:"type"`
+	Settings map[string]interface{} `json:"settings"`
+}
+
+func (r OpenSearchReconciler) getSnapshotsRepositoryBody() (string, error) {
+	repository := SnapshotRepository{
+		Type:     "fs",
+		Settings: map[string]interface{}{"location": "/usr/share/opensearch/snapshots", "compress": true},
+	}
+	if s3 := getSnapshots(r.cr).S3; s3 != nil {
+		if s3.GcsEnabled {
+			repository = SnapshotRepository{
+				Type:     "gcs",
+				Settings: map[string]interface{}{"bucket": s3.Bucket, "client": "default"},
+			}
+		} else if s3.Enabled {
+			repository = SnapshotRepository{Type: "s3", Settings: r.getS3RepositorySettings(s3)}
 		}
-		if r.cr.Spec.OpenSearch.Snapshots.S3.Enabled {
-			s3KeyId, s3KeySecret := r.getS3Credentials()
-			s3Bucket := r.cr.Spec.OpenSearch.Snapshots.S3.Bucket
-			s3Url := r.cr.Spec.OpenSearch.Snapshots.S3.Url
-			s3BasePath := r.cr.Spec.OpenSearch.Snapshots.S3.BasePath
-			s3Region := r.cr.Spec.OpenSearch.Snapshots.S3.Region
-			s3PathStyleAccess := strconv.FormatBool(r.cr.Spec.OpenSearch.Snapshots.S3.PathStyleAccess)
-			return fmt.Sprintf(`{"type": "s3", "settings": {"base_path": "%s", "bucket": "%s", "region": "%s", "endpoint": "%s", "protocol": "http", "access_key": "%s", "secret_key": "%s", "compress": true, "path_style_access": "%s"}}`, s3BasePath, s3Bucket, s3Region, s3Url, s3KeyId, s3KeySecret, s3PathStyleAccess)
+	}
+	body, err := json.Marshal(repository)
+	return string(body), err
+}
+
+// getS3RepositorySettings builds settings of S3 snapshot repository. Access keys are omitted
+// in pod identity mode, so that OpenSearch uses the default AWS credentials chain.
+func (r OpenSearchReconciler) getS3RepositorySettings(s3 *opensearchservice.S3) map[string]interface{} {
+	protocol := getS3Protocol(s3)
+	settings := map[string]interface{}{
+		"base_path":         s3.BasePath,
+		"bucket":            s3.Bucket,
+		"region":            s3.Region,
+		"endpoint":          s3.Url,
+		"protocol":          protocol,
+		"compress":          true,
+		"path_style_access": strconv.FormatBool(s3.PathStyleAccess),
+	}
+	if !s3.UsePodIdentity && r.cr.Spec.ExternalOpenSearch != nil {
+		// The keystore of external OpenSearch nodes is not managed by the operator, so access keys are passed
+		// in repository settings. For managed OpenSearch they are written to the keystore as secure settings.
+		settings["access_key"], settings["secret_key"] = r.getS3Credentials(s3)
+	}
+	if s3.ServerSideEncryption {
+		settings["server_side_encryption"] = true
+	}
+	optionalSettings := map[string]string{
+		"storage_class":              s3.StorageClass,
+		"chunk_size":                 s3.ChunkSize,
+		"max_snapshot_bytes_per_sec": s3.MaxSnapshotBytesPerSec,
+		"max_restore_bytes_per_sec":  s3.MaxRestoreBytesPerSec,
+	}
+	for name, value := range optionalSettings {
+		if value != "" {
+			settings[name] = value
 		}
 	}
-	return `{"type": "fs", "settings": {"location": "/usr/share/opensearch/snapshots", "compress": true}}`
+	return settings
+}
+
+// getS3Protocol returns the protocol from S3 specification or from the scheme of S3 url,
+// http is used if neither of them is specified
+func getS3Protocol(s3 *opensearchservice.S3) string {
+	if s3.Protocol != "" {
+		return s3.Protocol
+	}
+	if strings.HasPrefix(strings.ToLower(s3.Url), "https://") {
+		return "https"
+	}
+	return "http"
 }
@@ -0,0 +1,120 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetS3RepositorySettings(t *testing.T) {
+	// S3 storages are only referenced by URL in repository settings, so local servers stand in for them
+	httpStorage := httptest.NewServer(http.NotFoundHandler())
+	defer httpStorage.Close()
+	httpsStorage := httptest.NewTLSServer(http.NotFoundHandler())
+	defer httpsStorage.Close()
+
+	const namespace = "opensearch-service"
+	credentialsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-credentials", Namespace: namespace},
+		Data:       map[string][]byte{"s3-key-id": []byte("key-id"), "s3-key-secret": []byte("key-secret")},
+	}
+
+	baseSettings := func(url string, protocol string) map[string]interface{} {
+		return map[string]interface{}{
+			"base_path":         "snapshots",
+			"bucket":            "opensearch",
+			"region":            "us-east-1",
+			"endpoint":          url,
+			"protocol":          protocol,
+			"compress":          true,
+			"path_style_access": "true",
+		}
+	}
+	withSettings := func(settings map[string]interface{}, additional map[string]interface{}) map[string]interface{} {
+		for name, value := range additional {
+			settings[name] = value
+		}
+		return settings
+	}
+
+	tests := []struct {
+		name     string
+		s3       opensearchservice.S3
+		external bool
+		expected map[string]interface{}
+	}{
+		{name: "protocol from http url", s3: opensearchservice.S3{Url: httpStorage.URL},
+			expected: baseSettings(httpStorage.URL, "http")},
+		{name: "protocol from https url", s3: opensearchservice.S3{Url: httpsStorage.URL},
+			expected: baseSettings(httpsStorage.URL, "https")},
+		{name: "explicit protocol", s3: opensearchservice.S3{Url: httpsStorage.URL, Protocol: "http"},
+			expected: baseSettings(httpsStorage.URL, "http")},
+		{name: "access keys are not passed to managed OpenSearch",
+			s3:       opensearchservice.S3{Url: httpStorage.URL, SecretName: credentialsSecret.Name},
+			expected: baseSettings(httpStorage.URL, "http")},
+		{name: "access keys are passed to external OpenSearch",
+			s3:       opensearchservice.S3{Url: httpStorage.URL, SecretName: credentialsSecret.Name},
+			external: true,
+			expected: withSettings(baseSettings(httpStorage.URL, "http"),
+				map[string]interface{}{"access_key": "key-id", "secret_key": "key-secret"})},
+		{name: "access keys are not passed in pod identity mode",
+			s3:       opensearchservice.S3{Url: httpStorage.URL, SecretName: credentialsSecret.Name, UsePodIdentity: true},
+			external: true,
+			expected: baseSettings(httpStorage.URL, "http")},
+		{name: "optional settings",
+			s3: opensearchservice.S3{Url: httpsStorage.URL, ServerSideEncryption: true, StorageClass: "standard_ia",
+				ChunkSize: "1gb", MaxSnapshotBytesPerSec: "40mb", MaxRestoreBytesPerSec: "80mb"},
+			expected: withSettings(baseSettings(httpsStorage.URL, "https"), map[string]interface{}{
+				"server_side_encryption":     true,
+				"storage_class":              "standard_ia",
+				"chunk_size":                 "1gb",
+				"max_snapshot_bytes_per_sec": "40mb",
+				"max_restore_bytes_per_sec":  "80mb",
+			})},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s3 := test.s3
+			s3.Enabled = true
+			s3.Bucket = "opensearch"
+			s3.BasePath = "snapshots"
+			s3.Region = "us-east-1"
+			s3.PathStyleAccess = true
+			cr := &opensearchservice.OpenSearchService{
+				ObjectMeta: metav1.ObjectMeta{Name: "opensearch", Namespace: namespace},
+			}
+			if test.external {
+				cr.Spec.ExternalOpenSearch = &opensearchservice.ExternalOpenSearch{Url: "https://opensearch:9200"}
+			}
+			r := OpenSearchReconciler{
+				cr:     cr,
+				logger: logr.Discard(),
+				reconciler: &OpenSearchServiceReconciler{
+					Client: fake.NewClientBuilder().WithObjects(credentialsSecret).Build(),
+				},
+			}
+			assert.Equal(t, test.expected, r.getS3RepositorySettings(&s3))
+		})
+	}
+}
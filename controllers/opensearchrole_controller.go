@@ -0,0 +1,111 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+// OpenSearchRoleReconciler reconciles a OpenSearchRole object
+type OpenSearchRoleReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+type OpenSearchSecurityRole struct {
+	Description        string                       `json:"description,omitempty"`
+	ClusterPermissions []string                     `json:"cluster_permissions,omitempty"`
+	IndexPermissions   []OpenSearchIndexPermission  `json:"index_permissions,omitempty"`
+	TenantPermissions  []OpenSearchTenantPermission `json:"tenant_permissions,omitempty"`
+}
+
+type OpenSearchIndexPermission struct {
+	IndexPatterns  []string `json:"index_patterns"`
+	DLS            string   `json:"dls,omitempty"`
+	FLS            []string `json:"fls,omitempty"`
+	MaskedFields   []string `json:"masked_fields,omitempty"`
+	AllowedActions []string `json:"allowed_actions,omitempty"`
+}
+
+type OpenSearchTenantPermission struct {
+	TenantPatterns []string `json:"tenant_patterns"`
+	AllowedActions []string `json:"allowed_actions,omitempty"`
+}
+
+//+kubebuilder:rbac:groups=qubership.org,resources=opensearchroles,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=qubership.org,resources=opensearchroles/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=qubership.org,resources=opensearchroles/finalizers,verbs=update
+
+func (r *OpenSearchRoleReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling OpenSearch role")
+
+	instance := &opensearchservice.OpenSearchRole{}
+	if err := r.Client.Get(context.TODO(), request.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	roleName := instance.Spec.RoleName
+	if roleName == "" {
+		roleName = instance.Name
+	}
+	entity := securityEntity{kind: "role", resource: "roles", name: roleName}
+	synchronizer := securityObjectSynchronizer{client: r.Client, logger: reqLogger}
+	return synchronizer.synchronize(instance, &instance.Status, instance.Spec.OpenSearchServiceRef.Name, entity,
+		func() (*securityEntityDefinition, error) {
+			role := makeSecurityRole(instance.Spec)
+			return &securityEntityDefinition{body: role, hashData: role}, nil
+		})
+}
+
+func makeSecurityRole(spec opensearchservice.OpenSearchRoleSpec) OpenSearchSecurityRole {
+	role := OpenSearchSecurityRole{
+		Description:        spec.Description,
+		ClusterPermissions: spec.ClusterPermissions,
+	}
+	for _, permission := range spec.IndexPermissions {
+		role.IndexPermissions = append(role.IndexPermissions, OpenSearchIndexPermission{
+			IndexPatterns:  permission.IndexPatterns,
+			DLS:            permission.DLS,
+			FLS:            permission.FLS,
+			MaskedFields:   permission.MaskedFields,
+			AllowedActions: permission.AllowedActions,
+		})
+	}
+	for _, permission := range spec.TenantPermissions {
+		role.TenantPermissions = append(role.TenantPermissions, OpenSearchTenantPermission{
+			TenantPatterns: permission.TenantPatterns,
+			AllowedActions: permission.AllowedActions,
+		})
+	}
+	return role
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OpenSearchRoleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&opensearchservice.OpenSearchRole{}).
+		WithOptions(controller.Options{RateLimiter: customRateLimiter()}).
+		Complete(r)
+}
@@ -0,0 +1,75 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+// OpenSearchRoleMappingReconciler reconciles a OpenSearchRoleMapping object
+type OpenSearchRoleMappingReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=qubership.org,resources=opensearchrolemappings,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=qubership.org,resources=opensearchrolemappings/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=qubership.org,resources=opensearchrolemappings/finalizers,verbs=update
+
+func (r *OpenSearchRoleMappingReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling OpenSearch role mapping")
+
+	instance := &opensearchservice.OpenSearchRoleMapping{}
+	if err := r.Client.Get(context.TODO(), request.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	roleName := instance.Spec.RoleName
+	if roleName == "" {
+		roleName = instance.Name
+	}
+	entity := securityEntity{kind: "role mapping", resource: "rolesmapping", name: roleName}
+	synchronizer := securityObjectSynchronizer{client: r.Client, logger: reqLogger}
+	return synchronizer.synchronize(instance, &instance.Status, instance.Spec.OpenSearchServiceRef.Name, entity,
+		func() (*securityEntityDefinition, error) {
+			// Security plugin does not accept null lists, so empty lists are sent for omitted fields
+			roleMapping := OpenSearchRoleMapping{
+				Users:           append([]string{}, instance.Spec.Users...),
+				BackendRoles:    append([]string{}, instance.Spec.BackendRoles...),
+				AndBackendRoles: []string{},
+				Hosts:           append([]string{}, instance.Spec.Hosts...),
+				Description:     instance.Spec.Description,
+			}
+			return &securityEntityDefinition{body: roleMapping, hashData: roleMapping}, nil
+		})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OpenSearchRoleMappingReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&opensearchservice.OpenSearchRoleMapping{}).
+		WithOptions(controller.Options{RateLimiter: customRateLimiter()}).
+		Complete(r)
+}
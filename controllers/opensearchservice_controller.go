@@ -16,18 +16,24 @@ package controllers
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	"github.com/Netcracker/opensearch-service/util"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/util/wait"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"time"
 
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -37,16 +43,14 @@ import (
 )
 
 const (
-	defaultReadinessTimeout          = 800 * time.Second
 	maxRateLimiterDelay              = 60
 	minRateLimiterDelay              = 5
 	opensearchSecretHashName         = "secret.opensearch"
 	opensearchOldSecretHashName      = "secret.opensearch.old"
 	opensearchServiceConditionReason = "ReconcileCycleStatus"
+	readinessCheckInterval           = 20 * time.Second
 )
 
-var opensearchSecretHash = ""
-
 var log = logf.Log.WithName("controller_opensearchservice")
 
 type ReconcileService interface {
@@ -60,6 +64,17 @@ type NotReadyError struct {
 	Err        error
 }
 
+// RequeueError is returned by reconcile services when the long operation is in progress,
+// the reconciliation cycle is repeated after the delay instead of blocking the worker until the operation is finished
+type RequeueError struct {
+	After   time.Duration
+	Message string
+}
+
+func (re RequeueError) Error() string {
+	return re.Message
+}
+
 func (nre NotReadyError) Error() string {
 	message := "OpenSearch is not ready yet!"
 	if nre.StatusCode > 0 {
@@ -74,35 +89,49 @@ func (nre NotReadyError) Error() string {
 //+kubebuilder:rbac:groups=qubership.org,resources=opensearchservices,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=qubership.org,resources=opensearchservices/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=qubership.org,resources=opensearchservices/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *OpenSearchServiceReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
 	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling OpenSearch service")
 
-	//TODO: implement channel communication between DR server goroutine and current goroutine instead of sleeping
-	time.Sleep(time.Second * 5)
-
 	// Fetch the OpenSearchService instance
 	instance := &opensearchservice.OpenSearchService{}
 	var err error
 	if err = r.Client.Get(context.TODO(), request.NamespacedName, instance); err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
-			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Owned objects are automatically garbage collected, state of the object is forgotten.
 			// Return and don't requeue
+			r.Instances.remove(request.NamespacedName, reqLogger)
+			deleteMetrics(request.Namespace, request.Name)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		return ctrl.Result{}, err
 	}
 
-	r.StatusUpdater = util.NewStatusUpdater(r.Client, instance)
-	if err = r.updateConditions(NewCondition(statusFalse,
+	if !instance.DeletionTimestamp.IsZero() {
+		err = r.finalize(instance, reqLogger)
+		var requeueError RequeueError
+		if goerrors.As(err, &requeueError) {
+			reqLogger.Info(fmt.Sprintf("Teardown is in progress: %s", requeueError.Message))
+			return ctrl.Result{RequeueAfter: requeueError.After}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if err = r.addFinalizer(instance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err = r.updateConditions(instance, NewCondition(statusFalse,
 		typeInProgress,
 		opensearchServiceConditionReason,
 		"Reconciliation cycle started")); err != nil {
 		return ctrl.Result{}, err
 	}
+	// inProgressMessage describes the long operation the reconciliation cycle is requeued for
+	inProgressMessage := ""
 	defer func() {
 		var status opensearchservice.StatusCondition
 		if err != nil {
@@ -110,65 +139,82 @@ func (r *OpenSearchServiceReconciler) Reconcile(ctx context.Context, request ctr
 				typeFailed,
 				opensearchServiceConditionReason,
 				fmt.Sprintf("Reconciliation cycle is failed: %s", err.Error()))
+		} else if inProgressMessage != "" {
+			status = NewCondition(statusFalse,
+				typeInProgress,
+				opensearchServiceConditionReason,
+				fmt.Sprintf("Reconciliation cycle is in progress: %s", inProgressMessage))
 		} else {
 			status = NewCondition(statusTrue,
 				typeSuccessful,
 				opensearchServiceConditionReason,
 				"Reconciliation cycle is successfully finished")
 		}
-		err = r.updateConditions(status)
+		err = r.updateConditions(instance, status)
 		if err != nil {
 			reqLogger.Error(err, "Unable to update custom resource conditions")
 		}
 	}()
 
 	opensearchSecretName := fmt.Sprintf("%s-secret", instance.Name)
-	opensearchSecretHash, err = r.calculateSecretDataHash(opensearchSecretName, opensearchSecretHashName, instance, log)
+	state := r.getState(instance)
+	state.opensearchSecretHash, err = r.calculateSecretDataHash(opensearchSecretName, opensearchSecretHashName, instance, log)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
 	reconcilers := r.buildReconcilers(instance, log)
+	defer r.refreshStatuses(instance, reconcilers, reqLogger)
 
 	for _, reconciler := range reconcilers {
-		if err = reconciler.Reconcile(); err != nil {
+		start := time.Now()
+		err = reconciler.Reconcile()
+		observeReconcile(instance, reconciler, reconcilePhase, start, err)
+		var requeueError RequeueError
+		if goerrors.As(err, &requeueError) {
+			reqLogger.Info(fmt.Sprintf("Reconciliation of %T is in progress: %s", reconciler, requeueError.Message))
+			inProgressMessage = requeueError.Message
+			err = nil
+			return ctrl.Result{RequeueAfter: requeueError.After}, nil
+		}
+		if err != nil {
 			reqLogger.Error(err, fmt.Sprintf("Error when reconciling `%T`", reconciler))
 			return ctrl.Result{}, err
 		}
 	}
 
-	if instance.Spec.OpenSearch != nil {
-		var readinessTimeout time.Duration
-		readinessTimeout, err = time.ParseDuration(instance.Spec.OpenSearch.ReadinessTimeout)
-		if err != nil {
-			log.Error(err, fmt.Sprintf("Readiness timeout is specified incorrectly, %s value is used",
-				defaultReadinessTimeout))
-			readinessTimeout = defaultReadinessTimeout
-		}
-		err = wait.Poll(time.Second*20, readinessTimeout, func() (bool, error) {
-			err := r.checkOpenSearchIsReady(instance)
-			if err != nil {
-				log.Info(fmt.Sprintf("OpenSearch check - %v", err))
-				return false, nil
-			}
-			return true, nil
-		})
-		if err != nil {
-			err = fmt.Errorf("OpenSearch is not ready after %s", readinessTimeout)
+	if instance.Spec.OpenSearch != nil || instance.Spec.ExternalOpenSearch != nil {
+		var ready bool
+		if ready, err = r.checkReadiness(instance); err != nil {
 			return ctrl.Result{}, err
 		}
+		if !ready {
+			inProgressMessage = "waiting for OpenSearch to become ready"
+			return ctrl.Result{RequeueAfter: readinessCheckInterval}, nil
+		}
 	}
 
 	for _, reconciler := range reconcilers {
-		if err = reconciler.Configure(); err != nil {
+		start := time.Now()
+		err = reconciler.Configure()
+		observeReconcile(instance, reconciler, configurePhase, start, err)
+		var requeueError RequeueError
+		if goerrors.As(err, &requeueError) {
+			reqLogger.Info(fmt.Sprintf("Configuration of %T is in progress: %s", reconciler, requeueError.Message))
+			inProgressMessage = requeueError.Message
+			err = nil
+			return ctrl.Result{RequeueAfter: requeueError.After}, nil
+		}
+		if err != nil {
 			reqLogger.Error(err, fmt.Sprintf("Reconciliation cycle failed for %T:", reconciler))
 			return ctrl.Result{}, err
 		}
 	}
 
 	reqLogger.Info("Reconciliation cycle succeeded")
-	r.ResourceHashes[opensearchSecretHashName] = opensearchSecretHash
-	return ctrl.Result{}, nil
+	state.ResourceHashes[opensearchSecretHashName] = state.opensearchSecretHash
+	// Requeue to keep health of OpenSearch and its components up to date in the status
+	return ctrl.Result{RequeueAfter: time.Duration(getStatusRefreshPeriod()) * time.Second}, nil
 }
 
 func (r *OpenSearchServiceReconciler) buildReconcilers(cr *opensearchservice.OpenSearchService,
@@ -198,17 +244,92 @@ func (r *OpenSearchServiceReconciler) buildReconcilers(cr *opensearchservice.Ope
 	if cr.Spec.ExternalOpenSearch != nil {
 		reconcilers = append(reconcilers, NewExternalOpenSearchReconciler(r, cr, logger))
 	}
+	if cr.Spec.IndexManagement != nil || !isIndexManagementStatusEmpty(cr.Status.IndexManagementStatus) {
+		reconcilers = append(reconcilers, NewIndexManagementReconciler(r, cr, logger))
+	}
 	return reconcilers
 }
 
+// getReadinessTimeout returns time the operator waits for managed or external OpenSearch to become ready
+func getReadinessTimeout(cr *opensearchservice.OpenSearchService) string {
+	var timeout string
+	if cr.Spec.OpenSearch != nil {
+		timeout = cr.Spec.OpenSearch.ReadinessTimeout
+	} else if cr.Spec.ExternalOpenSearch != nil {
+		timeout = cr.Spec.ExternalOpenSearch.ReadinessTimeout
+	}
+	if timeout == "" {
+		return opensearchservice.DefaultReadinessTimeout.String()
+	}
+	return timeout
+}
+
+// checkReadiness checks once whether OpenSearch is ready and keeps the time the operator waits for it in the status,
+// it returns error if OpenSearch is not ready longer than the readiness timeout
+func (r *OpenSearchServiceReconciler) checkReadiness(cr *opensearchservice.OpenSearchService) (bool, error) {
+	readinessTimeout, err := time.ParseDuration(getReadinessTimeout(cr))
+	if err != nil {
+		log.Error(err, fmt.Sprintf("Readiness timeout is specified incorrectly, %s value is used",
+			opensearchservice.DefaultReadinessTimeout))
+		readinessTimeout = opensearchservice.DefaultReadinessTimeout
+	}
+	previous := cr.Status.ReadinessStatus
+	if err = r.checkOpenSearchIsReady(cr); err == nil {
+		if previous != nil && previous.Ready {
+			return true, nil
+		}
+		return true, r.updateReadinessStatus(cr, opensearchservice.ReadinessStatus{Ready: true})
+	}
+	log.Info(fmt.Sprintf("OpenSearch check - %v", err))
+	waitStartTime := metav1.Now()
+	if previous != nil && previous.WaitStartTime != nil {
+		waitStartTime = *previous.WaitStartTime
+	}
+	if time.Since(waitStartTime.Time) > readinessTimeout {
+		// The next reconciliation cycle starts waiting from the beginning
+		if err = r.updateReadinessStatus(cr, opensearchservice.ReadinessStatus{Message: err.Error()}); err != nil {
+			return false, err
+		}
+		return false, fmt.Errorf("OpenSearch is not ready after %s", readinessTimeout)
+	}
+	return false, r.updateReadinessStatus(cr, opensearchservice.ReadinessStatus{
+		WaitStartTime: &waitStartTime,
+		Message:       err.Error(),
+	})
+}
+
+func (r *OpenSearchServiceReconciler) updateReadinessStatus(cr *opensearchservice.OpenSearchService,
+	status opensearchservice.ReadinessStatus) error {
+	return util.NewStatusUpdater(r.Client, cr).UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
+		instance.Status.ReadinessStatus = &status
+	})
+}
+
+// findInstancesWaitingForReadiness returns requests for custom resources in the namespace of the stateful set
+// which wait for OpenSearch to become ready
+func (r *OpenSearchServiceReconciler) findInstancesWaitingForReadiness(ctx context.Context,
+	statefulSet client.Object) []reconcile.Request {
+	instances := &opensearchservice.OpenSearchServiceList{}
+	if err := r.Client.List(ctx, instances, client.InNamespace(statefulSet.GetNamespace())); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, instance := range instances.Items {
+		readinessStatus := instance.Status.ReadinessStatus
+		if readinessStatus != nil && !readinessStatus.Ready {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
 func (r *OpenSearchServiceReconciler) checkOpenSearchIsReady(cr *opensearchservice.OpenSearchService) error {
-	credentials := r.parseOpenSearchCredentials(cr, log)
-	url := r.createUrl(cr.Name, opensearchHttpPort)
-	httpClient, err := r.configureClient()
+	restClient, err := r.createOpenSearchRestClient(cr, log)
 	if err != nil {
 		return NotReadyError{Err: err}
 	}
-	restClient := util.NewRestClient(url, httpClient, credentials)
 	statusCode, _, err := restClient.SendRequest(http.MethodGet, "", nil)
 	if err != nil || statusCode != 200 {
 		return NotReadyError{StatusCode: statusCode, Err: err}
@@ -226,6 +347,9 @@ func (r *OpenSearchServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 					return true
 				}
 			}
+			if !e.ObjectNew.GetDeletionTimestamp().IsZero() {
+				return true
+			}
 			return e.ObjectNew.GetGeneration() == 0 || e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
 		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
@@ -234,12 +358,33 @@ func (r *OpenSearchServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		},
 	}
 
+	// readinessPredicate passes changes of ready replicas of stateful sets to pick up OpenSearch readiness promptly
+	readinessPredicate := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldStatefulSet, oldOk := e.ObjectOld.(*appsv1.StatefulSet)
+			newStatefulSet, newOk := e.ObjectNew.(*appsv1.StatefulSet)
+			return oldOk && newOk && oldStatefulSet.Status.ReadyReplicas != newStatefulSet.Status.ReadyReplicas
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&opensearchservice.OpenSearchService{}).
-		Owns(&corev1.Secret{}).
-		Owns(&corev1.ConfigMap{}).
-		Owns(&appsv1.StatefulSet{}).
-		WithEventFilter(statusPredicate).
+		For(&opensearchservice.OpenSearchService{}, builder.WithPredicates(statusPredicate)).
+		Owns(&corev1.Secret{}, builder.WithPredicates(statusPredicate)).
+		Owns(&corev1.ConfigMap{}, builder.WithPredicates(statusPredicate)).
+		Owns(&appsv1.StatefulSet{}, builder.WithPredicates(statusPredicate)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.findInstancesForTLSSecret),
+			builder.WithPredicates(statusPredicate)).
+		Watches(&appsv1.StatefulSet{}, handler.EnqueueRequestsFromMapFunc(r.findInstancesWaitingForReadiness),
+			builder.WithPredicates(readinessPredicate)).
 		WithOptions(controller.Options{RateLimiter: customRateLimiter()}).
 		Complete(r)
 }
@@ -24,16 +24,16 @@ import (
 	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
 	"github.com/Netcracker/opensearch-service/util"
 	"github.com/go-logr/logr"
-	"github.com/hashicorp/go-retryablehttp"
+	"io"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/tools/remotecommand"
 	"k8s.io/utils/pointer"
 	"net/http"
@@ -46,34 +46,33 @@ import (
 )
 
 const (
-	opensearchHttpPort     = 9200
-	opensearchHostEnvVar   = "OPENSEARCH_HOST"
-	scaleMessageTemplate   = "Timeout occurred during scaling %s"
-	httpClientRetryMax     = 2
-	scaleTimeout           = 180 * time.Second
-	updateTimeout          = 60 * time.Second
-	waitingInterval        = 10 * time.Second
-	httpClientRetryWaitMax = 5 * time.Second
-	httpClientTimeout      = 60 * time.Second
-	secretPattern          = "%s-secret"
-	oldSecretPattern       = "%s-secret-old"
+	opensearchHttpPort   = 9200
+	opensearchHostEnvVar = "OPENSEARCH_HOST"
+	scaleMessageTemplate = "waiting for deployment %s to be scaled %s"
+	waitingInterval      = 10 * time.Second
+	httpClientTimeout    = 60 * time.Second
+	secretPattern        = "%s-secret"
+	oldSecretPattern     = "%s-secret-old"
 )
 
 // OpenSearchServiceReconciler reconciles a OpenSearchService object
 type OpenSearchServiceReconciler struct {
 	client.Client
-	Scheme                *runtime.Scheme
-	ResourceHashes        map[string]string
-	ReplicationWatcher    ReplicationWatcher
-	SlowLogIndicesWatcher SlowLogIndicesWatcher
-	StatusUpdater         util.StatusUpdater
+	Scheme    *runtime.Scheme
+	Instances InstanceStates
+	Recorder  record.EventRecorder
 }
 
 // findSecret returns the secret found by name and namespace and error if it occurred
 func (r *OpenSearchServiceReconciler) findSecret(name string, namespace string, logger logr.Logger) (*corev1.Secret, error) {
+	return getSecret(r.Client, name, namespace, logger)
+}
+
+// getSecret returns the secret read with the client, it is used where there is no reconciler
+func getSecret(k8sClient client.Client, name string, namespace string, logger logr.Logger) (*corev1.Secret, error) {
 	logger.Info(fmt.Sprintf("Checking existence of [%s] secret", name))
 	foundSecret := &corev1.Secret{}
-	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, foundSecret)
+	err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, foundSecret)
 	return foundSecret, err
 }
 
@@ -137,7 +136,7 @@ func (r *OpenSearchServiceReconciler) calculateSecretDataHash(secretName string,
 	cr *opensearchservice.OpenSearchService, logger logr.Logger) (string, error) {
 	var secret *corev1.Secret
 	var err error
-	if r.ResourceHashes[hashName] == "" {
+	if r.getState(cr).ResourceHashes[hashName] == "" {
 		secret, err = r.watchSecret(secretName, cr, logger)
 		if err != nil {
 			return "", err
@@ -153,10 +152,107 @@ func (r *OpenSearchServiceReconciler) calculateSecretDataHash(secretName string,
 
 // parseOpenSearchCredentials gets credentials from OpenSearch secret
 func (r *OpenSearchServiceReconciler) parseOpenSearchCredentials(cr *opensearchservice.OpenSearchService, logger logr.Logger) util.Credentials {
+	return getOpenSearchCredentials(r.Client, cr, logger)
+}
+
+// getOpenSearchCredentials returns credentials of OpenSearch cluster of the custom resource
+func getOpenSearchCredentials(k8sClient client.Client, cr *opensearchservice.OpenSearchService, logger logr.Logger) util.Credentials {
+	if cr.Spec.ExternalOpenSearch != nil {
+		return getSecretCredentials(k8sClient, getExternalCredentialsSecretName(cr), cr.Namespace, "username", "password", logger)
+	}
+	return getSecretCredentials(k8sClient, fmt.Sprintf(oldSecretPattern, cr.Name), cr.Namespace, "username", "password", logger)
+}
+
+// createOpenSearchRestClient returns client for OpenSearch cluster of the custom resource, it can be external OpenSearch
+func (r *OpenSearchServiceReconciler) createOpenSearchRestClient(cr *opensearchservice.OpenSearchService,
+	logger logr.Logger) (*util.RestClient, error) {
+	return newOpenSearchRestClient(r.Client, cr, logger)
+}
+
+// newOpenSearchRestClient creates client for OpenSearch cluster of the custom resource with certificates and
+// credentials from secrets read with the client, it is used by controllers of OpenSearch security objects
+func newOpenSearchRestClient(k8sClient client.Client, cr *opensearchservice.OpenSearchService,
+	logger logr.Logger) (*util.RestClient, error) {
+	url := createUrl(cr.Name, opensearchHttpPort)
+	var options []util.RestClientOption
+	if cr.Spec.OpenSearch == nil && cr.Spec.ExternalOpenSearch != nil {
+		url = cr.Spec.ExternalOpenSearch.Url
+		var err error
+		if options, err = getExternalOpenSearchClientOptions(k8sClient, cr, logger); err != nil {
+			return nil, err
+		}
+	}
+	httpClient, err := configureOpenSearchClient(k8sClient, cr, logger)
+	if err != nil {
+		return nil, err
+	}
+	return util.NewRestClient(url, httpClient, getOpenSearchCredentials(k8sClient, cr, logger), options...), nil
+}
+
+// getExternalOpenSearchClientOptions returns failover URLs and client certificate of external OpenSearch
+func getExternalOpenSearchClientOptions(k8sClient client.Client, cr *opensearchservice.OpenSearchService,
+	logger logr.Logger) ([]util.RestClientOption, error) {
+	externalOpenSearch := cr.Spec.ExternalOpenSearch
+	options := []util.RestClientOption{util.WithFailoverURLs(externalOpenSearch.FailoverUrls...)}
+	secretName := externalOpenSearch.ClientCertificateSecretName
+	if secretName == "" {
+		return options, nil
+	}
+	secret, err := getSecret(k8sClient, secretName, cr.Namespace, logger)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get secret [%s] with client certificate: %w", secretName, err)
+	}
+	certificate, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("secret [%s] does not contain valid client certificate: %w", secretName, err)
+	}
+	return append(options, util.WithClientCertificates(certificate)), nil
+}
+
+// configureOpenSearchClient configures client with CA certificates of OpenSearch cluster of the custom resource.
+// External OpenSearch certificates are taken from the specified secret.
+func configureOpenSearchClient(k8sClient client.Client, cr *opensearchservice.OpenSearchService,
+	logger logr.Logger) (http.Client, error) {
+	if cr.Spec.OpenSearch != nil || cr.Spec.ExternalOpenSearch == nil || cr.Spec.ExternalOpenSearch.CASecret == nil {
+		return configureClient()
+	}
+	caSecret := cr.Spec.ExternalOpenSearch.CASecret
+	secret, err := getSecret(k8sClient, caSecret.Name, cr.Namespace, logger)
+	if err != nil {
+		return createHttpClient(), fmt.Errorf("unable to get secret [%s] with CA certificates: %w", caSecret.Name, err)
+	}
+	caCert := secret.Data[caSecret.Key]
+	if len(caCert) == 0 {
+		return createHttpClient(), fmt.Errorf("secret [%s] does not contain CA certificates in [%s] key", caSecret.Name, caSecret.Key)
+	}
+	return configureClientWithCACertificates(caCert), nil
+}
+
+// getExternalCredentialsSecretName returns name of the secret with credentials of external OpenSearch
+func getExternalCredentialsSecretName(cr *opensearchservice.OpenSearchService) string {
+	if cr.Spec.ExternalOpenSearch.CredentialsSecretName != "" {
+		return cr.Spec.ExternalOpenSearch.CredentialsSecretName
+	}
+	return fmt.Sprintf(secretPattern, cr.Name)
+}
+
+// getSnapshots returns snapshots configuration of managed or external OpenSearch
+func getSnapshots(cr *opensearchservice.OpenSearchService) *opensearchservice.Snapshots {
+	if cr.Spec.OpenSearch != nil {
+		return cr.Spec.OpenSearch.Snapshots
+	}
 	if cr.Spec.ExternalOpenSearch != nil {
-		return r.parseSecretCredentials(fmt.Sprintf(secretPattern, cr.Name), cr.Namespace, logger)
+		return cr.Spec.ExternalOpenSearch.Snapshots
 	}
-	return r.parseSecretCredentials(fmt.Sprintf(oldSecretPattern, cr.Name), cr.Namespace, logger)
+	return nil
+}
+
+// getSnapshotRepositoryName returns name of snapshot repository registered in OpenSearch
+func getSnapshotRepositoryName(snapshots *opensearchservice.Snapshots) string {
+	if snapshots == nil || snapshots.RepositoryName == "" {
+		return opensearchservice.DefaultSnapshotRepositoryName
+	}
+	return snapshots.RepositoryName
 }
 
 // parseSecretCredentials gets credentials from specified secret
@@ -165,9 +261,15 @@ func (r *OpenSearchServiceReconciler) parseSecretCredentials(name string, namesp
 }
 
 func (r *OpenSearchServiceReconciler) parseSecretCredentialsByKeys(name string, namespace string, usernameKey string,
+	passwordKey string, logger logr.Logger) util.Credentials {
+	return getSecretCredentials(r.Client, name, namespace, usernameKey, passwordKey, logger)
+}
+
+// getSecretCredentials returns credentials from specified keys of the secret, they are empty if the secret is not found
+func getSecretCredentials(k8sClient client.Client, name string, namespace string, usernameKey string,
 	passwordKey string, logger logr.Logger) util.Credentials {
 	var credentials util.Credentials
-	secret, err := r.findSecret(name, namespace, logger)
+	secret, err := getSecret(k8sClient, name, namespace, logger)
 	if err == nil {
 		username := string(secret.Data[usernameKey])
 		password := string(secret.Data[passwordKey])
@@ -217,7 +319,7 @@ func (r *OpenSearchServiceReconciler) calculateConfigDataHash(cmName string, has
 	cr *opensearchservice.OpenSearchService, logger logr.Logger) (string, error) {
 	var configMap *corev1.ConfigMap
 	var err error
-	if r.ResourceHashes[hashName] == "" {
+	if r.getState(cr).ResourceHashes[hashName] == "" {
 		configMap, err = r.watchConfigMap(cmName, cr, logger)
 		if err != nil {
 			return "", err
@@ -233,6 +335,12 @@ func (r *OpenSearchServiceReconciler) calculateConfigDataHash(cmName string, has
 
 // runCommandInPod runs specified command in specified pod
 func (r *OpenSearchServiceReconciler) runCommandInPod(podName string, container string, namespace string, command []string) error {
+	return r.runCommandInPodWithInput(podName, container, namespace, command, nil)
+}
+
+// runCommandInPodWithInput runs specified command in specified pod and passes input to its standard input
+func (r *OpenSearchServiceReconciler) runCommandInPodWithInput(podName string, container string, namespace string,
+	command []string, input io.Reader) error {
 	config := kubeconfig.GetConfigOrDie()
 	kubeClient, err := kubernetes.NewForConfig(config)
 	if err != nil {
@@ -244,6 +352,7 @@ func (r *OpenSearchServiceReconciler) runCommandInPod(podName string, container
 		Name(podName).
 		SubResource("exec").
 		VersionedParams(&corev1.PodExecOptions{
+			Stdin:     input != nil,
 			Stdout:    true,
 			Stderr:    true,
 			Container: container,
@@ -256,6 +365,7 @@ func (r *OpenSearchServiceReconciler) runCommandInPod(podName string, container
 	var execOut bytes.Buffer
 	var execErr bytes.Buffer
 	err = executor.Stream(remotecommand.StreamOptions{
+		Stdin:  input,
 		Stdout: &execOut,
 		Stderr: &execErr,
 		Tty:    false,
@@ -329,33 +439,21 @@ func (r *OpenSearchServiceReconciler) scaleDeployment(name string, namespace str
 	return err
 }
 
-func (r *OpenSearchServiceReconciler) scaleDeploymentWithCheck(name string, namespace string, replicas int32, interval, timeout time.Duration, logger logr.Logger) error {
-	err := r.scaleDeployment(name, namespace, replicas, logger)
-	if err != nil {
+// scaleDeploymentForNoWait scales the deployment and returns error to requeue the reconciliation
+// until the deployment is ready if waiting is required
+func (r *OpenSearchServiceReconciler) scaleDeploymentForNoWait(name string, namespace string, replicas int32, noWait bool, logger logr.Logger) error {
+	if err := r.scaleDeployment(name, namespace, replicas, logger); err != nil {
 		logger.Error(err, "Deployment update failed")
 		return err
 	}
-	logger.Info(fmt.Sprintf("deployment %s scaled", name))
-	err = wait.Poll(interval, timeout, func() (done bool, err error) {
-		return r.isDeploymentReady(name, namespace, logger), nil
-	})
-	if err != nil {
-		direction := "up"
-		if replicas == 0 {
-			direction = "down"
-		}
-		logger.Error(err, fmt.Sprintf(scaleMessageTemplate, direction))
-		return err
+	if noWait || r.isDeploymentReady(name, namespace, logger) {
+		return nil
 	}
-	return nil
-}
-
-func (r *OpenSearchServiceReconciler) scaleDeploymentForNoWait(name string, namespace string, replicas int32, noWait bool, logger logr.Logger) error {
-	if noWait {
-		return r.scaleDeployment(name, namespace, replicas, logger)
-	} else {
-		return r.scaleDeploymentWithCheck(name, namespace, replicas, waitingInterval, scaleTimeout, logger)
+	direction := "up"
+	if replicas == 0 {
+		direction = "down"
 	}
+	return RequeueError{After: waitingInterval, Message: fmt.Sprintf(scaleMessageTemplate, name, direction)}
 }
 
 func (r *OpenSearchServiceReconciler) scaleDeploymentForDR(name string, cr *opensearchservice.OpenSearchService, logger logr.Logger) error {
@@ -416,7 +514,7 @@ func (r *OpenSearchServiceReconciler) enableClientService(name string, namespace
 	return r.updateService(service, logger)
 }
 
-func (r *OpenSearchServiceReconciler) createUrl(host string, port int) string {
+func createUrl(host string, port int) string {
 	// if OpenSearch host specified, you can connect to operator remotely
 	osHost := os.Getenv(opensearchHostEnvVar)
 	if osHost != "" {
@@ -429,21 +527,18 @@ func (r *OpenSearchServiceReconciler) createUrl(host string, port int) string {
 	return fmt.Sprintf("%s://%s-internal:%d", protocol, host, port)
 }
 
-func (r *OpenSearchServiceReconciler) createHttpClient() http.Client {
-	retryClient := retryablehttp.NewClient()
-	retryClient.HTTPClient = &http.Client{Timeout: httpClientTimeout}
-	retryClient.RetryMax = httpClientRetryMax
-	retryClient.RetryWaitMax = httpClientRetryWaitMax
-	return *retryClient.StandardClient()
+// createHttpClient returns client with timeout, failed requests are retried by util.RestClient
+func createHttpClient() http.Client {
+	return http.Client{Timeout: httpClientTimeout}
 }
 
-func (r *OpenSearchServiceReconciler) configureClient() (http.Client, error) {
-	return r.configureClientWithCertificate(certificateFilePath)
+func configureClient() (http.Client, error) {
+	return configureClientWithCertificate(certificateFilePath)
 }
 
 // configureClientWithCertificate configures client with certificates from specified file
-func (r *OpenSearchServiceReconciler) configureClientWithCertificate(certificatePath string) (http.Client, error) {
-	httpClient := r.createHttpClient()
+func configureClientWithCertificate(certificatePath string) (http.Client, error) {
+	httpClient := createHttpClient()
 	if _, err := os.Stat(certificatePath); errors.Is(err, os.ErrNotExist) {
 		return httpClient, nil
 	}
@@ -452,16 +547,44 @@ func (r *OpenSearchServiceReconciler) configureClientWithCertificate(certificate
 		log.Error(err, fmt.Sprintf("Unable to read certificates from %s file", certificatePath))
 		return httpClient, err
 	}
+	return configureClientWithCACertificates(caCert), nil
+}
+
+// configureClientWithServerName configures client which verifies certificate of OpenSearch against the server name,
+// it is used to send requests to OpenSearch pods by IP addresses
+func configureClientWithServerName(serverName string) (http.Client, error) {
+	httpClient := createHttpClient()
+	if _, err := os.Stat(certificateFilePath); errors.Is(err, os.ErrNotExist) {
+		return httpClient, nil
+	}
+	caCert, err := os.ReadFile(certificateFilePath)
+	if err != nil {
+		return httpClient, err
+	}
 	caCertPool := x509.NewCertPool()
 	caCertPool.AppendCertsFromPEM(caCert)
 	httpClient.Transport = &http.Transport{
 		TLSClientConfig: &tls.Config{
-			RootCAs: caCertPool,
+			RootCAs:    caCertPool,
+			ServerName: serverName,
 		},
 	}
 	return httpClient, nil
 }
 
+// configureClientWithCACertificates configures client with specified CA certificates in PEM format
+func configureClientWithCACertificates(caCert []byte) http.Client {
+	httpClient := createHttpClient()
+	caCertPool := x509.NewCertPool()
+	caCertPool.AppendCertsFromPEM(caCert)
+	httpClient.Transport = &http.Transport{
+		TLSClientConfig: &tls.Config{
+			RootCAs: caCertPool,
+		},
+	}
+	return httpClient
+}
+
 // findStatefulSet returns the stateful set found by name and namespace and error if it occurred
 func (r *OpenSearchServiceReconciler) findStatefulSet(name string, namespace string, logger logr.Logger) (*appsv1.StatefulSet, error) {
 	logger.Info(fmt.Sprintf("Checking existence of [%s] StatefulSet", name))
@@ -477,6 +600,16 @@ func (r *OpenSearchServiceReconciler) findPod(name string, namespace string, log
 	return pod, err
 }
 
+// findNode returns Kubernetes node found by name, the node is requested directly from API server
+// because nodes are not cached by the operator
+func (r *OpenSearchServiceReconciler) findNode(name string) (*corev1.Node, error) {
+	kubeClient, err := kubernetes.NewForConfig(kubeconfig.GetConfigOrDie())
+	if err != nil {
+		return nil, err
+	}
+	return kubeClient.CoreV1().Nodes().Get(context.TODO(), name, metav1.GetOptions{})
+}
+
 // updateStatefulSet tries to update specified stateful set
 func (r *OpenSearchServiceReconciler) updateStatefulSet(statefulSet *appsv1.StatefulSet, logger logr.Logger) error {
 	logger.Info("Updating the found StatefulSet", "StatefulSet.Namespace", statefulSet.Namespace, "StatefulSet.Name", statefulSet.Name)
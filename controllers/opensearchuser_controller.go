@@ -0,0 +1,128 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// OpenSearchUserReconciler reconciles a OpenSearchUser object
+type OpenSearchUserReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+type OpenSearchInternalUser struct {
+	Password      string            `json:"password"`
+	BackendRoles  []string          `json:"backend_roles,omitempty"`
+	SecurityRoles []string          `json:"opendistro_security_roles,omitempty"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+	Description   string            `json:"description,omitempty"`
+}
+
+//+kubebuilder:rbac:groups=qubership.org,resources=opensearchusers,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=qubership.org,resources=opensearchusers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=qubership.org,resources=opensearchusers/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *OpenSearchUserReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling OpenSearch user")
+
+	instance := &opensearchservice.OpenSearchUser{}
+	if err := r.Client.Get(context.TODO(), request.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	username := instance.Spec.Username
+	if username == "" {
+		username = instance.Name
+	}
+	entity := securityEntity{kind: "user", resource: "internalusers", name: username}
+	synchronizer := securityObjectSynchronizer{client: r.Client, logger: reqLogger}
+	return synchronizer.synchronize(instance, &instance.Status, instance.Spec.OpenSearchServiceRef.Name, entity,
+		func() (*securityEntityDefinition, error) {
+			return r.defineUser(instance)
+		})
+}
+
+// defineUser builds internal user with password from the referenced secret.
+// Resource version of the secret is used for applied hash instead of the password itself.
+func (r *OpenSearchUserReconciler) defineUser(instance *opensearchservice.OpenSearchUser) (*securityEntityDefinition, error) {
+	secretSelector := instance.Spec.PasswordSecret
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(context.TODO(),
+		types.NamespacedName{Namespace: instance.Namespace, Name: secretSelector.Name}, secret); err != nil {
+		return nil, fmt.Errorf("unable to get secret [%s] with password: %w", secretSelector.Name, err)
+	}
+	password := string(secret.Data[secretSelector.Key])
+	if password == "" {
+		return nil, fmt.Errorf("secret [%s] does not contain password in [%s] key", secretSelector.Name, secretSelector.Key)
+	}
+	return &securityEntityDefinition{
+		body: OpenSearchInternalUser{
+			Password:      password,
+			BackendRoles:  instance.Spec.BackendRoles,
+			SecurityRoles: instance.Spec.SecurityRoles,
+			Attributes:    instance.Spec.Attributes,
+			Description:   instance.Spec.Description,
+		},
+		hashData: map[string]interface{}{
+			"spec":                  instance.Spec,
+			"secretResourceVersion": secret.ResourceVersion,
+		},
+	}, nil
+}
+
+// findUsersForSecret returns requests for users whose password is stored in the secret
+func (r *OpenSearchUserReconciler) findUsersForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	users := &opensearchservice.OpenSearchUserList{}
+	if err := r.Client.List(ctx, users, client.InNamespace(secret.GetNamespace())); err != nil {
+		log.Error(err, "Unable to list OpenSearch users")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, user := range users.Items {
+		if user.Spec.PasswordSecret.Name == secret.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: user.Namespace, Name: user.Name},
+			})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OpenSearchUserReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&opensearchservice.OpenSearchUser{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.findUsersForSecret)).
+		WithOptions(controller.Options{RateLimiter: customRateLimiter()}).
+		Complete(r)
+}
@@ -34,6 +34,7 @@ const (
 	replicationNotInProgressStatus = "REPLICATION NOT IN PROGRESS"
 	replicationAttemptsNumber      = 5
 	replicationCheckTimeout        = time.Second * 15
+	replicationStopCheckInterval   = time.Second * 3
 )
 
 type ReplicationManager struct {
@@ -193,6 +194,20 @@ func (rm ReplicationManager) Configure() error {
 	return nil
 }
 
+// RemoveRemoteCluster removes connection settings of the leader cluster created by Configure
+func (rm ReplicationManager) RemoveRemoteCluster() error {
+	body := fmt.Sprintf(`{"persistent": {"cluster": {"remote": {"%s": {"seeds": null}}}}}`, leaderAlias)
+	statusCode, responseBody, err := rm.restClient.SendRequest(http.MethodPut, "_cluster/settings", strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if statusCode >= 400 {
+		return fmt.Errorf("request to remove connection with the remote opensearch cluster returned unexpected status code - [%d], response - [%s]",
+			statusCode, string(responseBody))
+	}
+	return nil
+}
+
 func (rm ReplicationManager) Start() error {
 	body := fmt.Sprintf(`
 {
@@ -290,7 +305,9 @@ func (rm ReplicationManager) getReplicatedIndices() ([]string, error) {
 	return indices, nil
 }
 
-func (rm ReplicationManager) executeReplicationCheck(indexNames []string) error {
+// getInProgressIndices returns leader checkpoints of replicated indices matching the pattern which are not
+// synchronized with the leader yet, error is returned if the replication of some indices is failed
+func (rm ReplicationManager) getInProgressIndices(indexNames []string) (map[string]int, error) {
 	//TODO: should we execute replication health check here?
 	inProgressIndices := make(map[string]int)
 	var failedIndices []string
@@ -299,7 +316,7 @@ func (rm ReplicationManager) executeReplicationCheck(indexNames []string) error
 		matched, err := regexp.MatchString(pattern, index)
 		if err != nil {
 			rm.logger.Error(err, fmt.Sprintf("Regular expression - [%s] is invalid", rm.pattern))
-			return err
+			return nil, err
 		}
 		if !matched {
 			continue
@@ -307,7 +324,7 @@ func (rm ReplicationManager) executeReplicationCheck(indexNames []string) error
 		replicationIndexStats, err := rm.getIndexReplicationStatus(index)
 		if err != nil {
 			rm.logger.Error(err, fmt.Sprintf("Can not get replication index stats for [%s] index", index))
-			return err
+			return nil, err
 		}
 		if replicationIndexStats.Status == "SYNCING" || replicationIndexStats.Status == "BOOTSTRAPPING" {
 			if replicationIndexStats.Details.LeaderCheckpoint != replicationIndexStats.Details.FollowerCheckpoint {
@@ -321,25 +338,9 @@ func (rm ReplicationManager) executeReplicationCheck(indexNames []string) error
 	if len(failedIndices) > 0 {
 		err := fmt.Errorf("some replication indices are failed")
 		rm.logger.Error(err, fmt.Sprintf("Replication check is failed because there are failed replication indices: [%v]", failedIndices))
-		return err
-	}
-
-	attempts := replicationAttemptsNumber
-	for attempts > 0 {
-		restProgressIndex, err := rm.updateInProgressIndices(inProgressIndices)
-		if err != nil {
-			rm.logger.Error(err, "Can not update list of in progress replication indices.")
-			return err
-		}
-		if len(restProgressIndex) == 0 {
-			rm.logger.Info("Replication check is done")
-			return nil
-		}
-		rm.logger.Info(fmt.Sprintf("The rest replicated indices in progress are [%v]", restProgressIndex))
-		attempts -= 1
-		time.Sleep(replicationCheckTimeout)
+		return nil, err
 	}
-	return fmt.Errorf("replication check was failed after 5 attempts")
+	return inProgressIndices, nil
 }
 
 func (rm ReplicationManager) getIndexReplicationStatus(index string) (ReplicationIndexStats, error) {
@@ -384,9 +385,19 @@ func (rm ReplicationManager) updateInProgressIndices(inProgressIndices map[strin
 	return restProgressIndex, nil
 }
 
+// stopIndicesReplication stops the replication of indices which are still replicated. RequeueError is returned
+// if OpenSearch has not stopped the replication yet, the call can be repeated for the same indices.
 func (rm ReplicationManager) stopIndicesReplication(indexNames []string) error {
 	stopIndexReplicationTemplate := "_plugins/_replication/%s/_stop"
+	var stoppedIndices []string
 	for _, index := range indexNames {
+		replicationIndexStats, err := rm.getIndexReplicationStatus(index)
+		if err != nil {
+			return err
+		}
+		if replicationIndexStats.Status == replicationNotInProgressStatus {
+			continue
+		}
 		statusCode, responseBody, err :=
 			rm.restClient.SendRequest(http.MethodPost,
 				fmt.Sprintf(stopIndexReplicationTemplate, index),
@@ -399,9 +410,18 @@ func (rm ReplicationManager) stopIndicesReplication(indexNames []string) error {
 				index, statusCode, string(responseBody))
 		}
 		rm.logger.Info(fmt.Sprintf("Replication was stopped for index [%s]", index))
+		stoppedIndices = append(stoppedIndices, index)
+	}
+	for _, index := range stoppedIndices {
+		replicationIndexStats, err := rm.getIndexReplicationStatus(index)
+		if err != nil {
+			return err
+		}
+		if replicationIndexStats.Status != replicationNotInProgressStatus {
+			return RequeueError{After: replicationStopCheckInterval,
+				Message: fmt.Sprintf("waiting for replication of [%s] index to be stopped", index)}
+		}
 	}
-	//TODO: Check that Replication is stopped
-	time.Sleep(time.Second * 3)
 	return nil
 }
 
@@ -16,94 +16,82 @@ package controllers
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
+
 	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
 	"github.com/Netcracker/opensearch-service/util"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/types"
-	"strings"
-	"sync"
-	"time"
 )
 
 const (
-	failedStatus         = "FAILED"
-	runningState         = "running"
-	pausedState          = "paused"
-	defaultWatchInterval = 30
-	restartWaitPeriod    = 60
+	failedStatus      = "FAILED"
+	restartWaitPeriod = 60
 )
 
+// ReplicationWatcher restarts failed replication in standby mode, it is run by the manager as background watcher
 type ReplicationWatcher struct {
-	Lock  *sync.Mutex
-	state *string
+	*backgroundWatcher
+	// Lock prevents replication restart during the switchover
+	Lock *sync.Mutex
 }
 
-func NewReplicationWatcher(lock *sync.Mutex) ReplicationWatcher {
-	state := pausedState
-	return ReplicationWatcher{
-		Lock:  lock,
-		state: &state,
+func NewReplicationWatcher(name string, lock *sync.Mutex) *ReplicationWatcher {
+	return &ReplicationWatcher{
+		backgroundWatcher: newBackgroundWatcher(name),
+		Lock:              lock,
 	}
 }
 
-func (rw ReplicationWatcher) start(drr DisasterRecoveryReconciler, logger logr.Logger) {
-	if *rw.state == runningState {
-		return
-	} else {
-		*rw.state = runningState
+// start runs the watcher with the actual custom resource and interval or reconfigures the running watcher
+func (rw *ReplicationWatcher) start(drr DisasterRecoveryReconciler, logger logr.Logger) {
+	if !rw.isRunning() {
+		logger.Info("Start Replication Watcher")
 	}
-	logger.Info("Start Replication Watcher")
 	watchInterval := drr.cr.Spec.DisasterRecovery.ReplicationWatcherInterval
 	if watchInterval <= 0 {
-		watchInterval = defaultWatchInterval
+		watchInterval = opensearchservice.DefaultReplicationWatcherInterval
 	}
-	go rw.watch(drr, logger, watchInterval)
+	rw.run(time.Duration(watchInterval)*time.Second, func(ctx context.Context) {
+		rw.watch(ctx, drr, logger)
+	})
 }
 
-func (rw ReplicationWatcher) watch(drr DisasterRecoveryReconciler, logger logr.Logger, interval int) {
-	for {
-		if *rw.state == pausedState {
-			logger.Info("Replication Watcher was stopped, exit from watch loop")
-			return
-		}
-		// Fetch the OpenSearchService instance
-		instance := &opensearchservice.OpenSearchService{}
-		var err error
-		if err = drr.reconciler.Client.Get(context.TODO(), types.NamespacedName{
-			Namespace: drr.cr.Namespace,
-			Name:      drr.cr.Name,
-		}, instance); err != nil {
-			logger.Error(err, "")
-		} else {
-			if instance.Spec.DisasterRecovery.Mode == "standby" &&
-				instance.Status.DisasterRecoveryStatus.Mode == "standby" &&
-				instance.Status.DisasterRecoveryStatus.Status == "done" {
-				rw.restartReplicationOnFailure(drr, logger)
-				if *rw.state == pausedState {
-					logger.Info("Replication Watcher was stopped, exit from watch loop")
-					return
-				}
-			}
-		}
-		time.Sleep(time.Duration(interval) * time.Second)
+func (rw *ReplicationWatcher) watch(ctx context.Context, drr DisasterRecoveryReconciler, logger logr.Logger) {
+	// Fetch the OpenSearchService instance
+	instance := &opensearchservice.OpenSearchService{}
+	if err := drr.reconciler.Client.Get(ctx, types.NamespacedName{
+		Namespace: drr.cr.Namespace,
+		Name:      drr.cr.Name,
+	}, instance); err != nil {
+		logger.Error(err, "")
+		return
+	}
+	if instance.Spec.DisasterRecovery.Mode == "standby" &&
+		instance.Status.DisasterRecoveryStatus.Mode == "standby" &&
+		instance.Status.DisasterRecoveryStatus.Status == "done" {
+		rw.restartReplicationOnFailure(ctx, drr, logger)
 	}
 }
 
-func (rw ReplicationWatcher) restartReplicationOnFailure(drr DisasterRecoveryReconciler, logger logr.Logger) {
+func (rw *ReplicationWatcher) restartReplicationOnFailure(ctx context.Context, drr DisasterRecoveryReconciler, logger logr.Logger) {
 	defer rw.Lock.Unlock()
 	rw.Lock.Lock()
 	if err := rw.checkReplication(drr, false, logger); err != nil {
-		if *rw.state == pausedState {
-			logger.Info("Replication Watcher was stopped, exit from watch loop")
+		if !rw.isRunning() {
+			logger.Info("Replication Watcher was stopped, skip replication restart")
 			return
 		}
 		logger.Info(fmt.Sprintf("Try to restart replication because of error: %v", err))
-		rw.restartReplication(drr, logger)
+		rw.restartReplication(ctx, drr, logger)
 	}
 }
 
-func (rw ReplicationWatcher) checkReplication(drr DisasterRecoveryReconciler, allowNoAutofollowRule bool, logger logr.Logger) error {
+func (rw *ReplicationWatcher) checkReplication(drr DisasterRecoveryReconciler, allowNoAutofollowRule bool, logger logr.Logger) error {
 	logger.Info("Start checking replication status")
 	replicationManager := drr.getReplicationManager()
 	autoFollowRuleStats, err := replicationManager.GetAutoFollowRuleStats()
@@ -150,24 +138,50 @@ func (rw ReplicationWatcher) checkReplication(drr DisasterRecoveryReconciler, al
 	return nil
 }
 
-func (rw ReplicationWatcher) pause(logger logr.Logger) {
+func (rw *ReplicationWatcher) pause(logger logr.Logger) {
 	logger.Info("Stop Replication Watcher")
-	*rw.state = pausedState
+	rw.halt()
 }
 
-func (rw ReplicationWatcher) restartReplication(drr DisasterRecoveryReconciler, logger logr.Logger) {
+func (rw *ReplicationWatcher) restartReplication(ctx context.Context, drr DisasterRecoveryReconciler, logger logr.Logger) {
 	logger.Info("Restart replication")
 	replicationManager := drr.getReplicationManager()
-	err := drr.removePreviousReplication(replicationManager)
+	err := retryWhileInProgress(ctx, func() error {
+		return drr.removePreviousReplication(replicationManager)
+	})
 	if err != nil {
 		logger.Error(err, "Previous replication cannot be stopped")
+		recordReplicationRestart(drr.cr, err)
 		return
 	}
-	err = drr.runReplicationProcess(replicationManager)
+	err = retryWhileInProgress(ctx, func() error {
+		return drr.runReplicationProcess(replicationManager)
+	})
+	recordReplicationRestart(drr.cr, err)
 	if err != nil {
 		logger.Error(err, "Replication cannot be started")
 		return
 	}
 	logger.Info("Replication was restarted")
-	time.Sleep(time.Second * restartWaitPeriod)
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second * restartWaitPeriod):
+	}
+}
+
+// retryWhileInProgress repeats the operation while it returns RequeueError, the watcher runs outside
+// of reconciliation cycles, so it waits for OpenSearch itself
+func retryWhileInProgress(ctx context.Context, operation func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := operation()
+		var requeueError RequeueError
+		if !goerrors.As(err, &requeueError) || attempt == replicationAttemptsNumber {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(requeueError.After):
+		}
+	}
 }
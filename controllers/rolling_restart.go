@@ -0,0 +1,421 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/strings/slices"
+)
+
+const (
+	catNodesPath         = "_cat/nodes?h=name,node.role,cluster_manager&format=json"
+	clusterManagerRole   = "m"
+	electedManagerMarker = "*"
+)
+
+// Restart groups define the order of pods restart during the rolling update
+const (
+	dataNodeRestartGroup = iota
+	clusterManagerEligibleRestartGroup
+	electedClusterManagerRestartGroup
+)
+
+// NodeRoles describes roles of the node from OpenSearch cat nodes API
+type NodeRoles struct {
+	Name string `json:"name"`
+	// Roles contains abbreviations of node roles, for example "dimr"
+	Roles string `json:"node.role"`
+	// ClusterManager is "*" for the elected cluster manager
+	ClusterManager string `json:"cluster_manager"`
+}
+
+// podRestart describes the pod which has to be restarted during the rolling update
+type podRestart struct {
+	name        string
+	statefulSet *v1.StatefulSet
+	replica     int32
+	group       int
+	zone        string
+}
+
+// restartOpenSearchPods restarts outdated pods of the stateful sets in the order based on node roles,
+// so that the elected cluster manager is restarted only once and last. Data nodes are restarted in batches
+// of maxUnavailable pods or all pods of one availability zone are restarted together if zone awareness is enabled.
+// Only one batch is restarted per reconciliation cycle: RequeueError is returned while the rolling update waits
+// for restarted pods, gates or cluster health, and the batch is continued from the status in the next cycle.
+// It returns true if the rolling update is paused or aborted before the next batch
+// or halted because a rolling update gate is not passed.
+func (r OpenSearchReconciler) restartOpenSearchPods(client *util.RestClient, statefulSets []*v1.StatefulSet) (bool, error) {
+	err := r.continueRollingUpdateBatch(client, statefulSets)
+	var gateError *GateError
+	if goerrors.As(err, &gateError) {
+		return true, r.haltRollingUpdate(gateError)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	restarts, err := r.makeRestartOrder(client, statefulSets)
+	if err != nil {
+		return false, err
+	}
+	if err = r.updateRestartOrder(restarts); err != nil {
+		return false, err
+	}
+	batches := makeRestartBatches(restarts, getRollingUpdateMaxUnavailable(r.cr))
+	zoneAware := false
+	if isZoneAwarenessEnabled(r.cr) && len(restarts) != 0 {
+		zoneBatches, err := r.makeZoneRestartBatches(client, restarts)
+		if err != nil {
+			return false, err
+		}
+		if zoneBatches != nil {
+			if err = r.updateZonePlan(zoneBatches); err != nil {
+				return false, err
+			}
+			batches, zoneAware = zoneBatches, true
+		}
+	}
+	for _, batch := range batches {
+		action, reason, err := r.getRequestedStopReason()
+		if err != nil {
+			return false, err
+		}
+		if action != "" {
+			return true, r.interruptRollingUpdate(action, reason)
+		}
+		if err = r.checkPreflightGates(client, true); err == nil {
+			if zoneAware {
+				err = r.restartZone(client, batch)
+			} else {
+				err = r.restartPodsBatch(batch)
+			}
+		}
+		if goerrors.As(err, &gateError) {
+			return true, r.haltRollingUpdate(gateError)
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+
+	for _, statefulSet := range statefulSets {
+		status, err := r.findStatefulSetStatus(statefulSet)
+		if err != nil {
+			return false, err
+		}
+		if len(status.UpdatedReplicas) != 0 {
+			r.logger.Info(fmt.Sprintf("Clear %s updated replicas slice in CR", statefulSet.Name))
+			status.UpdatedReplicas = []int32{}
+			if err = r.updateStatefulSetStatuses(); err != nil {
+				return false, err
+			}
+		}
+	}
+	return false, nil
+}
+
+// continueRollingUpdateBatch finishes the batch restarted in the previous reconciliation cycles
+// and the flush performed before the next batch
+func (r OpenSearchReconciler) continueRollingUpdateBatch(client *util.RestClient, statefulSets []*v1.StatefulSet) error {
+	if len(r.cr.Status.RollingUpdateStatus.CurrentPods) != 0 {
+		if err := r.checkRestartedPods(client, statefulSets); err != nil {
+			return err
+		}
+	}
+	if zone := getRestartingZone(r.cr); zone != "" {
+		if err := r.finishZone(client, zone); err != nil {
+			return err
+		}
+	}
+	return r.waitForFlush()
+}
+
+// restartPodsBatch deletes pods of the batch which are not updated yet and moves the rolling update
+// to the phase waiting for them. It returns nil if all pods of the batch are already updated.
+func (r OpenSearchReconciler) restartPodsBatch(batch []podRestart) error {
+	var podNames []string
+	for _, restart := range batch {
+		status, err := r.findStatefulSetStatus(restart.statefulSet)
+		if err != nil {
+			return err
+		}
+		updatedReplicas, err := r.getUpdatedReplicasSlice(restart.statefulSet, status)
+		if err != nil {
+			return err
+		}
+		if !util.ArrayContains(updatedReplicas, restart.replica) {
+			podNames = append(podNames, restart.name)
+		}
+	}
+	if len(podNames) == 0 {
+		return nil
+	}
+	now := metav1.Now()
+	if err := r.changeRollingUpdateStatus(func(status *opensearchservice.RollingUpdateStatus) {
+		status.CurrentPods = podNames
+		status.Phase = rollingUpdatePodsReadyPhase
+		status.Gate = ""
+		status.PhaseStartTime = &now
+	}); err != nil {
+		return err
+	}
+
+	for _, podName := range podNames {
+		r.logger.Info(fmt.Sprintf("Try to restart OpenSearch pod %s", podName))
+		if err := r.reconciler.deletePodByName(podName, r.cr.Namespace, r.logger); err != nil {
+			return err
+		}
+		r.reconciler.recordEvent(r.cr, corev1.EventTypeNormal, podRestartedReason,
+			"Pod %s is restarted by the rolling update", podName)
+	}
+	return r.requeueRollingUpdate(podCheckInterval, fmt.Sprintf("waiting for pods %v to become ready", podNames))
+}
+
+// checkRestartedPods checks that pods of the current batch are recreated and ready, the cluster manager is elected
+// and post-restart gates are passed. Each pod is marked as completed as soon as all checks are passed.
+func (r OpenSearchReconciler) checkRestartedPods(client *util.RestClient, statefulSets []*v1.StatefulSet) error {
+	for _, podName := range r.cr.Status.RollingUpdateStatus.CurrentPods {
+		if slices.Contains(r.cr.Status.RollingUpdateStatus.CompletedPods, podName) {
+			continue
+		}
+		restart, found := findPodRestart(podName, statefulSets)
+		if !found {
+			return fmt.Errorf("stateful set of %s pod is not found", podName)
+		}
+		ready, err := r.isOpenSearchPodReady(restart)
+		if err != nil {
+			return err
+		}
+		if !ready {
+			if err = r.enterRollingUpdatePhase(rollingUpdatePodsReadyPhase, ""); err != nil {
+				return err
+			}
+			if r.isRollingUpdatePhaseExpired(podCheckTimeout) {
+				return fmt.Errorf("%s pod is not ready within %s", podName, podCheckTimeout)
+			}
+			return r.requeueRollingUpdate(podCheckInterval, fmt.Sprintf("waiting for %s pod to become ready", podName))
+		}
+		clusterManager, err := r.getClusterManagerNode(client)
+		if err != nil || clusterManager == "" {
+			if err = r.enterRollingUpdatePhase(rollingUpdateClusterManagerPhase, ""); err != nil {
+				return err
+			}
+			if r.isRollingUpdatePhaseExpired(healthCheckTimeout) {
+				return fmt.Errorf("cluster manager is not elected within %s after restart of %s pod", healthCheckTimeout, podName)
+			}
+			return r.requeueRollingUpdate(healthCheckInterval, "waiting for OpenSearch cluster manager to be elected")
+		}
+		status, err := r.findStatefulSetStatus(restart.statefulSet)
+		if err != nil {
+			return err
+		}
+		if !util.ArrayContains(status.UpdatedReplicas, restart.replica) {
+			if err = r.uploadUpdatedReplicasSlice(append(status.UpdatedReplicas, restart.replica), status); err != nil {
+				return err
+			}
+		}
+		if err = r.checkPostRestartGates(client, podName); err != nil {
+			return err
+		}
+		if err = r.changeRollingUpdateStatus(func(status *opensearchservice.RollingUpdateStatus) {
+			status.CompletedPods = append(status.CompletedPods, podName)
+			clearRollingUpdatePhase(status)
+		}); err != nil {
+			return err
+		}
+	}
+	return r.changeRollingUpdateStatus(func(status *opensearchservice.RollingUpdateStatus) {
+		status.CurrentPods = nil
+	})
+}
+
+// isOpenSearchPodReady returns true if the pod is recreated from the current revision of the stateful set
+// and its OpenSearch container is ready, so the pod which is still terminating is not considered ready
+func (r OpenSearchReconciler) isOpenSearchPodReady(restart podRestart) (bool, error) {
+	pod, err := r.reconciler.findPod(restart.name, r.cr.Namespace, r.logger)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if pod.DeletionTimestamp != nil ||
+		pod.Labels[v1.ControllerRevisionHashLabelKey] != restart.statefulSet.Status.UpdateRevision {
+		r.logger.Info(fmt.Sprintf("%s pod is not recreated yet", restart.name))
+		return false, nil
+	}
+	if len(pod.Status.ContainerStatuses) == 0 {
+		r.logger.Info(fmt.Sprintf("%s pod doesn't have any container", restart.name))
+		return false, nil
+	}
+	r.logger.Info(fmt.Sprintf("Container of %s pod ready: %t", restart.name, pod.Status.ContainerStatuses[0].Ready))
+	return pod.Status.ContainerStatuses[0].Ready, nil
+}
+
+// findPodRestart returns the restart of the pod with the stateful set and replica parsed from the pod name
+func findPodRestart(podName string, statefulSets []*v1.StatefulSet) (podRestart, bool) {
+	for _, statefulSet := range statefulSets {
+		ordinal, found := strings.CutPrefix(podName, statefulSet.Name+"-")
+		if !found {
+			continue
+		}
+		if replica, err := strconv.ParseInt(ordinal, 10, 32); err == nil {
+			return podRestart{name: podName, statefulSet: statefulSet, replica: int32(replica)}, true
+		}
+	}
+	return podRestart{}, false
+}
+
+// makeRestartBatches splits ordered restarts into batches, only data nodes are restarted together
+func makeRestartBatches(restarts []podRestart, maxUnavailable int) [][]podRestart {
+	var batches [][]podRestart
+	for _, restart := range restarts {
+		last := len(batches) - 1
+		if last >= 0 && restart.group == dataNodeRestartGroup && batches[last][0].group == dataNodeRestartGroup &&
+			len(batches[last]) < maxUnavailable {
+			batches[last] = append(batches[last], restart)
+			continue
+		}
+		batches = append(batches, []podRestart{restart})
+	}
+	return batches
+}
+
+// makeRestartOrder returns outdated pods of the stateful sets ordered by node roles: data, ingest and coordinating
+// nodes first, then cluster manager eligible nodes and the elected cluster manager last.
+// Pods of the same group keep the order of stateful sets and are restarted from the highest ordinal.
+func (r OpenSearchReconciler) makeRestartOrder(client *util.RestClient, statefulSets []*v1.StatefulSet) ([]podRestart, error) {
+	nodes, err := r.getNodeRoles(client)
+	if err != nil {
+		return nil, err
+	}
+	var restarts []podRestart
+	for _, statefulSet := range statefulSets {
+		if *statefulSet.Spec.Replicas == statefulSet.Status.UpdatedReplicas {
+			r.logger.Info(fmt.Sprintf("All replicas of %s stateful set are already updated", statefulSet.Name))
+			continue
+		}
+		for replica := *statefulSet.Spec.Replicas - 1; replica >= 0; replica-- {
+			podName := fmt.Sprintf("%s-%d", statefulSet.Name, replica)
+			updated, err := r.isPodUpdated(podName, statefulSet)
+			if err != nil {
+				return nil, err
+			}
+			if updated {
+				continue
+			}
+			restarts = append(restarts, podRestart{
+				name:        podName,
+				statefulSet: statefulSet,
+				replica:     replica,
+				group:       getRestartGroup(nodes[podName]),
+			})
+		}
+	}
+	sort.SliceStable(restarts, func(i, j int) bool {
+		return restarts[i].group < restarts[j].group
+	})
+	return restarts, nil
+}
+
+// isPodUpdated returns true if the pod is created from the current revision of the stateful set.
+// Missing pods are considered updated because they are recreated from the current revision.
+func (r OpenSearchReconciler) isPodUpdated(podName string, statefulSet *v1.StatefulSet) (bool, error) {
+	pod, err := r.reconciler.findPod(podName, r.cr.Namespace, r.logger)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return statefulSet.Status.UpdateRevision != "" &&
+		pod.Labels[v1.ControllerRevisionHashLabelKey] == statefulSet.Status.UpdateRevision, nil
+}
+
+// getNodeRoles returns roles of OpenSearch nodes by node names, which match pod names
+func (r OpenSearchReconciler) getNodeRoles(client *util.RestClient) (map[string]NodeRoles, error) {
+	responseBody, err := client.SendRequestWithStatusCodeCheck(http.MethodGet, catNodesPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	var nodeList []NodeRoles
+	if err = json.Unmarshal(responseBody, &nodeList); err != nil {
+		return nil, err
+	}
+	nodes := make(map[string]NodeRoles, len(nodeList))
+	for _, node := range nodeList {
+		nodes[node.Name] = node
+	}
+	return nodes, nil
+}
+
+// getRestartGroup returns restart group of the node, nodes which are not in the cluster are restarted first
+func getRestartGroup(node NodeRoles) int {
+	if node.ClusterManager == electedManagerMarker {
+		return electedClusterManagerRestartGroup
+	}
+	if strings.Contains(node.Roles, clusterManagerRole) {
+		return clusterManagerEligibleRestartGroup
+	}
+	return dataNodeRestartGroup
+}
+
+// updateRestartOrder writes the order of restarts to the status, the order is kept while the remaining restarts
+// are restarted in the same order
+func (r OpenSearchReconciler) updateRestartOrder(restarts []podRestart) error {
+	restartOrder := make([]string, 0, len(restarts))
+	for _, restart := range restarts {
+		restartOrder = append(restartOrder, restart.name)
+	}
+	if isSubsequence(restartOrder, r.cr.Status.RollingUpdateStatus.RestartOrder) {
+		return nil
+	}
+	r.logger.Info(fmt.Sprintf("OpenSearch pods are restarted in the following order: %v", restartOrder))
+	statusUpdater := util.NewStatusUpdater(r.reconciler.Client, r.cr)
+	err := statusUpdater.UpdateStatusWithRetry(func(cr *opensearchservice.OpenSearchService) {
+		cr.Status.RollingUpdateStatus.RestartOrder = restartOrder
+	})
+	if err != nil {
+		r.logger.Error(err, "Error while update restart order to CR")
+	}
+	r.cr.Status.RollingUpdateStatus.RestartOrder = restartOrder
+	recordRollingUpdateMetrics(r.cr)
+	return err
+}
+
+// isSubsequence returns true if all items are contained in the sequence in the same order
+func isSubsequence(items []string, sequence []string) bool {
+	index := 0
+	for _, value := range sequence {
+		if index < len(items) && items[index] == value {
+			index++
+		}
+	}
+	return index == len(items)
+}
@@ -0,0 +1,89 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRestartGroup(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     NodeRoles
+		expected int
+	}{
+		{name: "data node", node: NodeRoles{Name: "opensearch-data-0", Roles: "di"}, expected: dataNodeRestartGroup},
+		{name: "coordinating node", node: NodeRoles{Name: "opensearch-0", Roles: "-"}, expected: dataNodeRestartGroup},
+		{name: "node is not in the cluster", node: NodeRoles{}, expected: dataNodeRestartGroup},
+		{name: "cluster manager eligible node", node: NodeRoles{Name: "opensearch-1", Roles: "dimr"},
+			expected: clusterManagerEligibleRestartGroup},
+		{name: "elected cluster manager", node: NodeRoles{Name: "opensearch-2", Roles: "dimr", ClusterManager: "*"},
+			expected: electedClusterManagerRestartGroup},
+		{name: "not elected cluster manager", node: NodeRoles{Name: "opensearch-2", Roles: "m", ClusterManager: "-"},
+			expected: clusterManagerEligibleRestartGroup},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, getRestartGroup(test.node))
+		})
+	}
+}
+
+func TestMakeRestartBatches(t *testing.T) {
+	data := func(name string) podRestart {
+		return podRestart{name: name, group: dataNodeRestartGroup}
+	}
+	manager := func(name string) podRestart {
+		return podRestart{name: name, group: clusterManagerEligibleRestartGroup}
+	}
+	elected := func(name string) podRestart {
+		return podRestart{name: name, group: electedClusterManagerRestartGroup}
+	}
+	tests := []struct {
+		name           string
+		restarts       []podRestart
+		maxUnavailable int
+		expected       [][]string
+	}{
+		{name: "no restarts", restarts: nil, maxUnavailable: 2, expected: nil},
+		{name: "one by one", restarts: []podRestart{data("d-1"), data("d-0"), manager("m-1"), elected("m-0")},
+			maxUnavailable: 1, expected: [][]string{{"d-1"}, {"d-0"}, {"m-1"}, {"m-0"}}},
+		{name: "data nodes together",
+			restarts:       []podRestart{data("d-2"), data("d-1"), data("d-0"), manager("m-1"), elected("m-0")},
+			maxUnavailable: 2, expected: [][]string{{"d-2", "d-1"}, {"d-0"}, {"m-1"}, {"m-0"}}},
+		{name: "cluster manager nodes are not batched",
+			restarts:       []podRestart{manager("m-2"), manager("m-1"), elected("m-0")},
+			maxUnavailable: 3, expected: [][]string{{"m-2"}, {"m-1"}, {"m-0"}}},
+		{name: "max unavailable exceeds data nodes", restarts: []podRestart{data("d-1"), data("d-0"), elected("m-0")},
+			maxUnavailable: 5, expected: [][]string{{"d-1", "d-0"}, {"m-0"}}},
+		{name: "zero max unavailable", restarts: []podRestart{data("d-1"), data("d-0")},
+			maxUnavailable: 0, expected: [][]string{{"d-1"}, {"d-0"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var names [][]string
+			for _, batch := range makeRestartBatches(test.restarts, test.maxUnavailable) {
+				var batchNames []string
+				for _, restart := range batch {
+					batchNames = append(batchNames, restart.name)
+				}
+				names = append(names, batchNames)
+			}
+			assert.Equal(t, test.expected, names)
+		})
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+)
+
+const (
+	awarenessAttributesKey = "cluster.routing.allocation.awareness.attributes"
+	defaultZoneTopologyKey = "topology.kubernetes.io/zone"
+	clusterHealthGate      = "clusterHealth"
+	zonePendingStatus      = "pending"
+	zoneRestartingStatus   = "restarting"
+	zoneDoneStatus         = "done"
+)
+
+func isZoneAwarenessEnabled(cr *opensearchservice.OpenSearchService) bool {
+	options := cr.Spec.OpenSearch.RollingUpdateOptions
+	return options != nil && options.ZoneAwareness != nil && options.ZoneAwareness.Enabled
+}
+
+func getZoneTopologyKey(cr *opensearchservice.OpenSearchService) string {
+	if topologyKey := cr.Spec.OpenSearch.RollingUpdateOptions.ZoneAwareness.TopologyKey; topologyKey != "" {
+		return topologyKey
+	}
+	return defaultZoneTopologyKey
+}
+
+// makeZoneRestartBatches groups ordered restarts by availability zones, so that all pods of one zone are restarted
+// together. The zone with the elected cluster manager is restarted last. It returns nil batches if shard allocation
+// awareness is not configured, the zone of some pod is unknown or restart of one zone breaks the cluster manager quorum.
+func (r OpenSearchReconciler) makeZoneRestartBatches(client *util.RestClient, restarts []podRestart) ([][]podRestart, error) {
+	settings, err := getClusterSettings(client)
+	if err != nil {
+		return nil, err
+	}
+	if settings.get(awarenessAttributesKey) == "" {
+		r.logger.Info(fmt.Sprintf("%s setting is not specified, so pods are restarted regardless of zones",
+			awarenessAttributesKey))
+		return nil, nil
+	}
+	nodes, err := r.getNodeRoles(client)
+	if err != nil {
+		return nil, err
+	}
+
+	topologyKey := getZoneTopologyKey(r.cr)
+	zoneRestarts := make(map[string][]podRestart)
+	var zones []string
+	for index, restart := range restarts {
+		zone, err := r.getPodZone(restart.name, topologyKey)
+		if err != nil {
+			r.logger.Error(err, fmt.Sprintf("Unable to get zone of %s pod, so pods are restarted regardless of zones", restart.name))
+			return nil, nil
+		}
+		if zone == "" {
+			r.logger.Info(fmt.Sprintf("%s pod has no %s label, so pods are restarted regardless of zones", restart.name, topologyKey))
+			return nil, nil
+		}
+		if _, ok := zoneRestarts[zone]; !ok {
+			zones = append(zones, zone)
+		}
+		restarts[index].zone = zone
+		zoneRestarts[zone] = append(zoneRestarts[zone], restarts[index])
+	}
+
+	clusterManagers := 0
+	for _, node := range nodes {
+		if strings.Contains(node.Roles, clusterManagerRole) {
+			clusterManagers++
+		}
+	}
+	for _, zone := range zones {
+		zoneClusterManagers := 0
+		for _, restart := range zoneRestarts[zone] {
+			if restart.group != dataNodeRestartGroup {
+				zoneClusterManagers++
+			}
+		}
+		if clusterManagers > 1 && zoneClusterManagers*2 >= clusterManagers {
+			r.logger.Info(fmt.Sprintf("%d of %d cluster manager eligible nodes are in %s zone, so pods are restarted "+
+				"regardless of zones to keep the quorum", zoneClusterManagers, clusterManagers, zone))
+			return nil, nil
+		}
+	}
+
+	sort.SliceStable(zones, func(i, j int) bool {
+		iLast := hasElectedClusterManager(zoneRestarts[zones[i]])
+		jLast := hasElectedClusterManager(zoneRestarts[zones[j]])
+		if iLast != jLast {
+			return jLast
+		}
+		return zones[i] < zones[j]
+	})
+	batches := make([][]podRestart, 0, len(zones))
+	for _, zone := range zones {
+		batches = append(batches, zoneRestarts[zone])
+	}
+	return batches, nil
+}
+
+// getPodZone returns the zone from the pod label or from the label of Kubernetes node the pod is running on
+func (r OpenSearchReconciler) getPodZone(podName string, topologyKey string) (string, error) {
+	pod, err := r.reconciler.findPod(podName, r.cr.Namespace, r.logger)
+	if err != nil {
+		return "", err
+	}
+	if zone := pod.Labels[topologyKey]; zone != "" {
+		return zone, nil
+	}
+	if pod.Spec.NodeName == "" {
+		return "", nil
+	}
+	node, err := r.reconciler.findNode(pod.Spec.NodeName)
+	if err != nil {
+		return "", err
+	}
+	return node.Labels[topologyKey], nil
+}
+
+func hasElectedClusterManager(restarts []podRestart) bool {
+	for _, restart := range restarts {
+		if restart.group == electedClusterManagerRestartGroup {
+			return true
+		}
+	}
+	return false
+}
+
+// updateZonePlan writes zones in the restart order to the rolling update status,
+// zones restarted before the rolling update is paused or halted are kept
+func (r OpenSearchReconciler) updateZonePlan(batches [][]podRestart) error {
+	var zones []opensearchservice.ZoneRestartStatus
+	for _, zone := range r.cr.Status.RollingUpdateStatus.Zones {
+		if zone.Status == zoneDoneStatus {
+			zones = append(zones, zone)
+		}
+	}
+	var plan []string
+	for _, batch := range batches {
+		zone := opensearchservice.ZoneRestartStatus{Name: batch[0].zone, Status: zonePendingStatus}
+		for _, restart := range batch {
+			zone.Pods = append(zone.Pods, restart.name)
+		}
+		zones = append(zones, zone)
+		plan = append(plan, fmt.Sprintf("%s: %v", zone.Name, zone.Pods))
+	}
+	if reflect.DeepEqual(zones, r.cr.Status.RollingUpdateStatus.Zones) {
+		return nil
+	}
+	r.logger.Info(fmt.Sprintf("OpenSearch pods are restarted by zones in the following order: %s", strings.Join(plan, ", ")))
+	return r.changeRollingUpdateStatus(func(status *opensearchservice.RollingUpdateStatus) {
+		status.Zones = zones
+	})
+}
+
+// restartZone restarts all pods of the zone together. The zone is finished with finishZone
+// when all its pods are restarted.
+func (r OpenSearchReconciler) restartZone(client *util.RestClient, batch []podRestart) error {
+	zone := batch[0].zone
+	r.logger.Info(fmt.Sprintf("Restart pods of %s zone", zone))
+	if err := r.updateZoneStatus(zone, zoneRestartingStatus); err != nil {
+		return err
+	}
+	if err := r.restartPodsBatch(batch); err != nil {
+		return err
+	}
+	return r.finishZone(client, zone)
+}
+
+// finishZone enables shard allocation after restart of the zone and waits until OpenSearch becomes green.
+// Shard allocation is disabled again and flush is performed if there are zones to restart.
+func (r OpenSearchReconciler) finishZone(client *util.RestClient, zone string) error {
+	if r.cr.Status.RollingUpdateStatus.Phase != rollingUpdateClusterHealthPhase {
+		if err := r.changeAllocationSetting(true, client); err != nil {
+			return err
+		}
+		if err := r.enterRollingUpdatePhase(rollingUpdateClusterHealthPhase, ""); err != nil {
+			return err
+		}
+	}
+	healthy, err := r.isOpenSearchHealthy(client)
+	if err != nil || !healthy {
+		if r.isRollingUpdatePhaseExpired(getRollingUpdateGateTimeout(r.cr)) {
+			return &GateError{Gate: clusterHealthGate, Message: fmt.Sprintf("OpenSearch is not green after restart of %s zone", zone)}
+		}
+		return r.requeueRollingUpdate(healthCheckInterval,
+			fmt.Sprintf("waiting for OpenSearch to become green after restart of %s zone", zone))
+	}
+	if err = r.updateZoneStatus(zone, zoneDoneStatus); err != nil {
+		return err
+	}
+	if err = r.leaveRollingUpdatePhase(); err != nil {
+		return err
+	}
+	if !hasPendingZones(r.cr) {
+		return nil
+	}
+	if err = r.changeAllocationSetting(false, client); err != nil {
+		return err
+	}
+	return r.execFlushProcedure(client)
+}
+
+// getRestartingZone returns the zone which pods are restarted by the running rolling update
+func getRestartingZone(cr *opensearchservice.OpenSearchService) string {
+	for _, zone := range cr.Status.RollingUpdateStatus.Zones {
+		if zone.Status == zoneRestartingStatus {
+			return zone.Name
+		}
+	}
+	return ""
+}
+
+func hasPendingZones(cr *opensearchservice.OpenSearchService) bool {
+	for _, zone := range cr.Status.RollingUpdateStatus.Zones {
+		if zone.Status == zonePendingStatus {
+			return true
+		}
+	}
+	return false
+}
+
+func (r OpenSearchReconciler) updateZoneStatus(zone string, zoneStatus string) error {
+	return r.changeRollingUpdateStatus(func(status *opensearchservice.RollingUpdateStatus) {
+		for index := range status.Zones {
+			if status.Zones[index].Name == zone {
+				status.Zones[index].Status = zoneStatus
+			}
+		}
+	})
+}
@@ -0,0 +1,159 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newZoneAwareReconciler(topologyKey string, objects ...client.Object) OpenSearchReconciler {
+	cr := &opensearchservice.OpenSearchService{
+		ObjectMeta: metav1.ObjectMeta{Name: "opensearch", Namespace: "opensearch-service"},
+		Spec: opensearchservice.OpenSearchServiceSpec{OpenSearch: &opensearchservice.OpenSearch{
+			RollingUpdateOptions: &opensearchservice.RollingUpdateOptions{
+				ZoneAwareness: &opensearchservice.ZoneAwareness{Enabled: true, TopologyKey: topologyKey},
+			},
+		}},
+	}
+	return OpenSearchReconciler{
+		cr:         cr,
+		logger:     logr.Discard(),
+		reconciler: &OpenSearchServiceReconciler{Client: newFakeClient(append(objects, cr)...)},
+	}
+}
+
+func TestMakeZoneRestartBatches(t *testing.T) {
+	const awarenessSettings = `{"persistent":{"cluster.routing.allocation.awareness.attributes":"zone"}}`
+	// Pods are not bound to Kubernetes nodes, because nodes are requested directly from API server
+	pod := func(name string, labels map[string]string) client.Object {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "opensearch-service", Labels: labels}}
+	}
+	zone := func(zone string) map[string]string {
+		return map[string]string{defaultZoneTopologyKey: zone}
+	}
+	restarts := []podRestart{
+		{name: "d-0", group: dataNodeRestartGroup},
+		{name: "d-1", group: dataNodeRestartGroup},
+		{name: "m-1", group: clusterManagerEligibleRestartGroup},
+		{name: "m-2", group: clusterManagerEligibleRestartGroup},
+		{name: "m-0", group: electedClusterManagerRestartGroup},
+	}
+	nodes := `[{"name":"d-0","node.role":"d"},{"name":"d-1","node.role":"d"},{"name":"m-0","node.role":"m","cluster_manager":"*"},` +
+		`{"name":"m-1","node.role":"m"},{"name":"m-2","node.role":"m"}]`
+
+	tests := []struct {
+		name        string
+		settings    string
+		topologyKey string
+		objects     []client.Object
+		expected    [][]string
+	}{
+		{name: "zones are ordered with zone of elected cluster manager last", settings: awarenessSettings,
+			objects: []client.Object{
+				pod("d-0", zone("zone-b")),
+				pod("d-1", zone("zone-a")),
+				pod("m-0", zone("zone-a")),
+				pod("m-1", zone("zone-b")),
+				pod("m-2", zone("zone-c")),
+			},
+			expected: [][]string{{"d-0", "m-1"}, {"m-2"}, {"d-1", "m-0"}}},
+		{name: "zone is read from custom label", settings: awarenessSettings, topologyKey: "zone",
+			objects: []client.Object{
+				pod("d-0", map[string]string{"zone": "zone-b"}),
+				pod("d-1", map[string]string{"zone": "zone-a"}),
+				pod("m-0", map[string]string{"zone": "zone-a"}),
+				pod("m-1", map[string]string{"zone": "zone-b"}),
+				pod("m-2", map[string]string{"zone": "zone-c"}),
+			},
+			expected: [][]string{{"d-0", "m-1"}, {"m-2"}, {"d-1", "m-0"}}},
+		{name: "pods are not grouped without shard allocation awareness", settings: `{}`,
+			objects: []client.Object{
+				pod("d-0", zone("zone-a")),
+				pod("d-1", zone("zone-b")),
+				pod("m-0", zone("zone-a")),
+				pod("m-1", zone("zone-b")),
+				pod("m-2", zone("zone-c")),
+			}},
+		{name: "pods are not grouped if zone of pod is unknown", settings: awarenessSettings,
+			objects: []client.Object{
+				pod("d-0", zone("zone-a")),
+				pod("d-1", nil),
+				pod("m-0", zone("zone-a")),
+				pod("m-1", zone("zone-b")),
+				pod("m-2", zone("zone-c")),
+			}},
+		{name: "pods are not grouped if restart of zone breaks quorum", settings: awarenessSettings,
+			objects: []client.Object{
+				pod("d-0", zone("zone-a")),
+				pod("d-1", zone("zone-b")),
+				pod("m-0", zone("zone-a")),
+				pod("m-1", zone("zone-a")),
+				pod("m-2", zone("zone-b")),
+			}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := newOpenSearchMock(t).
+				on(http.MethodGet, clusterDefaultSettingsPath, http.StatusOK, test.settings).
+				on(http.MethodGet, catNodesPath, http.StatusOK, nodes)
+			r := newZoneAwareReconciler(test.topologyKey, test.objects...)
+
+			batches, err := r.makeZoneRestartBatches(mock.restClient(), append([]podRestart{}, restarts...))
+			assert.NoError(t, err)
+			var names [][]string
+			for _, batch := range batches {
+				var batchNames []string
+				for _, restart := range batch {
+					batchNames = append(batchNames, restart.name)
+				}
+				names = append(names, batchNames)
+			}
+			assert.Equal(t, test.expected, names)
+		})
+	}
+}
+
+func TestUpdateZonePlan(t *testing.T) {
+	r := newZoneAwareReconciler("")
+	r.cr.Status.RollingUpdateStatus.Zones = []opensearchservice.ZoneRestartStatus{
+		{Name: "zone-a", Pods: []string{"d-0"}, Status: zoneDoneStatus},
+		{Name: "zone-b", Pods: []string{"d-1"}, Status: zoneRestartingStatus},
+	}
+	assert.NoError(t, r.reconciler.Client.Status().Update(context.TODO(), r.cr))
+
+	batches := [][]podRestart{
+		{{name: "d-1", zone: "zone-b"}, {name: "m-1", zone: "zone-b"}},
+		{{name: "m-0", zone: "zone-c"}},
+	}
+	assert.NoError(t, r.updateZonePlan(batches))
+
+	expected := []opensearchservice.ZoneRestartStatus{
+		{Name: "zone-a", Pods: []string{"d-0"}, Status: zoneDoneStatus},
+		{Name: "zone-b", Pods: []string{"d-1", "m-1"}, Status: zonePendingStatus},
+		{Name: "zone-c", Pods: []string{"m-0"}, Status: zonePendingStatus},
+	}
+	updated := &opensearchservice.OpenSearchService{}
+	assert.NoError(t, r.reconciler.Client.Get(context.TODO(), client.ObjectKeyFromObject(r.cr), updated))
+	assert.Equal(t, expected, updated.Status.RollingUpdateStatus.Zones)
+}
@@ -0,0 +1,227 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	rollingUpdatePausedStatus          = "paused"
+	rollingUpdateAbortedStatus         = "aborted"
+	rollingUpdateHaltedStatus          = "halted"
+	rollingUpdatePauseAction           = "pause"
+	rollingUpdateAbortAction           = "abort"
+	defaultRollingUpdateMaxUnavailable = 1
+	// flushWaitPeriod is the time given to OpenSearch to process the flush request before pods are restarted
+	flushWaitPeriod = 20 * time.Second
+)
+
+// Phases of the rolling update the operator waits for between reconciliation cycles
+const (
+	rollingUpdateFlushPhase          = "flush"
+	rollingUpdatePodsReadyPhase      = "podsReady"
+	rollingUpdateClusterManagerPhase = "clusterManager"
+	rollingUpdateGatePhase           = "gate"
+	rollingUpdateClusterHealthPhase  = "clusterHealth"
+)
+
+func getRollingUpdateAction(cr *opensearchservice.OpenSearchService) string {
+	if cr.Spec.OpenSearch == nil || cr.Spec.OpenSearch.RollingUpdateOptions == nil {
+		return ""
+	}
+	return cr.Spec.OpenSearch.RollingUpdateOptions.Action
+}
+
+func getRollingUpdateMaxUnavailable(cr *opensearchservice.OpenSearchService) int {
+	if cr.Spec.OpenSearch.RollingUpdateOptions == nil || cr.Spec.OpenSearch.RollingUpdateOptions.MaxUnavailable < 1 {
+		return defaultRollingUpdateMaxUnavailable
+	}
+	return cr.Spec.OpenSearch.RollingUpdateOptions.MaxUnavailable
+}
+
+// getStopReason returns the reason to stop the rolling update and the corresponding action
+// if the rolling update is disabled, paused or aborted in the spec, otherwise it returns empty values
+func getStopReason(cr *opensearchservice.OpenSearchService) (string, string) {
+	if cr.Spec.OpenSearch == nil || !cr.Spec.OpenSearch.RollingUpdate {
+		return rollingUpdateAbortAction, "Rolling update is disabled"
+	}
+	switch getRollingUpdateAction(cr) {
+	case rollingUpdatePauseAction:
+		return rollingUpdatePauseAction, "Rolling update is paused with the action in the spec"
+	case rollingUpdateAbortAction:
+		return rollingUpdateAbortAction, "Rolling update is aborted with the action in the spec"
+	}
+	return "", ""
+}
+
+// getRequestedStopReason reads the actual custom resource to take into account the spec changed
+// while the rolling update is in progress
+func (r OpenSearchReconciler) getRequestedStopReason() (string, string, error) {
+	instance := &opensearchservice.OpenSearchService{}
+	if err := r.reconciler.Client.Get(context.TODO(),
+		types.NamespacedName{Name: r.cr.Name, Namespace: r.cr.Namespace}, instance); err != nil {
+		return "", "", err
+	}
+	action, reason := getStopReason(instance)
+	return action, reason, nil
+}
+
+// isRollingUpdateStopRequired returns true if the rolling update is running, paused or halted and has to be
+// stopped according to the action
+func isRollingUpdateStopRequired(cr *opensearchservice.OpenSearchService, action string) bool {
+	status := cr.Status.RollingUpdateStatus.Status
+	return status == rollingUpdateRunningStatus ||
+		((status == rollingUpdatePausedStatus || status == rollingUpdateHaltedStatus) && action == rollingUpdateAbortAction)
+}
+
+// isRollingUpdateInterrupted returns true if the rolling update is paused or halted and has to be continued
+func isRollingUpdateInterrupted(cr *opensearchservice.OpenSearchService) bool {
+	status := cr.Status.RollingUpdateStatus.Status
+	return status == rollingUpdatePausedStatus || status == rollingUpdateHaltedStatus
+}
+
+// stopRollingUpdate enables shard allocation disabled for the rolling update and marks it as paused or aborted
+func (r OpenSearchReconciler) stopRollingUpdate(client *util.RestClient, action string, reason string) error {
+	if err := r.enableAllocationIfNecessary(client); err != nil {
+		return err
+	}
+	return r.interruptRollingUpdate(action, reason)
+}
+
+func (r OpenSearchReconciler) startRollingUpdate() error {
+	resumed := isRollingUpdateInterrupted(r.cr)
+	now := metav1.Now()
+	return r.changeRollingUpdateStatus(func(status *opensearchservice.RollingUpdateStatus) {
+		status.Status = rollingUpdateRunningStatus
+		status.PauseReason = ""
+		status.PauseTime = nil
+		status.CompletionTime = nil
+		if !resumed {
+			status.StartTime = &now
+			status.RestartOrder = nil
+			status.CompletedPods = nil
+			status.Zones = nil
+		}
+	})
+}
+
+func (r OpenSearchReconciler) interruptRollingUpdate(action string, reason string) error {
+	r.logger.Info(fmt.Sprintf("%s, shard allocation is enabled", reason))
+	newStatus := rollingUpdatePausedStatus
+	if action == rollingUpdateAbortAction {
+		newStatus = rollingUpdateAbortedStatus
+	}
+	now := metav1.Now()
+	return r.changeRollingUpdateStatus(func(status *opensearchservice.RollingUpdateStatus) {
+		status.Status = newStatus
+		status.PauseReason = reason
+		status.PauseTime = &now
+		status.CurrentPods = nil
+		clearRollingUpdatePhase(status)
+	})
+}
+
+// haltRollingUpdate marks the rolling update as halted because the rolling update gate is not passed,
+// shard allocation is enabled by the caller
+func (r OpenSearchReconciler) haltRollingUpdate(gateError *GateError) error {
+	r.logger.Info(fmt.Sprintf("Rolling update is halted, %v", gateError))
+	halted := r.cr.Status.RollingUpdateStatus.Status == rollingUpdateHaltedStatus
+	now := metav1.Now()
+	return r.changeRollingUpdateStatus(func(status *opensearchservice.RollingUpdateStatus) {
+		status.Status = rollingUpdateHaltedStatus
+		status.PauseReason = gateError.Error()
+		if !halted || status.PauseTime == nil {
+			status.PauseTime = &now
+		}
+		status.CurrentPods = nil
+		clearRollingUpdatePhase(status)
+	})
+}
+
+func (r OpenSearchReconciler) completeRollingUpdate() error {
+	now := metav1.Now()
+	return r.changeRollingUpdateStatus(func(status *opensearchservice.RollingUpdateStatus) {
+		status.Status = rollingUpdateDoneStatus
+		status.CompletionTime = &now
+		status.CurrentPods = nil
+		clearRollingUpdatePhase(status)
+	})
+}
+
+// enterRollingUpdatePhase persists the phase the rolling update waits for, the start time of the phase
+// is kept if the rolling update already waits for the same phase and gate
+func (r OpenSearchReconciler) enterRollingUpdatePhase(phase string, gate string) error {
+	status := r.cr.Status.RollingUpdateStatus
+	if status.Phase == phase && status.Gate == gate && status.PhaseStartTime != nil {
+		return nil
+	}
+	now := metav1.Now()
+	return r.changeRollingUpdateStatus(func(status *opensearchservice.RollingUpdateStatus) {
+		status.Phase = phase
+		status.Gate = gate
+		status.PhaseStartTime = &now
+	})
+}
+
+// leaveRollingUpdatePhase clears the phase when the rolling update does not wait for it anymore
+func (r OpenSearchReconciler) leaveRollingUpdatePhase() error {
+	if r.cr.Status.RollingUpdateStatus.Phase == "" {
+		return nil
+	}
+	return r.changeRollingUpdateStatus(clearRollingUpdatePhase)
+}
+
+// isRollingUpdatePhaseExpired returns true if the current phase of the rolling update lasts longer than the timeout
+func (r OpenSearchReconciler) isRollingUpdatePhaseExpired(timeout time.Duration) bool {
+	phaseStartTime := r.cr.Status.RollingUpdateStatus.PhaseStartTime
+	return phaseStartTime == nil || time.Since(phaseStartTime.Time) > timeout
+}
+
+// requeueRollingUpdate returns error to repeat the reconciliation while the rolling update phase is not finished
+func (r OpenSearchReconciler) requeueRollingUpdate(after time.Duration, message string) error {
+	return RequeueError{
+		After:   after,
+		Message: fmt.Sprintf("rolling update is in [%s] phase, %s", r.cr.Status.RollingUpdateStatus.Phase, message),
+	}
+}
+
+func clearRollingUpdatePhase(status *opensearchservice.RollingUpdateStatus) {
+	status.Phase = ""
+	status.Gate = ""
+	status.PhaseStartTime = nil
+}
+
+// changeRollingUpdateStatus applies the change to the rolling update status of the custom resource
+// and to the local copy used during the rolling update
+func (r OpenSearchReconciler) changeRollingUpdateStatus(change func(status *opensearchservice.RollingUpdateStatus)) error {
+	change(&r.cr.Status.RollingUpdateStatus)
+	recordRollingUpdateMetrics(r.cr)
+	statusUpdater := util.NewStatusUpdater(r.reconciler.Client, r.cr)
+	err := statusUpdater.UpdateStatusWithRetry(func(cr *opensearchservice.OpenSearchService) {
+		change(&cr.Status.RollingUpdateStatus)
+	})
+	if err != nil {
+		r.logger.Error(err, "Error while updating rolling update status in CR")
+	}
+	return err
+}
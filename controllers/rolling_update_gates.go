@@ -0,0 +1,340 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	"k8s.io/utils/strings/slices"
+)
+
+const (
+	clusterDefaultSettingsPath = "_cluster/settings?include_defaults=true&flat_settings=true"
+	catAllocationPath          = "_cat/allocation?format=json&bytes=b&h=node,disk.percent,disk.avail"
+	catActiveRecoveryPath      = "_cat/recovery?active_only=true&format=json&h=index,shard,stage,target_node"
+	diskThresholdEnabledKey    = "cluster.routing.allocation.disk.threshold_enabled"
+	diskHighWatermarkKey       = "cluster.routing.allocation.disk.watermark.high"
+)
+
+// watermarkBytesPattern matches absolute disk watermark values such as 500mb or 1.5gb
+var watermarkBytesPattern = regexp.MustCompile(`^(?i)([0-9.]+)\s*(b|kb|mb|gb|tb|pb)?$`)
+
+// GateError describes the rolling update gate which is not passed within the timeout
+type GateError struct {
+	Gate    string
+	Message string
+}
+
+func (e *GateError) Error() string {
+	return fmt.Sprintf("rolling update gate %s is not passed: %s", e.Gate, e.Message)
+}
+
+// rollingUpdateGate is a check of OpenSearch cluster state performed during the rolling update.
+// The check returns the reason why the gate is not passed or an empty string if it is passed.
+type rollingUpdateGate struct {
+	name  string
+	check func(client *util.RestClient) (string, error)
+}
+
+// ClusterSettings contains flat cluster settings with default values
+type ClusterSettings struct {
+	Persistent map[string]interface{} `json:"persistent"`
+	Transient  map[string]interface{} `json:"transient"`
+	Defaults   map[string]interface{} `json:"defaults"`
+}
+
+// NodeAllocation describes disk usage of the node from OpenSearch cat allocation API
+type NodeAllocation struct {
+	Node        string `json:"node"`
+	DiskPercent string `json:"disk.percent"`
+	DiskAvail   string `json:"disk.avail"`
+}
+
+// ShardRecovery describes active shard recovery from OpenSearch cat recovery API
+type ShardRecovery struct {
+	Index      string `json:"index"`
+	Shard      string `json:"shard"`
+	Stage      string `json:"stage"`
+	TargetNode string `json:"target_node"`
+}
+
+// checkPreflightGates checks that the cluster is ready for the next pod restart.
+// If wait is false, the rolling update does not wait for gates which are not passed.
+func (r OpenSearchReconciler) checkPreflightGates(client *util.RestClient, wait bool) error {
+	return r.checkGates(client, []rollingUpdateGate{
+		{name: opensearchservice.RollingUpdateGateShardsSettled, check: r.checkShardsSettled},
+		{name: opensearchservice.RollingUpdateGatePendingTasks, check: r.checkPendingTasks},
+		{name: opensearchservice.RollingUpdateGateDiskUsage, check: r.checkDiskUsage},
+	}, wait)
+}
+
+// checkPostRestartGates checks that the restarted node has rejoined the cluster and recovered its shards
+func (r OpenSearchReconciler) checkPostRestartGates(client *util.RestClient, podName string) error {
+	return r.checkGates(client, []rollingUpdateGate{
+		{name: opensearchservice.RollingUpdateGateNodeJoined, check: func(client *util.RestClient) (string, error) {
+			return r.checkNodeJoined(client, podName)
+		}},
+		{name: opensearchservice.RollingUpdateGateRecoveryComplete, check: r.checkRecoveryComplete},
+	}, true)
+}
+
+// checkGates checks each gate once. If the gate is not passed and waitGates is true, the rolling update is moved
+// to "gate" phase and RequeueError is returned to check the gate again, GateError is returned when the gate
+// is not passed within the timeout.
+func (r OpenSearchReconciler) checkGates(client *util.RestClient, gates []rollingUpdateGate, waitGates bool) error {
+	disabled := getDisabledRollingUpdateGates(r.cr)
+	timeout := getRollingUpdateGateTimeout(r.cr)
+	for _, gate := range gates {
+		if slices.Contains(disabled, gate.name) {
+			continue
+		}
+		reason, err := gate.check(client)
+		if err != nil {
+			r.logger.Error(err, fmt.Sprintf("Unable to check %s rolling update gate", gate.name))
+			reason = fmt.Sprintf("the last error: %v", err)
+		} else if reason == "" {
+			r.logger.Info(fmt.Sprintf("Rolling update gate %s is passed", gate.name))
+			continue
+		}
+		r.logger.Info(fmt.Sprintf("Rolling update gate %s is not passed yet: %s", gate.name, reason))
+		if !waitGates {
+			return &GateError{Gate: gate.name, Message: reason}
+		}
+		if err = r.enterRollingUpdatePhase(rollingUpdateGatePhase, gate.name); err != nil {
+			return err
+		}
+		if r.isRollingUpdatePhaseExpired(timeout) {
+			return &GateError{Gate: gate.name, Message: reason}
+		}
+		return r.requeueRollingUpdate(healthCheckInterval,
+			fmt.Sprintf("waiting for rolling update gate %s: %s", gate.name, reason))
+	}
+	return nil
+}
+
+func (r OpenSearchReconciler) checkShardsSettled(client *util.RestClient) (string, error) {
+	health, err := getClusterHealth(client)
+	if err != nil {
+		return "", err
+	}
+	if health.RelocatingShards != 0 || health.InitializingShards != 0 {
+		return fmt.Sprintf("%d shards are relocating and %d shards are initializing",
+			health.RelocatingShards, health.InitializingShards), nil
+	}
+	return "", nil
+}
+
+func (r OpenSearchReconciler) checkPendingTasks(client *util.RestClient) (string, error) {
+	health, err := getClusterHealth(client)
+	if err != nil {
+		return "", err
+	}
+	maxPendingTasks := getRollingUpdateMaxPendingTasks(r.cr)
+	if health.NumberOfPendingTasks > maxPendingTasks {
+		return fmt.Sprintf("there are %d pending cluster tasks, the maximum is %d",
+			health.NumberOfPendingTasks, maxPendingTasks), nil
+	}
+	return "", nil
+}
+
+// checkDiskUsage checks that disk usage of all nodes is under the high watermark,
+// the watermark can be specified as percentage, ratio or minimum free space
+func (r OpenSearchReconciler) checkDiskUsage(client *util.RestClient) (string, error) {
+	settings, err := getClusterSettings(client)
+	if err != nil {
+		return "", err
+	}
+	if strings.EqualFold(settings.get(diskThresholdEnabledKey), "false") {
+		return "", nil
+	}
+	watermark := settings.get(diskHighWatermarkKey)
+	if watermark == "" {
+		return "", fmt.Errorf("%s setting is not found", diskHighWatermarkKey)
+	}
+	maxPercent, minFreeBytes, err := parseDiskWatermark(watermark)
+	if err != nil {
+		return "", err
+	}
+
+	responseBody, err := client.SendRequestWithStatusCodeCheck(http.MethodGet, catAllocationPath, nil)
+	if err != nil {
+		return "", err
+	}
+	var allocations []NodeAllocation
+	if err = json.Unmarshal(responseBody, &allocations); err != nil {
+		return "", err
+	}
+	for _, allocation := range allocations {
+		// Unassigned shards are reported without disk usage
+		if allocation.DiskPercent == "" || allocation.DiskAvail == "" {
+			continue
+		}
+		if minFreeBytes > 0 {
+			avail, err := strconv.ParseFloat(allocation.DiskAvail, 64)
+			if err != nil {
+				return "", err
+			}
+			if avail <= minFreeBytes {
+				return fmt.Sprintf("node %s has %s bytes of free disk space, the high watermark is %s",
+					allocation.Node, allocation.DiskAvail, watermark), nil
+			}
+			continue
+		}
+		percent, err := strconv.ParseFloat(allocation.DiskPercent, 64)
+		if err != nil {
+			return "", err
+		}
+		if percent >= maxPercent {
+			return fmt.Sprintf("disk usage of node %s is %s%%, the high watermark is %s",
+				allocation.Node, allocation.DiskPercent, watermark), nil
+		}
+	}
+	return "", nil
+}
+
+func (r OpenSearchReconciler) checkNodeJoined(client *util.RestClient, podName string) (string, error) {
+	nodes, err := r.getNodeRoles(client)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := nodes[podName]; !ok {
+		return fmt.Sprintf("node %s is not found in the cluster", podName), nil
+	}
+	return "", nil
+}
+
+func (r OpenSearchReconciler) checkRecoveryComplete(client *util.RestClient) (string, error) {
+	responseBody, err := client.SendRequestWithStatusCodeCheck(http.MethodGet, catActiveRecoveryPath, nil)
+	if err != nil {
+		return "", err
+	}
+	var recoveries []ShardRecovery
+	if err = json.Unmarshal(responseBody, &recoveries); err != nil {
+		return "", err
+	}
+	if len(recoveries) != 0 {
+		recovery := recoveries[0]
+		return fmt.Sprintf("%d shard recoveries are in progress, for example shard %s of %s index to %s node is in %s stage",
+			len(recoveries), recovery.Shard, recovery.Index, recovery.TargetNode, recovery.Stage), nil
+	}
+	return "", nil
+}
+
+func getClusterHealth(client *util.RestClient) (*OpenSearchHealth, error) {
+	responseBody, err := client.SendRequestWithStatusCodeCheck(http.MethodGet, clusterHealthPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	var health OpenSearchHealth
+	if err = json.Unmarshal(responseBody, &health); err != nil {
+		return nil, err
+	}
+	return &health, nil
+}
+
+func getClusterSettings(client *util.RestClient) (*ClusterSettings, error) {
+	responseBody, err := client.SendRequestWithStatusCodeCheck(http.MethodGet, clusterDefaultSettingsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	var settings ClusterSettings
+	if err = json.Unmarshal(responseBody, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// get returns the effective value of the setting, transient settings take precedence over persistent ones
+func (s ClusterSettings) get(key string) string {
+	for _, settings := range []map[string]interface{}{s.Transient, s.Persistent, s.Defaults} {
+		if value, ok := settings[key]; ok {
+			return fmt.Sprintf("%v", value)
+		}
+	}
+	return ""
+}
+
+// parseDiskWatermark returns the maximum disk usage percentage or the minimum free space in bytes
+// specified by the watermark, only one of the values is not zero
+func parseDiskWatermark(watermark string) (float64, float64, error) {
+	value := strings.TrimSpace(watermark)
+	if strings.HasSuffix(value, "%") {
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		return percent, 0, err
+	}
+	if ratio, err := strconv.ParseFloat(value, 64); err == nil {
+		return ratio * 100, 0, nil
+	}
+	match := watermarkBytesPattern.FindStringSubmatch(value)
+	if match == nil {
+		return 0, 0, fmt.Errorf("unsupported disk watermark value %q", watermark)
+	}
+	size, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	units := []string{"b", "kb", "mb", "gb", "tb", "pb"}
+	for power, unit := range units {
+		if strings.EqualFold(match[2], unit) {
+			size *= math.Pow(1024, float64(power))
+			break
+		}
+	}
+	return 0, size, nil
+}
+
+func getRollingUpdateGates(cr *opensearchservice.OpenSearchService) *opensearchservice.RollingUpdateGates {
+	if cr.Spec.OpenSearch == nil || cr.Spec.OpenSearch.RollingUpdateOptions == nil {
+		return nil
+	}
+	return cr.Spec.OpenSearch.RollingUpdateOptions.Gates
+}
+
+func getDisabledRollingUpdateGates(cr *opensearchservice.OpenSearchService) []string {
+	if gates := getRollingUpdateGates(cr); gates != nil {
+		return gates.Disabled
+	}
+	return nil
+}
+
+func getRollingUpdateMaxPendingTasks(cr *opensearchservice.OpenSearchService) int {
+	if gates := getRollingUpdateGates(cr); gates != nil && gates.MaxPendingTasks != nil {
+		return *gates.MaxPendingTasks
+	}
+	return opensearchservice.DefaultRollingUpdateMaxPendingTasks
+}
+
+func getRollingUpdateGateTimeout(cr *opensearchservice.OpenSearchService) time.Duration {
+	gates := getRollingUpdateGates(cr)
+	if gates == nil || gates.Timeout == "" {
+		return opensearchservice.DefaultRollingUpdateGateTimeout
+	}
+	timeout, err := time.ParseDuration(gates.Timeout)
+	if err != nil {
+		log.Error(err, fmt.Sprintf("Rolling update gate timeout is specified incorrectly, %s value is used",
+			opensearchservice.DefaultRollingUpdateGateTimeout))
+		return opensearchservice.DefaultRollingUpdateGateTimeout
+	}
+	return timeout
+}
@@ -0,0 +1,56 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDiskWatermark(t *testing.T) {
+	tests := []struct {
+		name            string
+		watermark       string
+		expectedPercent float64
+		expectedBytes   float64
+		expectedError   bool
+	}{
+		{name: "percentage", watermark: "85%", expectedPercent: 85},
+		{name: "fractional percentage", watermark: " 87.5% ", expectedPercent: 87.5},
+		{name: "ratio", watermark: "0.9", expectedPercent: 90},
+		{name: "whole ratio", watermark: "1.0", expectedPercent: 100},
+		{name: "bytes", watermark: "512b", expectedBytes: 512},
+		{name: "kilobytes", watermark: "2kb", expectedBytes: 2048},
+		{name: "megabytes in upper case", watermark: "100MB", expectedBytes: 100 * 1024 * 1024},
+		{name: "gigabytes with space", watermark: "1.5 gb", expectedBytes: 1.5 * 1024 * 1024 * 1024},
+		{name: "terabytes", watermark: "1tb", expectedBytes: 1024 * 1024 * 1024 * 1024},
+		{name: "invalid percentage", watermark: "abc%", expectedError: true},
+		{name: "unsupported unit", watermark: "10xb", expectedError: true},
+		{name: "empty value", watermark: "", expectedError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			percent, bytes, err := parseDiskWatermark(test.watermark)
+			if test.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, test.expectedPercent, percent, 1e-9)
+			assert.InDelta(t, test.expectedBytes, bytes, 1e-9)
+		})
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/strings/slices"
+)
+
+const (
+	catAllocationShardsPath = "_cat/allocation?format=json&h=node,shards"
+	excludeNameKey          = "cluster.routing.allocation.exclude._name"
+	dataRole                = "d"
+	scaleDownDrainingStatus = "draining"
+	scaleDownBlockedStatus  = "blocked"
+	scaleDownScalingStatus  = "scaling"
+	scaleDownDoneStatus     = "done"
+)
+
+// NodeShards describes the number of shards on the node from OpenSearch cat allocation API
+type NodeShards struct {
+	Node   string `json:"node"`
+	Shards string `json:"shards"`
+}
+
+// reconcileScaleDown safely reduces replicas of stateful sets from the spec. Nodes being removed are excluded
+// from shard allocation, replicas are reduced when the nodes hold no shards and the exclusion is cleared
+// when the pods are removed.
+func (r OpenSearchReconciler) reconcileScaleDown(restClient *util.RestClient) error {
+	nodes, err := r.getNodeRoles(restClient)
+	if err != nil {
+		return err
+	}
+	shards, err := getNodeShards(restClient)
+	if err != nil {
+		return err
+	}
+	settings, err := getClusterSettings(restClient)
+	if err != nil {
+		return err
+	}
+	health, err := getClusterHealth(restClient)
+	if err != nil {
+		return err
+	}
+
+	rollingUpdateStatus := r.cr.Status.RollingUpdateStatus.Status
+	rollingUpdateInProgress := rollingUpdateStatus == rollingUpdateRunningStatus || isRollingUpdateInterrupted(r.cr)
+	var statuses []opensearchservice.ScaleDownStatus
+	for _, target := range r.cr.Spec.OpenSearch.ScaleDown {
+		status := opensearchservice.ScaleDownStatus{StatefulSetName: target.StatefulSetName, TargetReplicas: target.Replicas}
+		previous := findScaleDownStatus(r.cr.Status.ScaleDownStatuses, target.StatefulSetName)
+		if previous != nil {
+			status.LastTransitionTime = previous.LastTransitionTime
+		}
+		if rollingUpdateInProgress {
+			status.Status = scaleDownBlockedStatus
+			status.Message = fmt.Sprintf("Rolling update is %s", rollingUpdateStatus)
+		} else if err = r.scaleDownStatefulSet(target, nodes, shards, health, &status); err != nil {
+			status.Status = scaleDownBlockedStatus
+			status.Message = err.Error()
+		}
+		// Blocked scale-down keeps nodes excluded, so that shards are not moved back to them
+		if status.Status == scaleDownBlockedStatus && status.ExcludedNodes == nil && previous != nil {
+			status.ExcludedNodes = previous.ExcludedNodes
+		}
+		statuses = append(statuses, status)
+	}
+
+	var previouslyExcluded, excluded []string
+	for _, status := range r.cr.Status.ScaleDownStatuses {
+		previouslyExcluded = append(previouslyExcluded, status.ExcludedNodes...)
+	}
+	for _, status := range statuses {
+		excluded = append(excluded, status.ExcludedNodes...)
+	}
+	if err = r.updateExcludedNodes(restClient, settings.get(excludeNameKey), previouslyExcluded, excluded); err != nil {
+		return err
+	}
+	return r.updateScaleDownStatuses(statuses)
+}
+
+// scaleDownStatefulSet fills the status with nodes to exclude and reduces replicas of the stateful set
+// when the excluded nodes are present in the cluster, hold no shards and the cluster is not red
+func (r OpenSearchReconciler) scaleDownStatefulSet(target opensearchservice.ScaleDownTarget, nodes map[string]NodeRoles,
+	shards map[string]int, health *OpenSearchHealth, status *opensearchservice.ScaleDownStatus) error {
+	statefulSet, err := r.reconciler.findStatefulSet(target.StatefulSetName, r.cr.Namespace, r.logger)
+	if err != nil {
+		return fmt.Errorf("unable to get %s stateful set: %w", target.StatefulSetName, err)
+	}
+	replicas := *statefulSet.Spec.Replicas
+	if replicas <= target.Replicas {
+		if statefulSet.Status.Replicas > target.Replicas {
+			// Pods are not removed yet, so they are kept excluded
+			status.Status = scaleDownScalingStatus
+			status.ExcludedNodes = getLeavingNodes(target.StatefulSetName, target.Replicas, statefulSet.Status.Replicas)
+			status.Message = fmt.Sprintf("Waiting for removal of %v pods", status.ExcludedNodes)
+			return nil
+		}
+		status.Status = scaleDownDoneStatus
+		status.Message = fmt.Sprintf("Stateful set has %d replicas", replicas)
+		return nil
+	}
+
+	leaving := getLeavingNodes(target.StatefulSetName, target.Replicas, replicas)
+	remainingDataNodes := 0
+	for name, node := range nodes {
+		if slices.Contains(leaving, name) {
+			if strings.Contains(node.Roles, clusterManagerRole) {
+				return fmt.Errorf("node %s is cluster manager eligible, its removal is not supported", name)
+			}
+			continue
+		}
+		if strings.Contains(node.Roles, dataRole) {
+			remainingDataNodes++
+		}
+	}
+	if remainingDataNodes == 0 {
+		return fmt.Errorf("there are no data nodes to move shards from %v nodes", leaving)
+	}
+
+	status.ExcludedNodes = leaving
+	// Shards of the node which is not in the cluster, for example because its pod is restarting, are unknown
+	var missing []string
+	for _, name := range leaving {
+		nodeShards, found := shards[name]
+		if _, joined := nodes[name]; !joined || !found {
+			missing = append(missing, name)
+			continue
+		}
+		status.RemainingShards += nodeShards
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("nodes %v are not present in the cluster, so their shards can not be checked", missing)
+	}
+	if status.RemainingShards > 0 {
+		status.Status = scaleDownDrainingStatus
+		status.Message = fmt.Sprintf("Waiting for %d shards to move from %v nodes", status.RemainingShards, leaving)
+		return nil
+	}
+	if health.Status == "red" {
+		return fmt.Errorf("cluster health is red, %s stateful set is not scaled down", target.StatefulSetName)
+	}
+
+	r.logger.Info(fmt.Sprintf("Nodes %v hold no shards, so %s stateful set is scaled down to %d replicas",
+		leaving, target.StatefulSetName, target.Replicas))
+	statefulSet.Spec.Replicas = &target.Replicas
+	if err = r.reconciler.updateStatefulSet(statefulSet, r.logger); err != nil {
+		return fmt.Errorf("unable to scale down %s stateful set: %w", target.StatefulSetName, err)
+	}
+	status.Status = scaleDownScalingStatus
+	status.Message = fmt.Sprintf("Waiting for removal of %v pods", leaving)
+	return nil
+}
+
+// updateExcludedNodes excludes nodes being removed from shard allocation,
+// nodes excluded outside of the operator are kept
+func (r OpenSearchReconciler) updateExcludedNodes(restClient *util.RestClient, current string,
+	previouslyExcluded []string, excluded []string) error {
+	var names []string
+	for _, name := range strings.Split(current, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !slices.Contains(previouslyExcluded, name) && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	for _, name := range excluded {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	desired := strings.Join(names, ",")
+	if desired == current {
+		return nil
+	}
+	var value interface{}
+	if desired != "" {
+		value = desired
+	}
+	body, err := json.Marshal(map[string]interface{}{"persistent": map[string]interface{}{excludeNameKey: value}})
+	if err != nil {
+		return err
+	}
+	r.logger.Info(fmt.Sprintf("Update nodes excluded from shard allocation: %s", body))
+	return r.updateSettings(restClient, strings.NewReader(string(body)))
+}
+
+func (r OpenSearchReconciler) updateScaleDownStatuses(statuses []opensearchservice.ScaleDownStatus) error {
+	for index := range statuses {
+		previous := findScaleDownStatus(r.cr.Status.ScaleDownStatuses, statuses[index].StatefulSetName)
+		if previous == nil || previous.Status != statuses[index].Status {
+			statuses[index].LastTransitionTime = metav1.Now()
+		}
+		if statuses[index].Status == scaleDownBlockedStatus {
+			r.logger.Info(fmt.Sprintf("Scale-down of %s stateful set is blocked: %s",
+				statuses[index].StatefulSetName, statuses[index].Message))
+		}
+	}
+	r.cr.Status.ScaleDownStatuses = statuses
+	return util.NewStatusUpdater(r.reconciler.Client, r.cr).UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
+		instance.Status.ScaleDownStatuses = statuses
+	})
+}
+
+func findScaleDownStatus(statuses []opensearchservice.ScaleDownStatus, statefulSetName string) *opensearchservice.ScaleDownStatus {
+	for index := range statuses {
+		if statuses[index].StatefulSetName == statefulSetName {
+			return &statuses[index]
+		}
+	}
+	return nil
+}
+
+// getLeavingNodes returns names of nodes removed when the stateful set is scaled down, they match pod names
+func getLeavingNodes(statefulSetName string, targetReplicas int32, replicas int32) []string {
+	var names []string
+	for replica := replicas - 1; replica >= targetReplicas; replica-- {
+		names = append(names, fmt.Sprintf("%s-%d", statefulSetName, replica))
+	}
+	return names
+}
+
+// getNodeShards returns the number of shards by node names
+func getNodeShards(restClient *util.RestClient) (map[string]int, error) {
+	responseBody, err := restClient.SendRequestWithStatusCodeCheck(http.MethodGet, catAllocationShardsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	var nodeList []NodeShards
+	if err = json.Unmarshal(responseBody, &nodeList); err != nil {
+		return nil, err
+	}
+	shards := make(map[string]int, len(nodeList))
+	for _, node := range nodeList {
+		count, err := strconv.Atoi(node.Shards)
+		if err != nil {
+			continue
+		}
+		shards[node.Node] = count
+	}
+	return shards, nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReconcileScaleDown(t *testing.T) {
+	const (
+		namespace       = "opensearch-service"
+		statefulSetName = "opensearch-data"
+		nodes           = `[{"name":"opensearch-data-0","node.role":"d"},{"name":"opensearch-data-1","node.role":"d"},` +
+			`{"name":"opensearch-data-2","node.role":"d"},{"name":"opensearch-0","node.role":"m","cluster_manager":"*"}]`
+	)
+	tests := []struct {
+		name                string
+		replicas            int32
+		currentReplicas     int32
+		shards              string
+		health              string
+		excludedSetting     string
+		rollingUpdateStatus string
+		previous            *opensearchservice.ScaleDownStatus
+		expectedStatus      opensearchservice.ScaleDownStatus
+		expectedReplicas    int32
+		expectedExclusion   []string
+	}{
+		{
+			name: "shards are drained from excluded node", replicas: 3, currentReplicas: 3,
+			shards: `[{"node":"opensearch-data-2","shards":"5"}]`, health: "green",
+			expectedStatus: opensearchservice.ScaleDownStatus{Status: scaleDownDrainingStatus,
+				ExcludedNodes: []string{"opensearch-data-2"}, RemainingShards: 5},
+			expectedReplicas:  3,
+			expectedExclusion: []string{`{"persistent":{"cluster.routing.allocation.exclude._name":"opensearch-data-2"}}`},
+		},
+		{
+			name: "stateful set is scaled down when excluded node holds no shards", replicas: 3, currentReplicas: 3,
+			shards: `[{"node":"opensearch-data-2","shards":"0"}]`, health: "green",
+			excludedSetting: "opensearch-data-2",
+			previous: &opensearchservice.ScaleDownStatus{Status: scaleDownDrainingStatus,
+				ExcludedNodes: []string{"opensearch-data-2"}},
+			expectedStatus: opensearchservice.ScaleDownStatus{Status: scaleDownScalingStatus,
+				ExcludedNodes: []string{"opensearch-data-2"}},
+			expectedReplicas: 2,
+		},
+		{
+			name: "stateful set is not scaled down when cluster is red", replicas: 3, currentReplicas: 3,
+			shards: `[{"node":"opensearch-data-2","shards":"0"}]`, health: "red",
+			excludedSetting: "opensearch-data-2",
+			previous: &opensearchservice.ScaleDownStatus{Status: scaleDownDrainingStatus,
+				ExcludedNodes: []string{"opensearch-data-2"}},
+			expectedStatus: opensearchservice.ScaleDownStatus{Status: scaleDownBlockedStatus,
+				ExcludedNodes: []string{"opensearch-data-2"}},
+			expectedReplicas: 3,
+		},
+		{
+			name: "blocked scale-down keeps nodes excluded", replicas: 3, currentReplicas: 3,
+			shards: `[{"node":"opensearch-data-2","shards":"3"}]`, health: "green",
+			excludedSetting: "opensearch-data-2", rollingUpdateStatus: rollingUpdateRunningStatus,
+			previous: &opensearchservice.ScaleDownStatus{Status: scaleDownDrainingStatus,
+				ExcludedNodes: []string{"opensearch-data-2"}, RemainingShards: 3},
+			expectedStatus: opensearchservice.ScaleDownStatus{Status: scaleDownBlockedStatus,
+				ExcludedNodes: []string{"opensearch-data-2"}},
+			expectedReplicas: 3,
+		},
+		{
+			name: "nodes are not excluded when scale-down is blocked from the start", replicas: 3, currentReplicas: 3,
+			shards: `[{"node":"opensearch-data-2","shards":"3"}]`, health: "green",
+			rollingUpdateStatus: rollingUpdateRunningStatus,
+			expectedStatus:      opensearchservice.ScaleDownStatus{Status: scaleDownBlockedStatus},
+			expectedReplicas:    3,
+		},
+		{
+			name: "removed pods are kept excluded", replicas: 2, currentReplicas: 3,
+			shards: `[]`, health: "green", excludedSetting: "opensearch-data-2",
+			previous: &opensearchservice.ScaleDownStatus{Status: scaleDownScalingStatus,
+				ExcludedNodes: []string{"opensearch-data-2"}},
+			expectedStatus: opensearchservice.ScaleDownStatus{Status: scaleDownScalingStatus,
+				ExcludedNodes: []string{"opensearch-data-2"}},
+			expectedReplicas: 2,
+		},
+		{
+			name: "exclusion is cleared and nodes excluded outside of operator are kept", replicas: 2, currentReplicas: 2,
+			shards: `[]`, health: "green", excludedSetting: "opensearch-data-2,other",
+			previous: &opensearchservice.ScaleDownStatus{Status: scaleDownScalingStatus,
+				ExcludedNodes: []string{"opensearch-data-2"}},
+			expectedStatus:    opensearchservice.ScaleDownStatus{Status: scaleDownDoneStatus},
+			expectedReplicas:  2,
+			expectedExclusion: []string{`{"persistent":{"cluster.routing.allocation.exclude._name":"other"}}`},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := newOpenSearchMock(t).
+				on(http.MethodGet, catNodesPath, http.StatusOK, nodes).
+				on(http.MethodGet, catAllocationShardsPath, http.StatusOK, test.shards).
+				on(http.MethodGet, clusterHealthPath, http.StatusOK, `{"status":"`+test.health+`"}`).
+				on(http.MethodGet, clusterDefaultSettingsPath, http.StatusOK,
+					`{"persistent":{"cluster.routing.allocation.exclude._name":"`+test.excludedSetting+`"}}`).
+				on(http.MethodPut, clusterSettingsPath, http.StatusOK, `{"acknowledged":true}`)
+
+			cr := &opensearchservice.OpenSearchService{
+				ObjectMeta: metav1.ObjectMeta{Name: "opensearch", Namespace: namespace},
+				Spec: opensearchservice.OpenSearchServiceSpec{OpenSearch: &opensearchservice.OpenSearch{
+					ScaleDown: []opensearchservice.ScaleDownTarget{{StatefulSetName: statefulSetName, Replicas: 2}},
+				}},
+			}
+			cr.Status.RollingUpdateStatus.Status = test.rollingUpdateStatus
+			if test.previous != nil {
+				previous := *test.previous
+				previous.StatefulSetName = statefulSetName
+				previous.TargetReplicas = 2
+				cr.Status.ScaleDownStatuses = []opensearchservice.ScaleDownStatus{previous}
+			}
+			statefulSet := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: statefulSetName, Namespace: namespace},
+				Spec:       appsv1.StatefulSetSpec{Replicas: &test.replicas},
+				Status:     appsv1.StatefulSetStatus{Replicas: test.currentReplicas},
+			}
+			k8sClient := newFakeClient(cr, statefulSet)
+			r := OpenSearchReconciler{
+				cr:         cr,
+				logger:     logr.Discard(),
+				reconciler: &OpenSearchServiceReconciler{Client: k8sClient},
+			}
+
+			assert.NoError(t, r.reconcileScaleDown(mock.restClient()))
+
+			updated := &opensearchservice.OpenSearchService{}
+			assert.NoError(t, k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cr), updated))
+			if assert.Len(t, updated.Status.ScaleDownStatuses, 1) {
+				status := updated.Status.ScaleDownStatuses[0]
+				assert.Equal(t, test.expectedStatus.Status, status.Status, status.Message)
+				assert.Equal(t, test.expectedStatus.ExcludedNodes, status.ExcludedNodes)
+				assert.Equal(t, test.expectedStatus.RemainingShards, status.RemainingShards)
+			}
+			assert.NoError(t, k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(statefulSet), statefulSet))
+			assert.Equal(t, test.expectedReplicas, *statefulSet.Spec.Replicas)
+			assert.Equal(t, test.expectedExclusion, mock.received(http.MethodPut, clusterSettingsPath))
+		})
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	keystoreToolPath          = "/usr/share/opensearch/bin/opensearch-keystore"
+	secureSettingsHashPattern = "secure-settings-%s"
+	nodesInfoPath             = "_nodes?filter_path=nodes.*.name,nodes.*.ephemeral_id"
+	reloadSecureSettingsPath  = "_nodes/reload_secure_settings"
+)
+
+// NodeInfo is the node description from OpenSearch nodes info API
+type NodeInfo struct {
+	Name        string `json:"name"`
+	EphemeralId string `json:"ephemeral_id"`
+}
+
+// ReloadSecureSettingsResponse is the response of OpenSearch reload secure settings API
+type ReloadSecureSettingsResponse struct {
+	NodesStatistics struct {
+		Total      int `json:"total"`
+		Successful int `json:"successful"`
+		Failed     int `json:"failed"`
+	} `json:"_nodes"`
+	Nodes map[string]struct {
+		Name            string `json:"name"`
+		ReloadException *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"reload_exception,omitempty"`
+	} `json:"nodes"`
+}
+
+// reconcileSecureSettings writes secure settings from secrets to the keystore of every OpenSearch node, removes
+// settings deleted from the spec and reloads secure settings. Settings are written again when values in secrets
+// are changed or when the node is restarted with a new keystore.
+func (r OpenSearchReconciler) reconcileSecureSettings(restClient *util.RestClient) error {
+	previous := r.cr.Status.SecureSettingsStatus
+	if previous == nil {
+		previous = &opensearchservice.SecureSettingsStatus{}
+	}
+	settings, err := r.getSecureSettings()
+	if err != nil {
+		return r.updateSecureSettingsStatus(previous, settings, false, err)
+	}
+	if len(settings) == 0 && len(previous.AppliedKeys) == 0 {
+		return nil
+	}
+	var removedKeys []string
+	for _, name := range previous.AppliedKeys {
+		if _, ok := settings[name]; !ok {
+			removedKeys = append(removedKeys, name)
+		}
+	}
+	settingsHash, err := util.Hash(settings)
+	if err != nil {
+		return err
+	}
+	nodes, err := r.getNodes(restClient)
+	if err != nil {
+		return r.updateSecureSettingsStatus(previous, settings, false, err)
+	}
+	nodeHashes := map[string]string{}
+	for _, node := range nodes {
+		nodeHash := fmt.Sprintf("%s/%s", settingsHash, node.EphemeralId)
+		if len(removedKeys) == 0 && r.state.ResourceHashes[fmt.Sprintf(secureSettingsHashPattern, node.Name)] == nodeHash {
+			continue
+		}
+		r.logger.Info(fmt.Sprintf("Updating secure settings in the keystore of [%s] node", node.Name))
+		if err = r.writeSecureSettings(node.Name, settings, removedKeys); err != nil {
+			return r.updateSecureSettingsStatus(previous, settings, false, err)
+		}
+		nodeHashes[node.Name] = nodeHash
+	}
+	if len(nodeHashes) == 0 {
+		return r.updateSecureSettingsStatus(previous, settings, false, nil)
+	}
+	if err = r.reloadSecureSettings(restClient); err != nil {
+		return r.updateSecureSettingsStatus(previous, settings, false, err)
+	}
+	for name, nodeHash := range nodeHashes {
+		r.state.ResourceHashes[fmt.Sprintf(secureSettingsHashPattern, name)] = nodeHash
+	}
+	return r.updateSecureSettingsStatus(previous, settings, true, nil)
+}
+
+// getSecureSettings returns values of secure settings from the spec and credentials of S3 snapshot repository
+func (r OpenSearchReconciler) getSecureSettings() (map[string]string, error) {
+	settings := map[string]string{}
+	if snapshots := r.cr.Spec.OpenSearch.Snapshots; snapshots != nil && snapshots.S3 != nil && snapshots.S3.KeystoreCredentialsEnabled() {
+		settings[opensearchservice.S3AccessKeySetting], settings[opensearchservice.S3SecretKeySetting] = r.getS3Credentials(snapshots.S3)
+	}
+	for _, setting := range r.cr.Spec.OpenSearch.SecureSettings {
+		secret, err := r.reconciler.findSecret(setting.SecretKeyRef.Name, r.cr.Namespace, r.logger)
+		if err != nil {
+			return nil, fmt.Errorf("can not find secret [%s] for [%s] secure setting: %w", setting.SecretKeyRef.Name, setting.Name, err)
+		}
+		value, ok := secret.Data[setting.SecretKeyRef.Key]
+		if !ok {
+			return nil, fmt.Errorf("secret [%s] does not contain [%s] key for [%s] secure setting",
+				setting.SecretKeyRef.Name, setting.SecretKeyRef.Key, setting.Name)
+		}
+		settings[setting.Name] = string(value)
+	}
+	return settings, nil
+}
+
+// getNodes returns names and ephemeral identifiers of OpenSearch nodes, node names match pod names
+func (r OpenSearchReconciler) getNodes(restClient *util.RestClient) ([]NodeInfo, error) {
+	responseBody, err := restClient.SendRequestWithStatusCodeCheck(http.MethodGet, nodesInfoPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		Nodes map[string]NodeInfo `json:"nodes"`
+	}
+	if err = json.Unmarshal(responseBody, &response); err != nil {
+		return nil, err
+	}
+	nodes := make([]NodeInfo, 0, len(response.Nodes))
+	for _, node := range response.Nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+	return nodes, nil
+}
+
+// writeSecureSettings adds settings to the keystore of the node and removes settings deleted from the spec.
+// Values are passed through standard input, so they do not appear in command line of processes.
+func (r OpenSearchReconciler) writeSecureSettings(podName string, settings map[string]string, removedKeys []string) error {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		command := []string{keystoreToolPath, "add", "--stdin", "--force", name}
+		if err := r.reconciler.runCommandInPodWithInput(podName, "opensearch", r.cr.Namespace, command,
+			strings.NewReader(settings[name])); err != nil {
+			return fmt.Errorf("unable to add [%s] secure setting to the keystore of [%s] node: %w", name, podName, err)
+		}
+	}
+	for _, name := range removedKeys {
+		// The setting may be absent in the keystore of the node that was restarted
+		command := []string{"/bin/sh", "-c",
+			fmt.Sprintf("if %[1]s list | grep -qx '%[2]s'; then %[1]s remove '%[2]s'; fi", keystoreToolPath, name)}
+		if err := r.reconciler.runCommandInPod(podName, "opensearch", r.cr.Namespace, command); err != nil {
+			return fmt.Errorf("unable to remove [%s] secure setting from the keystore of [%s] node: %w", name, podName, err)
+		}
+	}
+	return nil
+}
+
+// reloadSecureSettings reloads secure settings on all nodes and checks that every node reloaded them successfully
+func (r OpenSearchReconciler) reloadSecureSettings(restClient *util.RestClient) error {
+	responseBody, err := restClient.WithNonIdempotentRetries().SendRequestWithStatusCodeCheck(http.MethodPost,
+		reloadSecureSettingsPath, nil)
+	if err != nil {
+		return err
+	}
+	var response ReloadSecureSettingsResponse
+	if err = json.Unmarshal(responseBody, &response); err != nil {
+		return err
+	}
+	var failures []string
+	for id, node := range response.Nodes {
+		if node.ReloadException != nil {
+			name := node.Name
+			if name == "" {
+				name = id
+			}
+			failures = append(failures, fmt.Sprintf("%s: %s", name, node.ReloadException.Reason))
+		}
+	}
+	sort.Strings(failures)
+	if len(failures) > 0 || response.NodesStatistics.Failed > 0 {
+		return fmt.Errorf("secure settings are not reloaded on %d of %d nodes: %s",
+			response.NodesStatistics.Failed+len(failures), response.NodesStatistics.Total, strings.Join(failures, "; "))
+	}
+	r.logger.Info(fmt.Sprintf("Secure settings are reloaded on %d nodes", response.NodesStatistics.Successful))
+	return nil
+}
+
+func (r OpenSearchReconciler) updateSecureSettingsStatus(previous *opensearchservice.SecureSettingsStatus,
+	settings map[string]string, reloaded bool, applyErr error) error {
+	status := opensearchservice.SecureSettingsStatus{
+		Status:             managedResourceAppliedStatus,
+		LastReloadTime:     previous.LastReloadTime,
+		LastTransitionTime: previous.LastTransitionTime,
+	}
+	for name := range settings {
+		status.AppliedKeys = append(status.AppliedKeys, name)
+	}
+	sort.Strings(status.AppliedKeys)
+	if reloaded {
+		now := metav1.Now()
+		status.LastReloadTime = &now
+	}
+	if applyErr != nil {
+		// Keys of previously written settings are kept to remove them when the nodes are available
+		status.Status = managedResourceFailedStatus
+		status.Message = applyErr.Error()
+		status.AppliedKeys = mergeSettingKeys(previous.AppliedKeys, status.AppliedKeys)
+	}
+	if status.Status != previous.Status {
+		status.LastTransitionTime = metav1.Now()
+	}
+	if !reloaded && status.Status == previous.Status && status.Message == previous.Message &&
+		strings.Join(status.AppliedKeys, ",") == strings.Join(previous.AppliedKeys, ",") {
+		return applyErr
+	}
+	if err := util.NewStatusUpdater(r.reconciler.Client, r.cr).UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
+		if status.Status == managedResourceAppliedStatus && len(status.AppliedKeys) == 0 {
+			// All settings are removed from the spec and from the keystore, so there is nothing to maintain
+			instance.Status.SecureSettingsStatus = nil
+			return
+		}
+		instance.Status.SecureSettingsStatus = &status
+	}); err != nil {
+		return err
+	}
+	return applyErr
+}
@@ -0,0 +1,247 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const secureSettingsNamespace = "opensearch-service"
+
+func newSecureSettingsReconciler(cr *opensearchservice.OpenSearchService, objects ...client.Object) OpenSearchReconciler {
+	return OpenSearchReconciler{
+		cr:         cr,
+		logger:     logr.Discard(),
+		reconciler: &OpenSearchServiceReconciler{Client: newFakeClient(append(objects, cr)...)},
+		state:      &InstanceState{ResourceHashes: map[string]string{}},
+	}
+}
+
+func newSecureSettingsCR(opensearch *opensearchservice.OpenSearch,
+	status *opensearchservice.SecureSettingsStatus) *opensearchservice.OpenSearchService {
+	return &opensearchservice.OpenSearchService{
+		ObjectMeta: metav1.ObjectMeta{Name: "opensearch", Namespace: secureSettingsNamespace},
+		Spec:       opensearchservice.OpenSearchServiceSpec{OpenSearch: opensearch},
+		Status:     opensearchservice.OpenSearchServiceStatus{SecureSettingsStatus: status},
+	}
+}
+
+func newSecureSettingsStatus(status string, keys ...string) *opensearchservice.SecureSettingsStatus {
+	return &opensearchservice.SecureSettingsStatus{Status: status, AppliedKeys: keys}
+}
+
+func TestGetSecureSettings(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "secure-settings", Namespace: secureSettingsNamespace},
+		Data: map[string][]byte{
+			"token":         []byte("session-token"),
+			"s3-key-id":     []byte("key-id"),
+			"s3-key-secret": []byte("key-secret"),
+		},
+	}
+	tokenSetting := opensearchservice.SecureSetting{
+		Name: "s3.client.default.session_token",
+		SecretKeyRef: corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name}, Key: "token"},
+	}
+	tests := []struct {
+		name          string
+		opensearch    opensearchservice.OpenSearch
+		expected      map[string]string
+		expectedError bool
+	}{
+		{name: "settings from secrets",
+			opensearch: opensearchservice.OpenSearch{SecureSettings: []opensearchservice.SecureSetting{tokenSetting}},
+			expected:   map[string]string{"s3.client.default.session_token": "session-token"}},
+		{name: "credentials of S3 repository are added",
+			opensearch: opensearchservice.OpenSearch{Snapshots: &opensearchservice.Snapshots{
+				S3: &opensearchservice.S3{Enabled: true, SecretName: secret.Name}}},
+			expected: map[string]string{
+				opensearchservice.S3AccessKeySetting: "key-id",
+				opensearchservice.S3SecretKeySetting: "key-secret",
+			}},
+		{name: "credentials of S3 repository are not added in pod identity mode",
+			opensearch: opensearchservice.OpenSearch{Snapshots: &opensearchservice.Snapshots{
+				S3: &opensearchservice.S3{Enabled: true, SecretName: secret.Name, UsePodIdentity: true}}},
+			expected: map[string]string{}},
+		{name: "missing key of secret",
+			opensearch: opensearchservice.OpenSearch{SecureSettings: []opensearchservice.SecureSetting{{
+				Name: "s3.client.default.session_token",
+				SecretKeyRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name}, Key: "missing"},
+			}}},
+			expectedError: true},
+		{name: "missing secret",
+			opensearch: opensearchservice.OpenSearch{SecureSettings: []opensearchservice.SecureSetting{{
+				Name: "s3.client.default.session_token",
+				SecretKeyRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}, Key: "token"},
+			}}},
+			expectedError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opensearch := test.opensearch
+			r := newSecureSettingsReconciler(newSecureSettingsCR(&opensearch, nil), secret)
+			settings, err := r.getSecureSettings()
+			if test.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, settings)
+		})
+	}
+}
+
+func TestReconcileSecureSettings(t *testing.T) {
+	const (
+		settingName = "plugins.alerting.destination.token"
+		nodes       = `{"nodes":{"id":{"name":"opensearch-0","ephemeral_id":"ephemeral"}}}`
+	)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "secure-settings", Namespace: secureSettingsNamespace},
+		Data:       map[string][]byte{"token": []byte("value")},
+	}
+	setting := opensearchservice.SecureSetting{
+		Name: settingName,
+		SecretKeyRef: corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name}, Key: "token"},
+	}
+	settingsHash, err := util.Hash(map[string]string{settingName: "value"})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name                string
+		settings            []opensearchservice.SecureSetting
+		previous            *opensearchservice.SecureSettingsStatus
+		nodesStatusCode     int
+		nodeHash            string
+		expectedError       bool
+		expectedStatus      *opensearchservice.SecureSettingsStatus
+		expectedNodeQueries int
+	}{
+		{name: "nothing is done without secure settings", nodesStatusCode: http.StatusOK},
+		{name: "keys removed from the spec are kept while nodes are unavailable",
+			settings:            []opensearchservice.SecureSetting{setting},
+			previous:            newSecureSettingsStatus(managedResourceAppliedStatus, settingName, "removed"),
+			nodesStatusCode:     http.StatusServiceUnavailable,
+			expectedError:       true,
+			expectedStatus:      newSecureSettingsStatus(managedResourceFailedStatus, settingName, "removed"),
+			expectedNodeQueries: 1},
+		{name: "keys removed from the spec are kept when secret is invalid",
+			settings: []opensearchservice.SecureSetting{{Name: "new", SecretKeyRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name}, Key: "missing"}}},
+			previous:        newSecureSettingsStatus(managedResourceAppliedStatus, "removed"),
+			nodesStatusCode: http.StatusOK,
+			expectedError:   true,
+			expectedStatus:  newSecureSettingsStatus(managedResourceFailedStatus, "removed")},
+		{name: "keystore of the node is not written again while settings and node are the same",
+			settings:            []opensearchservice.SecureSetting{setting},
+			previous:            newSecureSettingsStatus(managedResourceAppliedStatus, settingName),
+			nodesStatusCode:     http.StatusOK,
+			nodeHash:            fmt.Sprintf("%s/%s", settingsHash, "ephemeral"),
+			expectedStatus:      newSecureSettingsStatus(managedResourceAppliedStatus, settingName),
+			expectedNodeQueries: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := newOpenSearchMock(t).
+				on(http.MethodGet, nodesInfoPath, test.nodesStatusCode, nodes)
+			cr := newSecureSettingsCR(&opensearchservice.OpenSearch{SecureSettings: test.settings}, test.previous)
+			r := newSecureSettingsReconciler(cr, secret)
+			if test.nodeHash != "" {
+				r.state.ResourceHashes[fmt.Sprintf(secureSettingsHashPattern, "opensearch-0")] = test.nodeHash
+			}
+
+			err := r.reconcileSecureSettings(mock.restClient())
+			assert.Equal(t, test.expectedError, err != nil, err)
+
+			updated := &opensearchservice.OpenSearchService{}
+			assert.NoError(t, r.reconciler.Client.Get(context.TODO(), client.ObjectKeyFromObject(cr), updated))
+			status := updated.Status.SecureSettingsStatus
+			if test.expectedStatus == nil {
+				assert.Nil(t, status)
+			} else if assert.NotNil(t, status) {
+				assert.Equal(t, test.expectedStatus.Status, status.Status)
+				assert.Equal(t, test.expectedStatus.AppliedKeys, status.AppliedKeys)
+			}
+			assert.Len(t, mock.received(http.MethodGet, nodesInfoPath), test.expectedNodeQueries)
+			assert.Empty(t, mock.received(http.MethodPost, reloadSecureSettingsPath))
+		})
+	}
+}
+
+func TestUpdateSecureSettingsStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		previous       opensearchservice.SecureSettingsStatus
+		settings       map[string]string
+		reloaded       bool
+		applyErr       error
+		expectedStatus *opensearchservice.SecureSettingsStatus
+	}{
+		{name: "status is removed when all keys are removed from the keystore",
+			previous: *newSecureSettingsStatus(managedResourceAppliedStatus, "removed"),
+			settings: map[string]string{}, reloaded: true},
+		{name: "removed keys are replaced with keys of settings after reload",
+			previous:       *newSecureSettingsStatus(managedResourceAppliedStatus, "removed"),
+			settings:       map[string]string{"second": "value", "first": "value"},
+			reloaded:       true,
+			expectedStatus: newSecureSettingsStatus(managedResourceAppliedStatus, "first", "second")},
+		{name: "removed keys are merged with keys of settings on failure",
+			previous: *newSecureSettingsStatus(managedResourceAppliedStatus, "removed"),
+			settings: map[string]string{"first": "value"},
+			applyErr: errors.New("reload is failed"),
+			expectedStatus: &opensearchservice.SecureSettingsStatus{Status: managedResourceFailedStatus, Message: "reload is failed",
+				AppliedKeys: []string{"first", "removed"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			previous := test.previous
+			cr := newSecureSettingsCR(&opensearchservice.OpenSearch{}, &previous)
+			r := newSecureSettingsReconciler(cr)
+
+			err := r.updateSecureSettingsStatus(&previous, test.settings, test.reloaded, test.applyErr)
+			assert.Equal(t, test.applyErr, err)
+
+			updated := &opensearchservice.OpenSearchService{}
+			assert.NoError(t, r.reconciler.Client.Get(context.TODO(), client.ObjectKeyFromObject(cr), updated))
+			status := updated.Status.SecureSettingsStatus
+			if test.expectedStatus == nil {
+				assert.Nil(t, status)
+				return
+			}
+			if assert.NotNil(t, status) {
+				assert.Equal(t, test.expectedStatus.Status, status.Status)
+				assert.Equal(t, test.expectedStatus.Message, status.Message)
+				assert.Equal(t, test.expectedStatus.AppliedKeys, status.AppliedKeys)
+				assert.Equal(t, test.reloaded, status.LastReloadTime != nil)
+			}
+		})
+	}
+}
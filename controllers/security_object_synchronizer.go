@@ -0,0 +1,261 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	securityObjectFinalizer      = "qubership.org/security-object"
+	securityObjectAppliedStatus  = "applied"
+	securityObjectFailedStatus   = "failed"
+	securityApiEntityPathPattern = "_plugins/_security/api/%s/%s"
+)
+
+// securityEntity describes entity of OpenSearch security plugin maintained by a custom resource
+type securityEntity struct {
+	// kind is name of the entity type used in messages
+	kind string
+	// resource is type of the entity in security plugin REST API, for example "internalusers"
+	resource string
+	name     string
+}
+
+func (e securityEntity) path() string {
+	return fmt.Sprintf(securityApiEntityPathPattern, e.resource, e.name)
+}
+
+// securityEntityDefinition is the body of the entity sent to OpenSearch and the data its applied hash is calculated from
+type securityEntityDefinition struct {
+	body     interface{}
+	hashData interface{}
+}
+
+// securityObjectSynchronizer contains logic shared by controllers of OpenSearchUser, OpenSearchRole
+// and OpenSearchRoleMapping custom resources
+type securityObjectSynchronizer struct {
+	client client.Client
+	logger logr.Logger
+}
+
+// synchronize applies security entity to OpenSearch of referenced OpenSearchService and writes result to the status.
+// Deleted custom resources are released after the entity is removed from OpenSearch.
+func (s securityObjectSynchronizer) synchronize(object client.Object, status *opensearchservice.SecurityObjectStatus,
+	serviceName string, entity securityEntity, define func() (*securityEntityDefinition, error)) (ctrl.Result, error) {
+	if !object.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, s.release(object, status, serviceName, entity)
+	}
+	if !controllerutil.ContainsFinalizer(object, securityObjectFinalizer) {
+		patch := client.MergeFrom(object.DeepCopyObject().(client.Object))
+		controllerutil.AddFinalizer(object, securityObjectFinalizer)
+		if err := s.client.Patch(context.TODO(), object, patch); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	service, err := s.findService(object.GetNamespace(), serviceName)
+	if err != nil {
+		return ctrl.Result{}, s.fail(object, status, err)
+	}
+	definition, err := define()
+	if err != nil {
+		return ctrl.Result{}, s.fail(object, status, err)
+	}
+	hash, err := util.Hash(definition.hashData)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if status.Status == securityObjectAppliedStatus && status.AppliedHash == hash && status.AppliedName == entity.name &&
+		status.ObservedGeneration == object.GetGeneration() {
+		return ctrl.Result{}, nil
+	}
+
+	restClient, err := newOpenSearchRestClient(s.client, service, s.logger)
+	if err != nil {
+		return ctrl.Result{}, s.fail(object, status, err)
+	}
+	exists, modifiable, err := s.lookupEntity(restClient, entity)
+	if err != nil {
+		return ctrl.Result{}, s.fail(object, status, err)
+	}
+	// Reserved entities and entities created outside of the resource can not be changed,
+	// so there is no reason to retry until the spec is changed
+	if !modifiable {
+		return ctrl.Result{}, s.updateStatus(object, status, securityObjectFailedStatus,
+			fmt.Sprintf("[%s] %s is reserved or hidden in OpenSearch and can not be modified", entity.name, entity.kind),
+			status.AppliedHash, status.AppliedName)
+	}
+	if exists && status.AppliedName != entity.name {
+		return ctrl.Result{}, s.updateStatus(object, status, securityObjectFailedStatus,
+			fmt.Sprintf("[%s] %s already exists in OpenSearch and is not created by the resource", entity.name, entity.kind),
+			status.AppliedHash, status.AppliedName)
+	}
+	if status.AppliedName != entity.name {
+		if status.AppliedName != "" {
+			previous := entity
+			previous.name = status.AppliedName
+			if err = s.deleteEntity(restClient, previous); err != nil {
+				return ctrl.Result{}, s.fail(object, status, err)
+			}
+		}
+		// The name is recorded before the entity is created to remove it on deletion of the resource
+		// even if the following status update fails
+		if err = s.updateStatus(object, status, status.Status, status.Message, "", entity.name); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	body, err := json.Marshal(definition.body)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	s.logger.Info(fmt.Sprintf("Applying [%s] %s to OpenSearch", entity.name, entity.kind))
+	if _, err = restClient.SendRequestWithStatusCodeCheck(http.MethodPut, entity.path(), strings.NewReader(string(body))); err != nil {
+		return ctrl.Result{}, s.fail(object, status, fmt.Errorf("unable to apply %s: %v", entity.kind, err))
+	}
+	return ctrl.Result{}, s.updateStatus(object, status, securityObjectAppliedStatus, "", hash, entity.name)
+}
+
+// release removes security entity from OpenSearch and removes finalizer from deleted custom resource.
+// The entity is left as is if it was never created or OpenSearchService does not exist anymore.
+func (s securityObjectSynchronizer) release(object client.Object, status *opensearchservice.SecurityObjectStatus,
+	serviceName string, entity securityEntity) error {
+	if !controllerutil.ContainsFinalizer(object, securityObjectFinalizer) {
+		return nil
+	}
+	if status.AppliedName != "" {
+		service, err := s.findService(object.GetNamespace(), serviceName)
+		if err == nil && service.DeletionTimestamp.IsZero() {
+			restClient, err := newOpenSearchRestClient(s.client, service, s.logger)
+			if err != nil {
+				return s.fail(object, status, err)
+			}
+			entity.name = status.AppliedName
+			if err = s.deleteEntity(restClient, entity); err != nil {
+				return s.fail(object, status, err)
+			}
+		} else if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	patch := client.MergeFrom(object.DeepCopyObject().(client.Object))
+	controllerutil.RemoveFinalizer(object, securityObjectFinalizer)
+	return s.client.Patch(context.TODO(), object, patch)
+}
+
+func (s securityObjectSynchronizer) deleteEntity(restClient *util.RestClient, entity securityEntity) error {
+	_, modifiable, err := s.lookupEntity(restClient, entity)
+	if err != nil {
+		return err
+	}
+	if !modifiable {
+		s.logger.Info(fmt.Sprintf("[%s] %s is reserved or hidden in OpenSearch, it is not removed", entity.name, entity.kind))
+		return nil
+	}
+	s.logger.Info(fmt.Sprintf("Removing [%s] %s from OpenSearch", entity.name, entity.kind))
+	statusCode, body, err := restClient.SendRequest(http.MethodDelete, entity.path(), nil)
+	if err != nil {
+		return err
+	}
+	if statusCode >= 400 && statusCode != http.StatusNotFound {
+		return fmt.Errorf("unable to remove %s, status code - [%d], response - [%s]", entity.kind, statusCode, string(body))
+	}
+	return nil
+}
+
+// lookupEntity checks whether the entity exists in OpenSearch and whether it can be modified,
+// reserved, hidden and static entities are not modifiable
+func (s securityObjectSynchronizer) lookupEntity(restClient *util.RestClient, entity securityEntity) (bool, bool, error) {
+	statusCode, body, err := restClient.SendRequest(http.MethodGet, entity.path(), nil)
+	if err != nil {
+		return false, false, err
+	}
+	if statusCode == http.StatusNotFound {
+		return false, true, nil
+	}
+	if statusCode >= 400 {
+		return false, false, fmt.Errorf("unable to get %s, status code - [%d], response - [%s]", entity.kind, statusCode, string(body))
+	}
+	var entities map[string]struct {
+		Reserved bool `json:"reserved"`
+		Hidden   bool `json:"hidden"`
+		Static   bool `json:"static"`
+	}
+	if err = json.Unmarshal(body, &entities); err != nil {
+		return false, false, err
+	}
+	existing, ok := entities[entity.name]
+	return ok, !(existing.Reserved || existing.Hidden || existing.Static), nil
+}
+
+func (s securityObjectSynchronizer) findService(namespace string, name string) (*opensearchservice.OpenSearchService, error) {
+	service := &opensearchservice.OpenSearchService{}
+	if err := s.client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, service); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("OpenSearchService [%s] is not found: %w", name, err)
+		}
+		return nil, err
+	}
+	return service, nil
+}
+
+// fail writes the error to the status and returns it to retry reconciliation
+func (s securityObjectSynchronizer) fail(object client.Object, status *opensearchservice.SecurityObjectStatus, err error) error {
+	if statusErr := s.updateStatus(object, status, securityObjectFailedStatus, err.Error(), status.AppliedHash,
+		status.AppliedName); statusErr != nil {
+		s.logger.Error(statusErr, "Unable to update status")
+	}
+	return err
+}
+
+// updateStatus updates status of the custom resource if it is changed, the status points to the field of the object
+func (s securityObjectSynchronizer) updateStatus(object client.Object, status *opensearchservice.SecurityObjectStatus,
+	state string, message string, hash string, name string) error {
+	newStatus := opensearchservice.SecurityObjectStatus{
+		Status:             state,
+		Message:            message,
+		ObservedGeneration: object.GetGeneration(),
+		AppliedHash:        hash,
+		AppliedName:        name,
+		LastTransitionTime: status.LastTransitionTime,
+	}
+	if reflect.DeepEqual(*status, newStatus) {
+		return nil
+	}
+	newStatus.LastTransitionTime = metav1.Now()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := s.client.Get(context.TODO(), client.ObjectKeyFromObject(object), object); err != nil {
+			return err
+		}
+		*status = newStatus
+		return s.client.Status().Update(context.TODO(), object)
+	})
+}
@@ -0,0 +1,177 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSynchronizeSecurityObject(t *testing.T) {
+	rolePath := "_plugins/_security/api/roles/app-role"
+	previousRolePath := "_plugins/_security/api/roles/old-role"
+	spec := opensearchservice.OpenSearchRoleSpec{
+		OpenSearchServiceRef: corev1.LocalObjectReference{Name: "opensearch"},
+		RoleName:             "app-role",
+		Description:          "Application role",
+		ClusterPermissions:   []string{"cluster_monitor"},
+	}
+	hash, err := util.Hash(makeSecurityRole(spec))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		status          opensearchservice.SecurityObjectStatus
+		existing        string
+		previous        string
+		expectedStatus  string
+		expectedMessage string
+		expectedName    string
+		expectedPut     bool
+		expectedDelete  bool
+	}{
+		{name: "new role is created",
+			expectedStatus: securityObjectAppliedStatus, expectedName: "app-role", expectedPut: true},
+		{name: "unchanged role is not applied again",
+			status: opensearchservice.SecurityObjectStatus{Status: securityObjectAppliedStatus, ObservedGeneration: 1,
+				AppliedHash: hash, AppliedName: "app-role"},
+			expectedStatus: securityObjectAppliedStatus, expectedName: "app-role"},
+		{name: "changed role is updated", existing: `{"app-role":{"reserved":false}}`,
+			status: opensearchservice.SecurityObjectStatus{Status: securityObjectAppliedStatus, ObservedGeneration: 1,
+				AppliedHash: "previous", AppliedName: "app-role"},
+			expectedStatus: securityObjectAppliedStatus, expectedName: "app-role", expectedPut: true},
+		{name: "role created outside of the resource is not adopted", existing: `{"app-role":{"reserved":false}}`,
+			expectedStatus: securityObjectFailedStatus, expectedMessage: "[app-role] role already exists in OpenSearch and is not created by the resource"},
+		{name: "reserved role is not modified", existing: `{"app-role":{"reserved":true}}`,
+			expectedStatus: securityObjectFailedStatus, expectedMessage: "[app-role] role is reserved or hidden in OpenSearch and can not be modified"},
+		{name: "renamed role replaces the previous one", previous: `{"old-role":{"reserved":false}}`,
+			status: opensearchservice.SecurityObjectStatus{Status: securityObjectAppliedStatus, ObservedGeneration: 1,
+				AppliedHash: hash, AppliedName: "old-role"},
+			expectedStatus: securityObjectAppliedStatus, expectedName: "app-role", expectedPut: true, expectedDelete: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := newOpenSearchMock(t).
+				on(http.MethodPut, rolePath, http.StatusOK, `{"status":"OK"}`).
+				on(http.MethodDelete, previousRolePath, http.StatusOK, `{"status":"OK"}`)
+			if test.existing != "" {
+				mock.on(http.MethodGet, rolePath, http.StatusOK, test.existing)
+			}
+			if test.previous != "" {
+				mock.on(http.MethodGet, previousRolePath, http.StatusOK, test.previous)
+			}
+			role := &opensearchservice.OpenSearchRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "opensearch-service", Generation: 1},
+				Spec:       spec,
+				Status:     test.status,
+			}
+			r := &OpenSearchRoleReconciler{Client: newFakeClient(newExternalOpenSearchService(mock), role)}
+
+			_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(role)})
+			assert.NoError(t, err)
+
+			updated := &opensearchservice.OpenSearchRole{}
+			assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(role), updated))
+			assert.Contains(t, updated.Finalizers, securityObjectFinalizer)
+			assert.Equal(t, test.expectedStatus, updated.Status.Status)
+			assert.Equal(t, test.expectedMessage, updated.Status.Message)
+			if test.expectedStatus == securityObjectAppliedStatus {
+				assert.Equal(t, hash, updated.Status.AppliedHash)
+				assert.Equal(t, test.expectedName, updated.Status.AppliedName)
+			} else {
+				assert.Empty(t, updated.Status.AppliedName)
+			}
+			received := mock.received(http.MethodPut, rolePath)
+			if test.expectedPut {
+				assert.Len(t, received, 1)
+				if len(received) == 1 {
+					assert.JSONEq(t, `{"description":"Application role","cluster_permissions":["cluster_monitor"]}`, received[0])
+				}
+			} else {
+				assert.Empty(t, received)
+			}
+			if test.expectedDelete {
+				assert.Len(t, mock.received(http.MethodDelete, previousRolePath), 1)
+			} else {
+				assert.Empty(t, mock.received(http.MethodDelete, previousRolePath))
+			}
+		})
+	}
+}
+
+func TestReleaseSecurityObject(t *testing.T) {
+	rolePath := "_plugins/_security/api/roles/app-role"
+	tests := []struct {
+		name           string
+		status         opensearchservice.SecurityObjectStatus
+		existing       string
+		expectedDelete bool
+	}{
+		{name: "applied role is removed", existing: `{"app-role":{"reserved":false}}`,
+			status:         opensearchservice.SecurityObjectStatus{Status: securityObjectAppliedStatus, AppliedName: "app-role"},
+			expectedDelete: true},
+		{name: "role which became reserved is kept", existing: `{"app-role":{"reserved":true}}`,
+			status: opensearchservice.SecurityObjectStatus{Status: securityObjectAppliedStatus, AppliedName: "app-role"}},
+		{name: "role which was never created is not removed", existing: `{"app-role":{"reserved":false}}`,
+			status: opensearchservice.SecurityObjectStatus{Status: securityObjectFailedStatus}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := newOpenSearchMock(t).
+				on(http.MethodGet, rolePath, http.StatusOK, test.existing).
+				on(http.MethodDelete, rolePath, http.StatusOK, `{"status":"OK"}`)
+			deletionTimestamp := metav1.Now()
+			role := &opensearchservice.OpenSearchRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "opensearch-service",
+					DeletionTimestamp: &deletionTimestamp, Finalizers: []string{securityObjectFinalizer}},
+				Spec: opensearchservice.OpenSearchRoleSpec{
+					OpenSearchServiceRef: corev1.LocalObjectReference{Name: "opensearch"}, RoleName: "app-role"},
+				Status: test.status,
+			}
+			r := &OpenSearchRoleReconciler{Client: newFakeClient(newExternalOpenSearchService(mock), role)}
+
+			_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(role)})
+			assert.NoError(t, err)
+
+			err = r.Client.Get(context.TODO(), client.ObjectKeyFromObject(role), &opensearchservice.OpenSearchRole{})
+			assert.True(t, errors.IsNotFound(err), "finalizer is not removed")
+			if test.expectedDelete {
+				assert.Len(t, mock.received(http.MethodDelete, rolePath), 1)
+			} else {
+				assert.Empty(t, mock.received(http.MethodDelete, rolePath))
+			}
+		})
+	}
+}
+
+// newExternalOpenSearchService returns OpenSearchService of external OpenSearch served by the mock
+func newExternalOpenSearchService(mock *openSearchMock) *opensearchservice.OpenSearchService {
+	return &opensearchservice.OpenSearchService{
+		ObjectMeta: metav1.ObjectMeta{Name: "opensearch", Namespace: "opensearch-service"},
+		Spec: opensearchservice.OpenSearchServiceSpec{
+			ExternalOpenSearch: &opensearchservice.ExternalOpenSearch{Url: mock.server.URL},
+		},
+	}
+}
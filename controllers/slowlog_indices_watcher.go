@@ -15,20 +15,18 @@
 package controllers
 
 import (
+	"context"
 	"fmt"
 	"github.com/Netcracker/opensearch-service/util"
 	"github.com/go-logr/logr"
 	"net/http"
 	"strings"
-	"sync"
 	"time"
 )
 
 const (
 	allIndicesExceptSystemPattern      = "*,-.*"
 	indicesExceptSystemPatternTemplate = "%s,-.*"
-	runningWatcherState                = "running"
-	stoppedWatcherState                = "stopped"
 	watchInterval                      = 60 * time.Second
 )
 
@@ -37,64 +35,53 @@ type SlowLogIndicesHelper struct {
 	restClient *util.RestClient
 }
 
+// SlowLogIndicesWatcher keeps slow log settings on indices created after the settings are applied,
+// it is run by the manager as background watcher
 type SlowLogIndicesWatcher struct {
-	lock  *sync.Mutex
-	State *string
+	*backgroundWatcher
 }
 
-func NewSlowLogIndicesWatcher(mutex *sync.Mutex) SlowLogIndicesWatcher {
-	state := stoppedWatcherState
-	return SlowLogIndicesWatcher{
-		lock:  mutex,
-		State: &state,
-	}
+func NewSlowLogIndicesWatcher(name string) *SlowLogIndicesWatcher {
+	return &SlowLogIndicesWatcher{backgroundWatcher: newBackgroundWatcher(name)}
 }
 
-func (sliw SlowLogIndicesWatcher) start(helper SlowLogIndicesHelper, indicesPattern string, minSeconds int) {
+func (sliw *SlowLogIndicesWatcher) start(helper SlowLogIndicesHelper, indicesPattern string, minSeconds int) {
 	sliw.stop(helper)
-	*sliw.State = runningWatcherState
-	go sliw.watch(helper, indicesPattern, minSeconds)
-}
-
-func (sliw SlowLogIndicesWatcher) stop(helper SlowLogIndicesHelper) {
-	if *sliw.State != stoppedWatcherState {
-		*sliw.State = stoppedWatcherState
-		sliw.removeSlowLogSetting(helper)
-	}
+	sliw.run(watchInterval, func(ctx context.Context) {
+		sliw.addSlowLogSetting(helper, indicesPattern, minSeconds)
+	})
 }
 
-func (sliw SlowLogIndicesWatcher) watch(helper SlowLogIndicesHelper, indicesPattern string, minSeconds int) {
-	sliw.lock.Lock()
-	defer sliw.lock.Unlock()
-	for {
-		if *sliw.State == stoppedWatcherState {
-			helper.logger.Info("SlowLog Indices Watcher is stopped, exit from watch loop")
-			return
-		}
-		sliw.addSlowLogSetting(helper, indicesPattern, minSeconds)
-		time.Sleep(watchInterval)
+// stop stops the watcher and removes slow log settings applied by the watcher before
+func (sliw *SlowLogIndicesWatcher) stop(helper SlowLogIndicesHelper) {
+	if sliw.isRunning() {
+		sliw.halt()
+		_ = sliw.removeSlowLogSetting(helper)
 	}
 }
 
-func (sliw SlowLogIndicesWatcher) addSlowLogSetting(helper SlowLogIndicesHelper, indicesPattern string, minSeconds int) {
+func (sliw *SlowLogIndicesWatcher) addSlowLogSetting(helper SlowLogIndicesHelper, indicesPattern string, minSeconds int) {
 	pattern := fmt.Sprintf(indicesExceptSystemPatternTemplate, indicesPattern)
 	body := fmt.Sprintf(`{"search": {"slowlog": {"threshold": {"query": {"warn": "-1", "trace": "-1", "debug": "-1", "info": "%ds"}}}}}`, minSeconds)
-	sliw.updateSettings(helper, pattern, body)
+	_ = sliw.updateSettings(helper, pattern, body)
 }
 
-func (sliw SlowLogIndicesWatcher) removeSlowLogSetting(helper SlowLogIndicesHelper) {
-	sliw.lock.Lock()
-	defer sliw.lock.Unlock()
+func (sliw *SlowLogIndicesWatcher) removeSlowLogSetting(helper SlowLogIndicesHelper) error {
 	body := `{"search": {"slowlog": {"threshold": {"query": {"warn": null, "trace": null, "debug": null, "info": null}}}}}`
-	sliw.updateSettings(helper, allIndicesExceptSystemPattern, body)
+	return sliw.updateSettings(helper, allIndicesExceptSystemPattern, body)
 }
 
-func (sliw SlowLogIndicesWatcher) updateSettings(helper SlowLogIndicesHelper, indicesPattern string, body string) {
+func (sliw *SlowLogIndicesWatcher) updateSettings(helper SlowLogIndicesHelper, indicesPattern string, body string) error {
 	path := fmt.Sprintf("%s/_settings?allow_no_indices=true", indicesPattern)
 	statusCode, responseBody, err := helper.restClient.SendRequest(http.MethodPut, path, strings.NewReader(body))
 	if err != nil {
 		helper.logger.Error(err, "unable to update indices `slowlog` settings")
+		return err
 	}
 	helper.logger.Info(fmt.Sprintf("Update settings request is finished with `%d` status code and body: %s",
 		statusCode, string(responseBody)))
+	if statusCode >= 400 {
+		return fmt.Errorf("unable to update indices `slowlog` settings, status code - [%d]", statusCode)
+	}
+	return nil
 }
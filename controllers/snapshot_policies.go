@@ -0,0 +1,280 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	snapshotPolicyExplainPathPattern = "_plugins/_sm/policies/%s/_explain"
+	defaultSnapshotPolicyTimezone    = "UTC"
+)
+
+var snapshotPolicyKind = managedResourceKind{
+	name:         "snapshot policy",
+	pathPattern:  "_plugins/_sm/policies/%s",
+	createMethod: http.MethodPost,
+	extract: func(body []byte) (*managedResourceDefinition, error) {
+		var response struct {
+			SeqNo       *int64          `json:"_seq_no"`
+			PrimaryTerm *int64          `json:"_primary_term"`
+			Policy      json.RawMessage `json:"sm_policy"`
+		}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, err
+		}
+		return &managedResourceDefinition{
+			Definition:  response.Policy,
+			SeqNo:       response.SeqNo,
+			PrimaryTerm: response.PrimaryTerm,
+		}, nil
+	},
+}
+
+type SnapshotPolicy struct {
+	Description    string                  `json:"description"`
+	Creation       SnapshotPolicyCreation  `json:"creation"`
+	Deletion       *SnapshotPolicyDeletion `json:"deletion,omitempty"`
+	SnapshotConfig SnapshotPolicyConfig    `json:"snapshot_config"`
+	Enabled        bool                    `json:"enabled"`
+}
+
+type SnapshotPolicyCreation struct {
+	Schedule SnapshotPolicySchedule `json:"schedule"`
+}
+
+type SnapshotPolicyDeletion struct {
+	Schedule  SnapshotPolicySchedule  `json:"schedule"`
+	Condition SnapshotPolicyCondition `json:"condition"`
+}
+
+type SnapshotPolicySchedule struct {
+	Cron SnapshotPolicyCron `json:"cron"`
+}
+
+type SnapshotPolicyCron struct {
+	Expression string `json:"expression"`
+	Timezone   string `json:"timezone"`
+}
+
+type SnapshotPolicyCondition struct {
+	MaxCount int    `json:"max_count,omitempty"`
+	MinCount int    `json:"min_count,omitempty"`
+	MaxAge   string `json:"max_age,omitempty"`
+}
+
+type SnapshotPolicyConfig struct {
+	Indices            string `json:"indices"`
+	Repository         string `json:"repository"`
+	IncludeGlobalState bool   `json:"include_global_state"`
+	Timezone           string `json:"timezone"`
+}
+
+type SnapshotPolicyExplanation struct {
+	Name     string                  `json:"name"`
+	Creation SnapshotPolicyExecution `json:"creation"`
+}
+
+type SnapshotPolicyExecution struct {
+	Trigger struct {
+		Time int64 `json:"time"`
+	} `json:"trigger"`
+	LatestExecution *struct {
+		Status    string `json:"status"`
+		StartTime int64  `json:"start_time"`
+		EndTime   int64  `json:"end_time"`
+		Info      struct {
+			Message string `json:"message"`
+			Cause   string `json:"cause"`
+		} `json:"info"`
+	} `json:"latest_execution"`
+}
+
+// reconcileSnapshotPolicies maintains Snapshot Management policies for schedules from the spec
+// and removes policies of deleted schedules
+func (r OpenSearchReconciler) reconcileSnapshotPolicies(restClient *util.RestClient) error {
+	var schedules []opensearchservice.SnapshotSchedule
+	snapshots := getSnapshots(r.cr)
+	repositoryName := getSnapshotRepositoryName(snapshots)
+	if snapshots != nil {
+		schedules = snapshots.Schedules
+	}
+	var policies []opensearchservice.IndexManagementResource
+	for _, schedule := range schedules {
+		body, err := json.Marshal(makeSnapshotPolicy(schedule, repositoryName))
+		if err != nil {
+			return err
+		}
+		policies = append(policies, opensearchservice.IndexManagementResource{
+			Name: schedule.Name,
+			Body: apiextensionsv1.JSON{Raw: body},
+		})
+	}
+
+	var previous []opensearchservice.ManagedResourceStatus
+	for _, policyStatus := range r.cr.Status.SnapshotPolicies {
+		previous = append(previous, policyStatus.ManagedResourceStatus)
+	}
+	resourceStatuses := applyManagedResources(restClient, r.logger, snapshotPolicyKind, policies, previous)
+	resourceStatuses = append(resourceStatuses,
+		deleteRemovedManagedResources(restClient, r.logger, snapshotPolicyKind, policies, previous)...)
+
+	var failed []string
+	statuses := make([]opensearchservice.SnapshotPolicyStatus, 0, len(resourceStatuses))
+	for _, resourceStatus := range resourceStatuses {
+		policyStatus := opensearchservice.SnapshotPolicyStatus{}
+		if previousStatus := findSnapshotPolicyStatus(r.cr.Status.SnapshotPolicies, resourceStatus.Name); previousStatus != nil {
+			policyStatus = *previousStatus
+		}
+		policyStatus.ManagedResourceStatus = resourceStatus
+		statuses = append(statuses, policyStatus)
+		if resourceStatus.Status == managedResourceFailedStatus {
+			failed = append(failed, resourceStatus.Name)
+		}
+	}
+	explanations := r.explainSnapshotPolicies(restClient, statuses)
+
+	statusUpdater := util.NewStatusUpdater(r.reconciler.Client, r.cr)
+	if err := statusUpdater.UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
+		instance.Status.SnapshotPolicies = statuses
+		updateSnapshotPolicyExecutions(instance.Status.SnapshotPolicies, explanations)
+	}); err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("unable to apply snapshot policies %v, see status for details", failed)
+	}
+	return nil
+}
+
+// explainSnapshotPolicies returns state of creation workflow of applied Snapshot Management policies
+func (r OpenSearchReconciler) explainSnapshotPolicies(restClient *util.RestClient,
+	statuses []opensearchservice.SnapshotPolicyStatus) []SnapshotPolicyExplanation {
+	var names []string
+	for _, policyStatus := range statuses {
+		if policyStatus.Status == managedResourceAppliedStatus {
+			names = append(names, policyStatus.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	body, err := restClient.SendRequestWithStatusCodeCheck(http.MethodGet,
+		fmt.Sprintf(snapshotPolicyExplainPathPattern, strings.Join(names, ",")), nil)
+	if err != nil {
+		r.logger.Error(err, "Unable to explain snapshot policies")
+		return nil
+	}
+	var response struct {
+		Policies []SnapshotPolicyExplanation `json:"policies"`
+	}
+	if err = json.Unmarshal(body, &response); err != nil {
+		r.logger.Error(err, "Unable to parse response of snapshot policies explain request")
+		return nil
+	}
+	return response.Policies
+}
+
+// updateSnapshotPolicyExecutions writes results of the latest snapshot creations and the next run time to statuses.
+// Only the latest execution is returned by OpenSearch, so the last success or failure is kept until it is replaced.
+func updateSnapshotPolicyExecutions(statuses []opensearchservice.SnapshotPolicyStatus,
+	explanations []SnapshotPolicyExplanation) {
+	for _, explanation := range explanations {
+		policyStatus := findSnapshotPolicyStatus(statuses, explanation.Name)
+		if policyStatus == nil {
+			continue
+		}
+		if explanation.Creation.Trigger.Time > 0 {
+			nextRunTime := metav1.NewTime(time.UnixMilli(explanation.Creation.Trigger.Time))
+			policyStatus.NextRunTime = &nextRunTime
+		}
+		execution := explanation.Creation.LatestExecution
+		if execution == nil {
+			continue
+		}
+		executionTime := execution.EndTime
+		if executionTime == 0 {
+			executionTime = execution.StartTime
+		}
+		finishedAt := metav1.NewTime(time.UnixMilli(executionTime))
+		switch execution.Status {
+		case "SUCCESS":
+			policyStatus.LastSuccessTime = &finishedAt
+		case "FAILED", "TIME_LIMIT_EXCEEDED":
+			policyStatus.LastFailureTime = &finishedAt
+			policyStatus.LastFailureMessage = strings.TrimSpace(fmt.Sprintf("%s %s",
+				execution.Info.Message, execution.Info.Cause))
+		}
+	}
+}
+
+func makeSnapshotPolicy(schedule opensearchservice.SnapshotSchedule, repositoryName string) SnapshotPolicy {
+	timezone := schedule.Timezone
+	if timezone == "" {
+		timezone = defaultSnapshotPolicyTimezone
+	}
+	indices := "*"
+	if len(schedule.IndexPatterns) > 0 {
+		indices = strings.Join(schedule.IndexPatterns, ",")
+	}
+	policy := SnapshotPolicy{
+		Description: fmt.Sprintf("Snapshot schedule [%s] maintained by OpenSearch service operator", schedule.Name),
+		Creation: SnapshotPolicyCreation{
+			Schedule: SnapshotPolicySchedule{Cron: SnapshotPolicyCron{Expression: schedule.Cron, Timezone: timezone}},
+		},
+		SnapshotConfig: SnapshotPolicyConfig{
+			Indices:            indices,
+			Repository:         repositoryName,
+			IncludeGlobalState: schedule.IncludeGlobalState,
+			Timezone:           timezone,
+		},
+		Enabled: true,
+	}
+	if schedule.Retention != nil {
+		deletionCron := schedule.DeletionCron
+		if deletionCron == "" {
+			deletionCron = schedule.Cron
+		}
+		policy.Deletion = &SnapshotPolicyDeletion{
+			Schedule: SnapshotPolicySchedule{Cron: SnapshotPolicyCron{Expression: deletionCron, Timezone: timezone}},
+			Condition: SnapshotPolicyCondition{
+				MaxCount: schedule.Retention.MaxCount,
+				MinCount: schedule.Retention.MinCount,
+				MaxAge:   schedule.Retention.MaxAge,
+			},
+		}
+	}
+	return policy
+}
+
+func findSnapshotPolicyStatus(statuses []opensearchservice.SnapshotPolicyStatus,
+	name string) *opensearchservice.SnapshotPolicyStatus {
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
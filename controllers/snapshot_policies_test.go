@@ -0,0 +1,214 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestMakeSnapshotPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule opensearchservice.SnapshotSchedule
+		expected SnapshotPolicy
+	}{
+		{name: "default timezone and indices",
+			schedule: opensearchservice.SnapshotSchedule{Name: "daily", Cron: "0 0 * * *"},
+			expected: SnapshotPolicy{
+				Description: "Snapshot schedule [daily] maintained by OpenSearch service operator",
+				Creation: SnapshotPolicyCreation{
+					Schedule: SnapshotPolicySchedule{Cron: SnapshotPolicyCron{Expression: "0 0 * * *", Timezone: "UTC"}}},
+				SnapshotConfig: SnapshotPolicyConfig{Indices: "*", Repository: "snapshots", Timezone: "UTC"},
+				Enabled:        true,
+			}},
+		{name: "retention with creation schedule",
+			schedule: opensearchservice.SnapshotSchedule{Name: "hourly", Cron: "0 * * * *", Timezone: "Europe/Berlin",
+				IndexPatterns: []string{"logs-*", "metrics-*"}, IncludeGlobalState: true,
+				Retention: &opensearchservice.SnapshotRetention{MaxCount: 24, MinCount: 1, MaxAge: "1d"}},
+			expected: SnapshotPolicy{
+				Description: "Snapshot schedule [hourly] maintained by OpenSearch service operator",
+				Creation: SnapshotPolicyCreation{
+					Schedule: SnapshotPolicySchedule{Cron: SnapshotPolicyCron{Expression: "0 * * * *", Timezone: "Europe/Berlin"}}},
+				Deletion: &SnapshotPolicyDeletion{
+					Schedule:  SnapshotPolicySchedule{Cron: SnapshotPolicyCron{Expression: "0 * * * *", Timezone: "Europe/Berlin"}},
+					Condition: SnapshotPolicyCondition{MaxCount: 24, MinCount: 1, MaxAge: "1d"},
+				},
+				SnapshotConfig: SnapshotPolicyConfig{Indices: "logs-*,metrics-*", Repository: "snapshots",
+					IncludeGlobalState: true, Timezone: "Europe/Berlin"},
+				Enabled: true,
+			}},
+		{name: "retention with deletion schedule",
+			schedule: opensearchservice.SnapshotSchedule{Name: "daily", Cron: "0 0 * * *", DeletionCron: "0 12 * * *",
+				Retention: &opensearchservice.SnapshotRetention{MaxAge: "7d"}},
+			expected: SnapshotPolicy{
+				Description: "Snapshot schedule [daily] maintained by OpenSearch service operator",
+				Creation: SnapshotPolicyCreation{
+					Schedule: SnapshotPolicySchedule{Cron: SnapshotPolicyCron{Expression: "0 0 * * *", Timezone: "UTC"}}},
+				Deletion: &SnapshotPolicyDeletion{
+					Schedule:  SnapshotPolicySchedule{Cron: SnapshotPolicyCron{Expression: "0 12 * * *", Timezone: "UTC"}},
+					Condition: SnapshotPolicyCondition{MaxAge: "7d"},
+				},
+				SnapshotConfig: SnapshotPolicyConfig{Indices: "*", Repository: "snapshots", Timezone: "UTC"},
+				Enabled:        true,
+			}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, makeSnapshotPolicy(test.schedule, "snapshots"))
+		})
+	}
+}
+
+func TestReconcileSnapshotPolicies(t *testing.T) {
+	schedule := opensearchservice.SnapshotSchedule{Name: "daily", Cron: "0 0 * * *"}
+	policyBody, err := json.Marshal(makeSnapshotPolicy(schedule, opensearchservice.DefaultSnapshotRepositoryName))
+	assert.NoError(t, err)
+	specHash, err := util.Hash(apiextensionsv1.JSON{Raw: policyBody})
+	assert.NoError(t, err)
+	observedHash, err := util.Hash(json.RawMessage(policyBody))
+	assert.NoError(t, err)
+	existingPolicy := fmt.Sprintf(`{"_id":"daily-sm-policy","_seq_no":3,"_primary_term":1,"sm_policy":%s}`, policyBody)
+	policyPath := fmt.Sprintf(snapshotPolicyKind.pathPattern, "daily")
+	conditionalPolicyPath := policyPath + "?if_seq_no=3&if_primary_term=1"
+
+	tests := []struct {
+		name            string
+		schedules       []opensearchservice.SnapshotSchedule
+		existing        string
+		previous        []opensearchservice.SnapshotPolicyStatus
+		expectedMethod  string
+		expectedPath    string
+		expectedDrifted bool
+		expectedNames   []string
+	}{
+		{name: "new policy is created", schedules: []opensearchservice.SnapshotSchedule{schedule},
+			expectedMethod: http.MethodPost, expectedPath: policyPath, expectedNames: []string{"daily"}},
+		{name: "unchanged policy is not applied again", schedules: []opensearchservice.SnapshotSchedule{schedule},
+			existing: existingPolicy,
+			previous: []opensearchservice.SnapshotPolicyStatus{{ManagedResourceStatus: opensearchservice.ManagedResourceStatus{
+				Name: "daily", Status: managedResourceAppliedStatus, SpecHash: specHash, ObservedHash: observedHash}}},
+			expectedNames: []string{"daily"}},
+		{name: "changed schedule updates the policy with sequence number", schedules: []opensearchservice.SnapshotSchedule{schedule},
+			existing: existingPolicy,
+			previous: []opensearchservice.SnapshotPolicyStatus{{ManagedResourceStatus: opensearchservice.ManagedResourceStatus{
+				Name: "daily", Status: managedResourceAppliedStatus, SpecHash: "previous", ObservedHash: observedHash}}},
+			expectedMethod: http.MethodPut, expectedPath: conditionalPolicyPath, expectedNames: []string{"daily"}},
+		{name: "policy changed outside of the operator is reverted", schedules: []opensearchservice.SnapshotSchedule{schedule},
+			existing: existingPolicy,
+			previous: []opensearchservice.SnapshotPolicyStatus{{ManagedResourceStatus: opensearchservice.ManagedResourceStatus{
+				Name: "daily", Status: managedResourceAppliedStatus, SpecHash: specHash, ObservedHash: "changed"}}},
+			expectedMethod: http.MethodPut, expectedPath: conditionalPolicyPath, expectedDrifted: true,
+			expectedNames: []string{"daily"}},
+		{name: "policy of removed schedule is deleted",
+			previous: []opensearchservice.SnapshotPolicyStatus{{ManagedResourceStatus: opensearchservice.ManagedResourceStatus{
+				Name: "daily", Status: managedResourceAppliedStatus, SpecHash: specHash, ObservedHash: observedHash}}},
+			expectedMethod: http.MethodDelete, expectedPath: policyPath},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := newOpenSearchMock(t).
+				on(http.MethodPost, policyPath, http.StatusCreated, `{}`).
+				on(http.MethodPut, conditionalPolicyPath, http.StatusOK, `{}`).
+				on(http.MethodDelete, policyPath, http.StatusOK, `{}`)
+			if test.existing != "" {
+				mock.on(http.MethodGet, policyPath, http.StatusOK, test.existing)
+			}
+			cr := &opensearchservice.OpenSearchService{
+				ObjectMeta: metav1.ObjectMeta{Name: "opensearch", Namespace: "opensearch-service"},
+				Spec: opensearchservice.OpenSearchServiceSpec{OpenSearch: &opensearchservice.OpenSearch{
+					Snapshots: &opensearchservice.Snapshots{Schedules: test.schedules},
+				}},
+				Status: opensearchservice.OpenSearchServiceStatus{SnapshotPolicies: test.previous},
+			}
+			r := OpenSearchReconciler{
+				cr:         cr,
+				logger:     logr.Discard(),
+				reconciler: &OpenSearchServiceReconciler{Client: newFakeClient(cr)},
+			}
+
+			assert.NoError(t, r.reconcileSnapshotPolicies(mock.restClient()))
+
+			for _, request := range []struct{ method, path string }{
+				{http.MethodPost, policyPath}, {http.MethodPut, conditionalPolicyPath}, {http.MethodDelete, policyPath},
+			} {
+				received := mock.received(request.method, request.path)
+				if request.method == test.expectedMethod && request.path == test.expectedPath {
+					assert.Len(t, received, 1, request.method)
+					if request.method != http.MethodDelete {
+						assert.JSONEq(t, string(policyBody), received[0])
+					}
+				} else {
+					assert.Empty(t, received, request.method)
+				}
+			}
+			updated := &opensearchservice.OpenSearchService{}
+			assert.NoError(t, r.reconciler.Client.Get(context.TODO(), client.ObjectKeyFromObject(cr), updated))
+			var names []string
+			for _, policyStatus := range updated.Status.SnapshotPolicies {
+				names = append(names, policyStatus.Name)
+				assert.Equal(t, managedResourceAppliedStatus, policyStatus.Status)
+				assert.Equal(t, specHash, policyStatus.SpecHash)
+				assert.Equal(t, test.expectedDrifted, policyStatus.Drifted)
+			}
+			assert.Equal(t, test.expectedNames, names)
+		})
+	}
+}
+
+func TestUpdateSnapshotPolicyExecutions(t *testing.T) {
+	previousSuccess := metav1.NewTime(time.UnixMilli(1000))
+	statuses := []opensearchservice.SnapshotPolicyStatus{
+		{ManagedResourceStatus: opensearchservice.ManagedResourceStatus{Name: "succeeded"}},
+		{ManagedResourceStatus: opensearchservice.ManagedResourceStatus{Name: "failed"}, LastSuccessTime: &previousSuccess},
+		{ManagedResourceStatus: opensearchservice.ManagedResourceStatus{Name: "running"}},
+	}
+	var explanations []SnapshotPolicyExplanation
+	assert.NoError(t, json.Unmarshal([]byte(`[
+		{"name":"succeeded","creation":{"trigger":{"time":5000},
+			"latest_execution":{"status":"SUCCESS","start_time":2000,"end_time":3000}}},
+		{"name":"failed","creation":{"trigger":{"time":6000},
+			"latest_execution":{"status":"FAILED","start_time":4000,"info":{"message":"Snapshot is failed.","cause":"No space left"}}}},
+		{"name":"running","creation":{"trigger":{"time":7000},"latest_execution":{"status":"IN_PROGRESS","start_time":4000}}},
+		{"name":"unknown","creation":{"trigger":{"time":8000}}}
+	]`), &explanations))
+
+	updateSnapshotPolicyExecutions(statuses, explanations)
+
+	assert.Equal(t, int64(3000), statuses[0].LastSuccessTime.UnixMilli())
+	assert.Nil(t, statuses[0].LastFailureTime)
+	assert.Equal(t, int64(5000), statuses[0].NextRunTime.UnixMilli())
+
+	assert.Equal(t, int64(1000), statuses[1].LastSuccessTime.UnixMilli())
+	assert.Equal(t, int64(4000), statuses[1].LastFailureTime.UnixMilli())
+	assert.Equal(t, "Snapshot is failed. No space left", statuses[1].LastFailureMessage)
+	assert.Equal(t, int64(6000), statuses[1].NextRunTime.UnixMilli())
+
+	assert.Nil(t, statuses[2].LastSuccessTime)
+	assert.Nil(t, statuses[2].LastFailureTime)
+	assert.Equal(t, int64(7000), statuses[2].NextRunTime.UnixMilli())
+}
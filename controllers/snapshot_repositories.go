@@ -0,0 +1,201 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const snapshotRepositoryVerifyPathPattern = "_snapshot/%s/_verify"
+
+var snapshotRepositoryKind = managedResourceKind{
+	name:        "snapshot repository",
+	pathPattern: "_snapshot/%s",
+	extract: func(body []byte) (*managedResourceDefinition, error) {
+		var response map[string]json.RawMessage
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, err
+		}
+		for _, repository := range response {
+			return &managedResourceDefinition{Definition: repository}, nil
+		}
+		return nil, nil
+	},
+}
+
+// SnapshotRepositoryVerification is the result of snapshot repository verification
+type SnapshotRepositoryVerification struct {
+	Name  string
+	Nodes []string
+	Error string
+	Time  metav1.Time
+}
+
+// reconcileSnapshotRepositories registers the repository with RepositoryName and additional repositories from the spec,
+// recreates repositories missing in OpenSearch and removes repositories deleted from the spec
+func (r OpenSearchReconciler) reconcileSnapshotRepositories(restClient *util.RestClient) error {
+	repositories, err := r.makeSnapshotRepositories()
+	if err != nil {
+		return err
+	}
+	var previous []opensearchservice.ManagedResourceStatus
+	for _, repositoryStatus := range r.cr.Status.SnapshotRepositories {
+		previous = append(previous, repositoryStatus.ManagedResourceStatus)
+	}
+	resourceStatuses := applyManagedResources(restClient, r.logger, snapshotRepositoryKind, repositories, previous)
+	resourceStatuses = append(resourceStatuses,
+		deleteRemovedManagedResources(restClient, r.logger, snapshotRepositoryKind, repositories, previous)...)
+
+	var failed []string
+	statuses := make([]opensearchservice.SnapshotRepositoryStatus, 0, len(resourceStatuses))
+	for _, resourceStatus := range resourceStatuses {
+		repositoryStatus := opensearchservice.SnapshotRepositoryStatus{}
+		if previousStatus := findSnapshotRepositoryStatus(r.cr.Status.SnapshotRepositories, resourceStatus.Name); previousStatus != nil {
+			repositoryStatus = *previousStatus
+		}
+		repositoryStatus.ManagedResourceStatus = resourceStatus
+		statuses = append(statuses, repositoryStatus)
+		if resourceStatus.Status == managedResourceFailedStatus {
+			failed = append(failed, resourceStatus.Name)
+			r.reconciler.recordEvent(r.cr, corev1.EventTypeWarning, snapshotRepositoryFailedReason,
+				"Snapshot repository %s is not registered: %s", resourceStatus.Name, resourceStatus.Message)
+		}
+	}
+
+	statusUpdater := util.NewStatusUpdater(r.reconciler.Client, r.cr)
+	if err = statusUpdater.UpdateStatusWithRetry(func(instance *opensearchservice.OpenSearchService) {
+		instance.Status.SnapshotRepositories = statuses
+	}); err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("unable to register snapshot repositories %v, see status for details", failed)
+	}
+	return nil
+}
+
+// makeSnapshotRepositories returns bodies of snapshot repositories from the spec
+func (r OpenSearchReconciler) makeSnapshotRepositories() ([]opensearchservice.IndexManagementResource, error) {
+	snapshots := getSnapshots(r.cr)
+	if snapshots == nil {
+		return nil, nil
+	}
+	body, err := r.getSnapshotsRepositoryBody()
+	if err != nil {
+		return nil, err
+	}
+	repositories := []opensearchservice.IndexManagementResource{{
+		Name: getSnapshotRepositoryName(snapshots),
+		Body: apiextensionsv1.JSON{Raw: []byte(body)},
+	}}
+	for _, repository := range snapshots.Repositories {
+		settings := map[string]interface{}{}
+		for name, value := range repository.Settings {
+			var parsed interface{}
+			if err = json.Unmarshal(value.Raw, &parsed); err != nil {
+				return nil, fmt.Errorf("value of [%s] setting of [%s] snapshot repository is incorrect: %w",
+					name, repository.Name, err)
+			}
+			settings[name] = parsed
+		}
+		if repository.Readonly {
+			settings["readonly"] = true
+		}
+		data, err := json.Marshal(SnapshotRepository{Type: repository.Type, Settings: settings})
+		if err != nil {
+			return nil, err
+		}
+		repositories = append(repositories, opensearchservice.IndexManagementResource{
+			Name: repository.Name,
+			Body: apiextensionsv1.JSON{Raw: data},
+		})
+	}
+	return repositories, nil
+}
+
+// verifySnapshotRepositories checks that OpenSearch nodes have access to registered snapshot repositories
+func (r OpenSearchReconciler) verifySnapshotRepositories(restClient *util.RestClient,
+	statuses []opensearchservice.SnapshotRepositoryStatus) []SnapshotRepositoryVerification {
+	var verifications []SnapshotRepositoryVerification
+	for _, repositoryStatus := range statuses {
+		if repositoryStatus.Status != managedResourceAppliedStatus {
+			continue
+		}
+		verification := SnapshotRepositoryVerification{Name: repositoryStatus.Name, Time: metav1.Now()}
+		body, err := restClient.WithNonIdempotentRetries().SendRequestWithStatusCodeCheck(http.MethodPost,
+			fmt.Sprintf(snapshotRepositoryVerifyPathPattern, repositoryStatus.Name), nil)
+		if err != nil {
+			r.logger.Error(err, fmt.Sprintf("Verification of [%s] snapshot repository is failed", repositoryStatus.Name))
+			verification.Error = err.Error()
+			verifications = append(verifications, verification)
+			continue
+		}
+		var response struct {
+			Nodes map[string]struct {
+				Name string `json:"name"`
+			} `json:"nodes"`
+		}
+		if err = json.Unmarshal(body, &response); err != nil {
+			verification.Error = fmt.Sprintf("Unable to parse response of repository verification: %v", err)
+			verifications = append(verifications, verification)
+			continue
+		}
+		for _, node := range response.Nodes {
+			verification.Nodes = append(verification.Nodes, node.Name)
+		}
+		sort.Strings(verification.Nodes)
+		verifications = append(verifications, verification)
+	}
+	return verifications
+}
+
+// updateSnapshotRepositoryVerifications writes results of repository verifications to statuses,
+// the last verification error is kept until it is replaced by the next one
+func updateSnapshotRepositoryVerifications(statuses []opensearchservice.SnapshotRepositoryStatus,
+	verifications []SnapshotRepositoryVerification) {
+	for _, verification := range verifications {
+		repositoryStatus := findSnapshotRepositoryStatus(statuses, verification.Name)
+		if repositoryStatus == nil {
+			continue
+		}
+		verificationTime := verification.Time
+		repositoryStatus.LastVerificationTime = &verificationTime
+		repositoryStatus.Verified = verification.Error == ""
+		repositoryStatus.VerifiedNodes = verification.Nodes
+		if verification.Error != "" {
+			repositoryStatus.LastVerificationError = verification.Error
+			repositoryStatus.LastVerificationErrorTime = &verificationTime
+		}
+	}
+}
+
+func findSnapshotRepositoryStatus(statuses []opensearchservice.SnapshotRepositoryStatus,
+	name string) *opensearchservice.SnapshotRepositoryStatus {
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
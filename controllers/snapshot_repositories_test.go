@@ -0,0 +1,212 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultSnapshotRepositoryBody = `{"type":"fs","settings":{"location":"/usr/share/opensearch/snapshots","compress":true}}`
+
+func TestMakeSnapshotRepositories(t *testing.T) {
+	tests := []struct {
+		name          string
+		snapshots     *opensearchservice.Snapshots
+		expected      map[string]string
+		expectedError bool
+	}{
+		{name: "snapshots are not configured"},
+		{name: "default repository", snapshots: &opensearchservice.Snapshots{},
+			expected: map[string]string{opensearchservice.DefaultSnapshotRepositoryName: defaultSnapshotRepositoryBody}},
+		{name: "additional repositories", snapshots: &opensearchservice.Snapshots{RepositoryName: "local",
+			Repositories: []opensearchservice.SnapshotRepository{
+				{Name: "archive", Type: "s3", Readonly: true, Settings: map[string]apiextensionsv1.JSON{
+					"bucket": {Raw: []byte(`"archive"`)}, "max_restore_bytes_per_sec": {Raw: []byte(`"40mb"`)}}},
+				{Name: "azure", Type: "azure", Settings: map[string]apiextensionsv1.JSON{
+					"container": {Raw: []byte(`"backups"`)}, "compress": {Raw: []byte(`true`)}}},
+			}},
+			expected: map[string]string{
+				"local":   defaultSnapshotRepositoryBody,
+				"archive": `{"type":"s3","settings":{"bucket":"archive","max_restore_bytes_per_sec":"40mb","readonly":true}}`,
+				"azure":   `{"type":"azure","settings":{"container":"backups","compress":true}}`,
+			}},
+		{name: "incorrect setting value", snapshots: &opensearchservice.Snapshots{
+			Repositories: []opensearchservice.SnapshotRepository{
+				{Name: "archive", Type: "s3", Settings: map[string]apiextensionsv1.JSON{"bucket": {Raw: []byte(`archive`)}}},
+			}},
+			expectedError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cr := &opensearchservice.OpenSearchService{Spec: opensearchservice.OpenSearchServiceSpec{
+				OpenSearch: &opensearchservice.OpenSearch{Snapshots: test.snapshots},
+			}}
+			r := OpenSearchReconciler{cr: cr, logger: logr.Discard()}
+
+			repositories, err := r.makeSnapshotRepositories()
+			if test.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, repositories, len(test.expected))
+			for _, repository := range repositories {
+				assert.JSONEq(t, test.expected[repository.Name], string(repository.Body.Raw), repository.Name)
+			}
+		})
+	}
+}
+
+func TestReconcileSnapshotRepositories(t *testing.T) {
+	repositoryName := opensearchservice.DefaultSnapshotRepositoryName
+	repositoryPath := "_snapshot/" + repositoryName
+	existingRepository := `{"` + repositoryName + `":` + defaultSnapshotRepositoryBody + `}`
+	verificationTime := metav1.Now()
+
+	tests := []struct {
+		name           string
+		snapshots      *opensearchservice.Snapshots
+		existing       string
+		putStatusCode  int
+		previous       []opensearchservice.SnapshotRepositoryStatus
+		expectedPut    bool
+		expectedDelete []string
+		expectedError  bool
+		expectedStatus string
+	}{
+		{name: "repository is registered", snapshots: &opensearchservice.Snapshots{},
+			putStatusCode: http.StatusOK, expectedPut: true, expectedStatus: managedResourceAppliedStatus},
+		{name: "missing repository is recreated", snapshots: &opensearchservice.Snapshots{},
+			putStatusCode: http.StatusOK,
+			previous: []opensearchservice.SnapshotRepositoryStatus{{
+				ManagedResourceStatus: opensearchservice.ManagedResourceStatus{Name: repositoryName,
+					Status: managedResourceAppliedStatus},
+				Verified: true, LastVerificationTime: &verificationTime}},
+			expectedPut: true, expectedStatus: managedResourceAppliedStatus},
+		{name: "rejected repository is failed", snapshots: &opensearchservice.Snapshots{},
+			putStatusCode: http.StatusBadRequest, expectedPut: true, expectedError: true,
+			expectedStatus: managedResourceFailedStatus},
+		{name: "removed repository is deleted", snapshots: &opensearchservice.Snapshots{}, existing: existingRepository,
+			putStatusCode: http.StatusOK,
+			previous: []opensearchservice.SnapshotRepositoryStatus{{
+				ManagedResourceStatus: opensearchservice.ManagedResourceStatus{Name: "archive",
+					Status: managedResourceAppliedStatus}}},
+			expectedPut: true, expectedDelete: []string{"archive"}, expectedStatus: managedResourceAppliedStatus},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := newOpenSearchMock(t).
+				on(http.MethodPut, repositoryPath, test.putStatusCode, `{"acknowledged":true}`).
+				on(http.MethodDelete, "_snapshot/archive", http.StatusOK, `{"acknowledged":true}`)
+			if test.existing != "" {
+				mock.on(http.MethodGet, repositoryPath, http.StatusOK, test.existing)
+			}
+			cr := &opensearchservice.OpenSearchService{
+				ObjectMeta: metav1.ObjectMeta{Name: "opensearch", Namespace: "opensearch-service"},
+				Spec: opensearchservice.OpenSearchServiceSpec{
+					OpenSearch: &opensearchservice.OpenSearch{Snapshots: test.snapshots},
+				},
+				Status: opensearchservice.OpenSearchServiceStatus{SnapshotRepositories: test.previous},
+			}
+			r := OpenSearchReconciler{
+				cr:         cr,
+				logger:     logr.Discard(),
+				reconciler: &OpenSearchServiceReconciler{Client: newFakeClient(cr)},
+			}
+
+			err := r.reconcileSnapshotRepositories(mock.restClient())
+			assert.Equal(t, test.expectedError, err != nil, err)
+
+			received := mock.received(http.MethodPut, repositoryPath)
+			if test.expectedPut && assert.Len(t, received, 1) {
+				assert.JSONEq(t, defaultSnapshotRepositoryBody, received[0])
+			}
+			assert.Len(t, mock.received(http.MethodDelete, "_snapshot/archive"), len(test.expectedDelete))
+
+			updated := &opensearchservice.OpenSearchService{}
+			assert.NoError(t, r.reconciler.Client.Get(context.TODO(), client.ObjectKeyFromObject(cr), updated))
+			statuses := updated.Status.SnapshotRepositories
+			if assert.Len(t, statuses, 1) {
+				assert.Equal(t, repositoryName, statuses[0].Name)
+				assert.Equal(t, test.expectedStatus, statuses[0].Status)
+				if len(test.previous) > 0 && test.previous[0].Name == repositoryName {
+					assert.True(t, statuses[0].Verified, "verification result is lost")
+					assert.NotNil(t, statuses[0].LastVerificationTime)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifySnapshotRepositories(t *testing.T) {
+	mock := newOpenSearchMock(t).
+		on(http.MethodPost, "_snapshot/local/_verify", http.StatusOK,
+			`{"nodes":{"b1":{"name":"opensearch-1"},"a0":{"name":"opensearch-0"}}}`).
+		on(http.MethodPost, "_snapshot/archive/_verify", http.StatusInternalServerError,
+			`{"error":{"type":"repository_verification_exception"}}`)
+	statuses := []opensearchservice.SnapshotRepositoryStatus{
+		{ManagedResourceStatus: opensearchservice.ManagedResourceStatus{Name: "local", Status: managedResourceAppliedStatus}},
+		{ManagedResourceStatus: opensearchservice.ManagedResourceStatus{Name: "archive", Status: managedResourceAppliedStatus}},
+		{ManagedResourceStatus: opensearchservice.ManagedResourceStatus{Name: "failed", Status: managedResourceFailedStatus}},
+	}
+	r := OpenSearchReconciler{logger: logr.Discard()}
+
+	verifications := r.verifySnapshotRepositories(mock.restClient(), statuses)
+
+	if assert.Len(t, verifications, 2) {
+		assert.Equal(t, "local", verifications[0].Name)
+		assert.Equal(t, []string{"opensearch-0", "opensearch-1"}, verifications[0].Nodes)
+		assert.Empty(t, verifications[0].Error)
+		assert.Equal(t, "archive", verifications[1].Name)
+		assert.Contains(t, verifications[1].Error, "repository_verification_exception")
+	}
+	assert.Empty(t, mock.received(http.MethodPost, "_snapshot/failed/_verify"))
+}
+
+func TestUpdateSnapshotRepositoryVerifications(t *testing.T) {
+	previousErrorTime := metav1.NewTime(metav1.Now().Add(-time.Hour))
+	statuses := []opensearchservice.SnapshotRepositoryStatus{
+		{ManagedResourceStatus: opensearchservice.ManagedResourceStatus{Name: "local"},
+			LastVerificationError: "Access denied", LastVerificationErrorTime: &previousErrorTime},
+		{ManagedResourceStatus: opensearchservice.ManagedResourceStatus{Name: "archive"}, Verified: true},
+	}
+	verificationTime := metav1.Now()
+
+	updateSnapshotRepositoryVerifications(statuses, []SnapshotRepositoryVerification{
+		{Name: "local", Nodes: []string{"opensearch-0"}, Time: verificationTime},
+		{Name: "archive", Error: "Repository is missing", Time: verificationTime},
+		{Name: "unknown", Time: verificationTime},
+	})
+
+	assert.True(t, statuses[0].Verified)
+	assert.Equal(t, []string{"opensearch-0"}, statuses[0].VerifiedNodes)
+	assert.Equal(t, verificationTime, *statuses[0].LastVerificationTime)
+	assert.Equal(t, "Access denied", statuses[0].LastVerificationError)
+	assert.Equal(t, previousErrorTime, *statuses[0].LastVerificationErrorTime)
+
+	assert.False(t, statuses[1].Verified)
+	assert.Equal(t, "Repository is missing", statuses[1].LastVerificationError)
+	assert.Equal(t, verificationTime, *statuses[1].LastVerificationErrorTime)
+}
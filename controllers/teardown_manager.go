@@ -0,0 +1,279 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	opensearchServiceFinalizer = "qubership.org/teardown"
+	teardownDoneStatus         = "done"
+	teardownFailedStatus       = "failed"
+	teardownSkippedStatus      = "skipped"
+	// teardownTimeout is the time since deletion after which failed steps do not block the release of OpenSearchService
+	teardownTimeout = 10 * time.Minute
+)
+
+var defaultTeardownSteps = []opensearchservice.TeardownStep{
+	opensearchservice.TeardownWatchersStep,
+	opensearchservice.TeardownSlowLogSettingsStep,
+	opensearchservice.TeardownReplicationStep,
+	opensearchservice.TeardownSnapshotRepositoryStep,
+}
+
+// TeardownManager removes state the operator created in OpenSearch for deleted OpenSearchService
+type TeardownManager struct {
+	cr         *opensearchservice.OpenSearchService
+	logger     logr.Logger
+	reconciler *OpenSearchServiceReconciler
+	state      *InstanceState
+}
+
+func NewTeardownManager(r *OpenSearchServiceReconciler, cr *opensearchservice.OpenSearchService,
+	logger logr.Logger) TeardownManager {
+	return TeardownManager{
+		cr:         cr,
+		logger:     logger,
+		reconciler: r,
+		state:      r.getState(cr),
+	}
+}
+
+// addFinalizer adds teardown finalizer to OpenSearchService if it is not present
+func (r *OpenSearchServiceReconciler) addFinalizer(cr *opensearchservice.OpenSearchService) error {
+	if controllerutil.ContainsFinalizer(cr, opensearchServiceFinalizer) {
+		return nil
+	}
+	patch := client.MergeFrom(cr.DeepCopy())
+	controllerutil.AddFinalizer(cr, opensearchServiceFinalizer)
+	return r.Client.Patch(context.TODO(), cr, patch)
+}
+
+// finalize performs teardown of deleted OpenSearchService and releases it by removing the finalizer
+func (r *OpenSearchServiceReconciler) finalize(cr *opensearchservice.OpenSearchService, logger logr.Logger) error {
+	if !controllerutil.ContainsFinalizer(cr, opensearchServiceFinalizer) {
+		return nil
+	}
+	logger.Info("OpenSearch service is being deleted, start teardown")
+	if err := NewTeardownManager(r, cr, logger).Run(); err != nil {
+		return err
+	}
+	patch := client.MergeFrom(cr.DeepCopy())
+	controllerutil.RemoveFinalizer(cr, opensearchServiceFinalizer)
+	if err := r.Client.Patch(context.TODO(), cr, patch); err != nil {
+		return err
+	}
+	r.Instances.remove(types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}, logger)
+	deleteMetrics(cr.Namespace, cr.Name)
+	logger.Info("Teardown is finished, OpenSearch service is released")
+	return nil
+}
+
+// Run performs configured teardown steps in order. Steps which are already done are not repeated.
+// Errors are ignored if teardown is not configured explicitly or if teardownTimeout since deletion is exceeded.
+func (tm TeardownManager) Run() error {
+	steps := defaultTeardownSteps
+	ignoreErrors := true
+	if tm.cr.Spec.Teardown != nil {
+		if len(tm.cr.Spec.Teardown.Steps) > 0 {
+			steps = tm.cr.Spec.Teardown.Steps
+		}
+		ignoreErrors = tm.cr.Spec.Teardown.IgnoreErrors
+	}
+	timeoutExceeded := tm.cr.DeletionTimestamp != nil && time.Since(tm.cr.DeletionTimestamp.Time) > teardownTimeout
+	openSearchExists, err := tm.openSearchExists()
+	if err != nil {
+		return err
+	}
+	for _, step := range steps {
+		if tm.isStepFinished(step) {
+			continue
+		}
+		if !openSearchExists && step != opensearchservice.TeardownWatchersStep {
+			tm.logger.Info(fmt.Sprintf("OpenSearch stateful sets do not exist, [%s] teardown step is skipped", step))
+			if err = tm.updateStepStatus(step, teardownSkippedStatus, "OpenSearch does not exist"); err != nil {
+				return err
+			}
+			continue
+		}
+		tm.logger.Info(fmt.Sprintf("Performing [%s] teardown step", step))
+		performed, err := tm.runStep(step)
+		var requeueError RequeueError
+		if goerrors.As(err, &requeueError) && !timeoutExceeded {
+			return err
+		}
+		status := teardownDoneStatus
+		message := ""
+		if err != nil {
+			status = teardownFailedStatus
+			message = err.Error()
+		} else if !performed {
+			status = teardownSkippedStatus
+			message = "Nothing to clean up"
+		}
+		if updateErr := tm.updateStepStatus(step, status, message); updateErr != nil {
+			return updateErr
+		}
+		if err != nil {
+			if !ignoreErrors && !timeoutExceeded {
+				return fmt.Errorf("teardown step [%s] is failed: %w", step, err)
+			}
+			tm.logger.Error(err, fmt.Sprintf("Teardown step [%s] is failed, the error is ignored", step))
+		}
+	}
+	return nil
+}
+
+// openSearchExists checks if any stateful set of managed OpenSearch is present, external OpenSearch is always considered present
+func (tm TeardownManager) openSearchExists() (bool, error) {
+	if tm.cr.Spec.OpenSearch == nil || tm.cr.Spec.OpenSearch.StatefulSetNames == "" {
+		return true, nil
+	}
+	for _, name := range strings.Split(tm.cr.Spec.OpenSearch.StatefulSetNames, ",") {
+		_, err := tm.reconciler.findStatefulSet(strings.TrimSpace(name), tm.cr.Namespace, tm.logger)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.IsNotFound(err) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// runStep performs teardown step and returns false if there is nothing to clean up for it
+func (tm TeardownManager) runStep(step opensearchservice.TeardownStep) (bool, error) {
+	switch step {
+	case opensearchservice.TeardownWatchersStep:
+		return tm.stopWatchers()
+	case opensearchservice.TeardownSlowLogSettingsStep:
+		return tm.removeSlowLogSettings()
+	case opensearchservice.TeardownReplicationStep:
+		return tm.removeReplication()
+	case opensearchservice.TeardownSnapshotRepositoryStep:
+		return tm.removeSnapshotRepository()
+	}
+	return false, fmt.Errorf("unknown teardown step [%s]", step)
+}
+
+func (tm TeardownManager) stopWatchers() (bool, error) {
+	tm.state.ReplicationWatcher.pause(tm.logger)
+	tm.state.SlowLogIndicesWatcher.halt()
+	return true, nil
+}
+
+func (tm TeardownManager) removeSlowLogSettings() (bool, error) {
+	if tm.cr.Spec.Monitoring == nil || tm.cr.Spec.Monitoring.SlowQueries == nil {
+		return false, nil
+	}
+	tm.state.SlowLogIndicesWatcher.halt()
+	restClient, err := tm.reconciler.createOpenSearchRestClient(tm.cr, tm.logger)
+	if err != nil {
+		return true, err
+	}
+	helper := SlowLogIndicesHelper{
+		logger:     tm.logger,
+		restClient: restClient,
+	}
+	return true, tm.state.SlowLogIndicesWatcher.removeSlowLogSetting(helper)
+}
+
+func (tm TeardownManager) removeReplication() (bool, error) {
+	if tm.cr.Spec.DisasterRecovery == nil {
+		return false, nil
+	}
+	replicationManager := NewDisasterRecoveryReconciler(tm.reconciler, tm.cr, tm.logger).getReplicationManager()
+	if err := replicationManager.RemoveReplicationRule(); err != nil {
+		return true, err
+	}
+	if err := replicationManager.StopReplication(); err != nil {
+		return true, err
+	}
+	return true, replicationManager.RemoveRemoteCluster()
+}
+
+func (tm TeardownManager) removeSnapshotRepository() (bool, error) {
+	snapshots := getSnapshots(tm.cr)
+	repositoryNames := map[string]bool{}
+	if snapshots != nil {
+		repositoryNames[getSnapshotRepositoryName(snapshots)] = true
+	}
+	for _, repositoryStatus := range tm.cr.Status.SnapshotRepositories {
+		repositoryNames[repositoryStatus.Name] = true
+	}
+	if len(repositoryNames) == 0 {
+		return false, nil
+	}
+	restClient, err := tm.reconciler.createOpenSearchRestClient(tm.cr, tm.logger)
+	if err != nil {
+		return true, err
+	}
+	for repositoryName := range repositoryNames {
+		statusCode, body, err := restClient.SendRequest(http.MethodDelete,
+			fmt.Sprintf("_snapshot/%s", repositoryName), nil)
+		if err != nil {
+			return true, err
+		}
+		if statusCode >= 400 && statusCode != http.StatusNotFound {
+			return true, fmt.Errorf("unable to remove snapshot repository [%s], status code - [%d], response - [%s]",
+				repositoryName, statusCode, string(body))
+		}
+	}
+	return true, nil
+}
+
+func (tm TeardownManager) isStepFinished(step opensearchservice.TeardownStep) bool {
+	for _, stepStatus := range tm.cr.Status.TeardownStatus.Steps {
+		if stepStatus.Name == step {
+			return stepStatus.Status == teardownDoneStatus || stepStatus.Status == teardownSkippedStatus
+		}
+	}
+	return false
+}
+
+func (tm TeardownManager) updateStepStatus(step opensearchservice.TeardownStep, status string, message string) error {
+	stepStatus := opensearchservice.TeardownStepStatus{
+		Name:               step,
+		Status:             status,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+	setStepStatus := func(instance *opensearchservice.OpenSearchService) {
+		for i, current := range instance.Status.TeardownStatus.Steps {
+			if current.Name == step {
+				instance.Status.TeardownStatus.Steps[i] = stepStatus
+				return
+			}
+		}
+		instance.Status.TeardownStatus.Steps = append(instance.Status.TeardownStatus.Steps, stepStatus)
+	}
+	setStepStatus(tm.cr)
+	return util.NewStatusUpdater(tm.reconciler.Client, tm.cr).UpdateStatusWithRetry(setStepStatus)
+}
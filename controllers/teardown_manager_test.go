@@ -0,0 +1,130 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const unknownTeardownStep opensearchservice.TeardownStep = "unknown"
+
+func newDeletedOpenSearchService(deletedAgo time.Duration, opensearch *opensearchservice.OpenSearch,
+	teardown *opensearchservice.Teardown) *opensearchservice.OpenSearchService {
+	deletionTimestamp := metav1.NewTime(time.Now().Add(-deletedAgo))
+	return &opensearchservice.OpenSearchService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "opensearch",
+			Namespace:         "opensearch-service",
+			Finalizers:        []string{opensearchServiceFinalizer},
+			DeletionTimestamp: &deletionTimestamp,
+		},
+		Spec: opensearchservice.OpenSearchServiceSpec{OpenSearch: opensearch, Teardown: teardown},
+	}
+}
+
+func TestTeardownManagerRun(t *testing.T) {
+	nothingToCleanUp := func(step opensearchservice.TeardownStep) opensearchservice.TeardownStepStatus {
+		return opensearchservice.TeardownStepStatus{Name: step, Status: teardownSkippedStatus, Message: "Nothing to clean up"}
+	}
+	tests := []struct {
+		name          string
+		opensearch    *opensearchservice.OpenSearch
+		teardown      *opensearchservice.Teardown
+		deletedAgo    time.Duration
+		finished      []opensearchservice.TeardownStepStatus
+		expectedError bool
+		expected      []opensearchservice.TeardownStepStatus
+	}{
+		{name: "default steps without configured features", opensearch: &opensearchservice.OpenSearch{},
+			expected: []opensearchservice.TeardownStepStatus{
+				{Name: opensearchservice.TeardownWatchersStep, Status: teardownDoneStatus},
+				nothingToCleanUp(opensearchservice.TeardownSlowLogSettingsStep),
+				nothingToCleanUp(opensearchservice.TeardownReplicationStep),
+				nothingToCleanUp(opensearchservice.TeardownSnapshotRepositoryStep),
+			}},
+		{name: "steps are skipped when OpenSearch does not exist",
+			opensearch: &opensearchservice.OpenSearch{StatefulSetNames: "opensearch"},
+			teardown: &opensearchservice.Teardown{Steps: []opensearchservice.TeardownStep{
+				opensearchservice.TeardownWatchersStep, opensearchservice.TeardownSnapshotRepositoryStep}},
+			expected: []opensearchservice.TeardownStepStatus{
+				{Name: opensearchservice.TeardownWatchersStep, Status: teardownDoneStatus},
+				{Name: opensearchservice.TeardownSnapshotRepositoryStep, Status: teardownSkippedStatus,
+					Message: "OpenSearch does not exist"},
+			}},
+		{name: "finished steps are not repeated", opensearch: &opensearchservice.OpenSearch{},
+			teardown: &opensearchservice.Teardown{Steps: []opensearchservice.TeardownStep{unknownTeardownStep}},
+			finished: []opensearchservice.TeardownStepStatus{{Name: unknownTeardownStep, Status: teardownDoneStatus}},
+			expected: []opensearchservice.TeardownStepStatus{{Name: unknownTeardownStep, Status: teardownDoneStatus}}},
+		{name: "failed step blocks release", opensearch: &opensearchservice.OpenSearch{},
+			teardown:      &opensearchservice.Teardown{Steps: []opensearchservice.TeardownStep{unknownTeardownStep}},
+			expectedError: true,
+			expected: []opensearchservice.TeardownStepStatus{{Name: unknownTeardownStep, Status: teardownFailedStatus,
+				Message: "unknown teardown step [unknown]"}}},
+		{name: "error of failed step is ignored", opensearch: &opensearchservice.OpenSearch{},
+			teardown: &opensearchservice.Teardown{Steps: []opensearchservice.TeardownStep{unknownTeardownStep},
+				IgnoreErrors: true},
+			expected: []opensearchservice.TeardownStepStatus{{Name: unknownTeardownStep, Status: teardownFailedStatus,
+				Message: "unknown teardown step [unknown]"}}},
+		{name: "error of failed step is ignored after teardown timeout", opensearch: &opensearchservice.OpenSearch{},
+			teardown:   &opensearchservice.Teardown{Steps: []opensearchservice.TeardownStep{unknownTeardownStep}},
+			deletedAgo: teardownTimeout + time.Minute,
+			expected: []opensearchservice.TeardownStepStatus{{Name: unknownTeardownStep, Status: teardownFailedStatus,
+				Message: "unknown teardown step [unknown]"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cr := newDeletedOpenSearchService(test.deletedAgo, test.opensearch, test.teardown)
+			cr.Status.TeardownStatus.Steps = test.finished
+			reconciler := &OpenSearchServiceReconciler{Client: newFakeClient(cr), Instances: newTestInstanceStates()}
+
+			err := NewTeardownManager(reconciler, cr, logr.Discard()).Run()
+			assert.Equal(t, test.expectedError, err != nil, err)
+
+			updated := &opensearchservice.OpenSearchService{}
+			assert.NoError(t, reconciler.Client.Get(context.TODO(), client.ObjectKeyFromObject(cr), updated))
+			steps := updated.Status.TeardownStatus.Steps
+			for i := range steps {
+				steps[i].LastTransitionTime = metav1.Time{}
+			}
+			assert.Equal(t, test.expected, steps)
+		})
+	}
+}
+
+func TestFinalize(t *testing.T) {
+	cr := newDeletedOpenSearchService(0, &opensearchservice.OpenSearch{}, nil)
+	reconciler := &OpenSearchServiceReconciler{Client: newFakeClient(cr), Instances: newTestInstanceStates()}
+	name := types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}
+	state := reconciler.getState(cr)
+
+	assert.NoError(t, reconciler.finalize(cr, logr.Discard()))
+
+	// The custom resource is removed by Kubernetes as soon as it is released
+	err := reconciler.Client.Get(context.TODO(), name, &opensearchservice.OpenSearchService{})
+	assert.True(t, errors.IsNotFound(err), err)
+	assert.NotContains(t, reconciler.Instances.states, name)
+	assert.False(t, state.ReplicationWatcher.isRunning())
+	assert.False(t, state.SlowLogIndicesWatcher.isRunning())
+}
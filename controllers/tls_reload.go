@@ -0,0 +1,341 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	sslCertsPath                   = "_plugins/_security/api/ssl/certs"
+	sslReloadCertsPathPattern      = "_plugins/_security/api/ssl/%s/reloadcerts"
+	transportLayer                 = "transport"
+	httpLayer                      = "http"
+	transportCertsReloadReason     = "TransportCertificatesReload"
+	httpCertsReloadReason          = "HTTPCertificatesReload"
+	certificateSerialAnnotationKey = "qubership.org/%s-certificate-serial"
+	tlsReloadInterval              = 20 * time.Second
+	// tlsReloadTimeout covers the delay of kubelet in updating files of the renewed secret in pods,
+	// the reload is repeated in reconciliation cycles until it is verified or the timeout is expired
+	tlsReloadTimeout = 3 * time.Minute
+)
+
+// CertificatesInfo contains certificates loaded on the node from OpenSearch security SSL certs API
+type CertificatesInfo struct {
+	HTTPCertificates      []CertificateInfo `json:"http_certificates_list"`
+	TransportCertificates []CertificateInfo `json:"transport_certificates_list"`
+}
+
+type CertificateInfo struct {
+	SubjectDN    string `json:"subject_dn"`
+	SerialNumber string `json:"serial_number"`
+	NotAfter     string `json:"not_after"`
+}
+
+// reconcileTLSReload checks that certificates from TLS secrets are loaded on all OpenSearch nodes. Outdated
+// certificates are reloaded and verified by serial numbers, nodes are restarted only if the reload fails.
+func (r OpenSearchReconciler) reconcileTLSReload(restClient *util.RestClient) error {
+	tlsReload := r.cr.Spec.OpenSearch.TLSReload
+	if tlsReload.TransportSecretName != "" {
+		if err := r.reloadCertificates(restClient, transportLayer, tlsReload.TransportSecretName, transportCertsReloadReason); err != nil {
+			return err
+		}
+	}
+	if tlsReload.HTTPSecretName != "" {
+		return r.reloadCertificates(restClient, httpLayer, tlsReload.HTTPSecretName, httpCertsReloadReason)
+	}
+	return nil
+}
+
+func (r OpenSearchReconciler) reloadCertificates(restClient *util.RestClient, layer string, secretName string, reason string) error {
+	serial, err := r.getSecretCertificateSerial(secretName)
+	if err != nil {
+		return r.updateTLSReloadCondition(typeFailed, reason, fmt.Sprintf("Unable to read %s certificate: %v", layer, err))
+	}
+	nodeClients, err := r.getNodeClients(restClient)
+	if err != nil {
+		return err
+	}
+	outdated, err := getOutdatedNodes(nodeClients, layer, serial)
+	if err != nil {
+		return err
+	}
+	if len(outdated) == 0 {
+		if err = r.updateTLSReloadStatus(layer, nil); err != nil {
+			return err
+		}
+		return r.updateTLSReloadCondition(typeSuccessful, reason,
+			fmt.Sprintf("%s certificate with %s serial number is loaded on all nodes", layer, serial.Text(16)))
+	}
+	restartRequested, err := r.isCertificateRestartRequested(layer, serial)
+	if err != nil {
+		return err
+	}
+	if restartRequested {
+		if err = r.updateTLSReloadStatus(layer, nil); err != nil {
+			return err
+		}
+		return r.updateTLSReloadCondition(typeInProgress, reason,
+			fmt.Sprintf("Nodes %v are being restarted to load %s certificate", outdated, layer))
+	}
+
+	reloadStatus := findTLSReloadStatus(r.cr, layer)
+	if reloadStatus == nil || reloadStatus.SerialNumber != serial.Text(16) {
+		r.logger.Info(fmt.Sprintf("Nodes %v have outdated %s certificate, try to reload it", outdated, layer))
+		now := metav1.Now()
+		reloadStatus = &opensearchservice.TLSReloadStatus{Layer: layer, SerialNumber: serial.Text(16), StartTime: &now}
+	} else if reloadStatus.StartTime != nil && time.Since(reloadStatus.StartTime.Time) > tlsReloadTimeout {
+		return r.restartNodesWithCertificate(layer, serial, reason,
+			fmt.Sprintf("Reload of %s certificate is not verified on nodes %v", layer, outdated))
+	}
+	for _, node := range outdated {
+		path := fmt.Sprintf(sslReloadCertsPathPattern, layer)
+		if _, err = nodeClients[node].SendRequestWithStatusCodeCheck(http.MethodPut, path, nil); err != nil {
+			r.logger.Error(err, fmt.Sprintf("Unable to reload %s certificate on %s node", layer, node))
+			return r.restartNodesWithCertificate(layer, serial, reason,
+				fmt.Sprintf("Reload of %s certificate is failed: %v", layer, err))
+		}
+	}
+	remaining := make(map[string]*util.RestClient, len(outdated))
+	for _, node := range outdated {
+		remaining[node] = nodeClients[node]
+	}
+	if outdated, err = getOutdatedNodes(remaining, layer, serial); err != nil {
+		return r.restartNodesWithCertificate(layer, serial, reason,
+			fmt.Sprintf("Reload of %s certificate is failed: %v", layer, err))
+	}
+	if len(outdated) == 0 {
+		r.logger.Info(fmt.Sprintf("%s certificate is reloaded on all nodes", layer))
+		if err = r.updateTLSReloadStatus(layer, nil); err != nil {
+			return err
+		}
+		return r.updateTLSReloadCondition(typeSuccessful, reason,
+			fmt.Sprintf("%s certificate with %s serial number is reloaded on all nodes", layer, serial.Text(16)))
+	}
+	// Kubelet may not have updated files of the renewed secret in pods yet, so the reload is repeated
+	reloadStatus.Nodes = outdated
+	if err = r.updateTLSReloadStatus(layer, reloadStatus); err != nil {
+		return err
+	}
+	if err = r.updateTLSReloadCondition(typeInProgress, reason,
+		fmt.Sprintf("Reload of %s certificate is not verified on nodes %v yet", layer, outdated)); err != nil {
+		return err
+	}
+	return RequeueError{After: tlsReloadInterval,
+		Message: fmt.Sprintf("waiting for %s certificate to be reloaded on nodes %v", layer, outdated)}
+}
+
+// restartNodesWithCertificate requests rolling restart of nodes when the certificate can not be reloaded
+func (r OpenSearchReconciler) restartNodesWithCertificate(layer string, serial *big.Int, reason string, message string) error {
+	r.logger.Info(fmt.Sprintf("%s, so rolling restart is requested", message))
+	if err := r.updateTLSReloadStatus(layer, nil); err != nil {
+		return err
+	}
+	if err := r.requestCertificateRestart(layer, serial); err != nil {
+		return r.updateTLSReloadCondition(typeFailed, reason, fmt.Sprintf("%s, rolling restart is failed: %v", message, err))
+	}
+	return r.updateTLSReloadCondition(typeInProgress, reason, fmt.Sprintf("%s, rolling restart is requested", message))
+}
+
+// updateTLSReloadStatus replaces the reload status of the layer, the status is removed if it is nil
+func (r OpenSearchReconciler) updateTLSReloadStatus(layer string, reloadStatus *opensearchservice.TLSReloadStatus) error {
+	change := func(instance *opensearchservice.OpenSearchService) {
+		var statuses []opensearchservice.TLSReloadStatus
+		for _, status := range instance.Status.TLSReloadStatuses {
+			if status.Layer != layer {
+				statuses = append(statuses, status)
+			}
+		}
+		if reloadStatus != nil {
+			statuses = append(statuses, *reloadStatus)
+		}
+		instance.Status.TLSReloadStatuses = statuses
+	}
+	if reloadStatus == nil && findTLSReloadStatus(r.cr, layer) == nil {
+		return nil
+	}
+	change(r.cr)
+	return util.NewStatusUpdater(r.reconciler.Client, r.cr).UpdateStatusWithRetry(change)
+}
+
+func findTLSReloadStatus(cr *opensearchservice.OpenSearchService, layer string) *opensearchservice.TLSReloadStatus {
+	for index := range cr.Status.TLSReloadStatuses {
+		if cr.Status.TLSReloadStatuses[index].Layer == layer {
+			return &cr.Status.TLSReloadStatuses[index]
+		}
+	}
+	return nil
+}
+
+// getSecretCertificateSerial returns serial number of the certificate from tls.crt key of the secret
+func (r OpenSearchReconciler) getSecretCertificateSerial(secretName string) (*big.Int, error) {
+	secret, err := r.reconciler.findSecret(secretName, r.cr.Namespace, r.logger)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		return nil, fmt.Errorf("%s secret does not contain certificate in %s key", secretName, corev1.TLSCertKey)
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return certificate.SerialNumber, nil
+}
+
+// getNodeClients returns clients which send requests to OpenSearch nodes directly by pod IP addresses,
+// certificates of nodes are verified against the name of internal service
+func (r OpenSearchReconciler) getNodeClients(restClient *util.RestClient) (map[string]*util.RestClient, error) {
+	nodes, err := r.getNodeRoles(restClient)
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := configureClientWithServerName(fmt.Sprintf("%s-internal", r.cr.Name))
+	if err != nil {
+		return nil, err
+	}
+	protocol := strings.Split(createUrl(r.cr.Name, opensearchHttpPort), "://")[0]
+	clients := make(map[string]*util.RestClient, len(nodes))
+	for name := range nodes {
+		pod, err := r.reconciler.findPod(name, r.cr.Namespace, r.logger)
+		if err != nil {
+			return nil, err
+		}
+		if pod.Status.PodIP == "" {
+			return nil, fmt.Errorf("%s pod has no IP address", name)
+		}
+		clients[name] = restClient.WithURL(fmt.Sprintf("%s://%s:%d", protocol, pod.Status.PodIP, opensearchHttpPort), httpClient)
+	}
+	return clients, nil
+}
+
+// getOutdatedNodes returns names of nodes with certificate of the layer which serial number differs from the expected one
+func getOutdatedNodes(nodeClients map[string]*util.RestClient, layer string, serial *big.Int) ([]string, error) {
+	var outdated []string
+	for name, client := range nodeClients {
+		responseBody, err := client.SendRequestWithStatusCodeCheck(http.MethodGet, sslCertsPath, nil)
+		if err != nil {
+			return nil, err
+		}
+		var info CertificatesInfo
+		if err = json.Unmarshal(responseBody, &info); err != nil {
+			return nil, err
+		}
+		certificates := info.TransportCertificates
+		if layer == httpLayer {
+			certificates = info.HTTPCertificates
+		}
+		if len(certificates) == 0 || !isSerialNumberEqual(certificates[0].SerialNumber, serial) {
+			outdated = append(outdated, name)
+		}
+	}
+	return outdated, nil
+}
+
+// isSerialNumberEqual compares serial number returned by OpenSearch in decimal or hexadecimal form with the expected one
+func isSerialNumberEqual(value string, serial *big.Int) bool {
+	value = strings.ReplaceAll(strings.TrimSpace(value), ":", "")
+	for _, base := range []int{10, 16} {
+		if parsed, ok := new(big.Int).SetString(value, base); ok && parsed.Cmp(serial) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isCertificateRestartRequested returns true if stateful sets are already updated to restart nodes
+// with the certificate of the layer
+func (r OpenSearchReconciler) isCertificateRestartRequested(layer string, serial *big.Int) (bool, error) {
+	statefulSets, err := r.getStatefulSets()
+	if err != nil || len(statefulSets) == 0 {
+		return false, err
+	}
+	annotationKey := fmt.Sprintf(certificateSerialAnnotationKey, layer)
+	for _, statefulSet := range statefulSets {
+		if statefulSet.Spec.Template.Annotations[annotationKey] != serial.Text(16) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// requestCertificateRestart updates pod template of stateful sets with serial number of the certificate,
+// so that pods are restarted by the rolling update
+func (r OpenSearchReconciler) requestCertificateRestart(layer string, serial *big.Int) error {
+	statefulSets, err := r.getStatefulSets()
+	if err != nil {
+		return err
+	}
+	if len(statefulSets) == 0 {
+		return fmt.Errorf("there are no stateful sets to restart")
+	}
+	annotationKey := fmt.Sprintf(certificateSerialAnnotationKey, layer)
+	for _, statefulSet := range statefulSets {
+		if statefulSet.Spec.Template.Annotations == nil {
+			statefulSet.Spec.Template.Annotations = map[string]string{}
+		}
+		statefulSet.Spec.Template.Annotations[annotationKey] = serial.Text(16)
+		if err = r.reconciler.updateStatefulSet(statefulSet, r.logger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r OpenSearchReconciler) updateTLSReloadCondition(conditionType string, reason string, message string) error {
+	conditionStatus := statusFalse
+	if conditionType == typeSuccessful {
+		conditionStatus = statusTrue
+	}
+	return r.reconciler.updateConditions(r.cr, NewCondition(conditionStatus, conditionType, reason, message))
+}
+
+// findInstancesForTLSSecret returns requests to reconcile custom resources which reload certificates from the secret
+func (r *OpenSearchServiceReconciler) findInstancesForTLSSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	instances := &opensearchservice.OpenSearchServiceList{}
+	if err := r.Client.List(ctx, instances, client.InNamespace(secret.GetNamespace())); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, instance := range instances.Items {
+		if instance.Spec.OpenSearch == nil || instance.Spec.OpenSearch.TLSReload == nil {
+			continue
+		}
+		tlsReload := instance.Spec.OpenSearch.TLSReload
+		if secret.GetName() == tlsReload.TransportSecretName || secret.GetName() == tlsReload.HTTPSecretName {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace},
+			})
+		}
+	}
+	return requests
+}
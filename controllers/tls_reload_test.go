@@ -0,0 +1,45 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSerialNumberEqual(t *testing.T) {
+	serial, _ := new(big.Int).SetString("1a2b3c4d5e6f", 16)
+	tests := []struct {
+		name     string
+		value    string
+		expected bool
+	}{
+		{name: "hexadecimal", value: "1a2b3c4d5e6f", expected: true},
+		{name: "hexadecimal in upper case", value: "1A2B3C4D5E6F", expected: true},
+		{name: "hexadecimal with colons", value: "1a:2b:3c:4d:5e:6f", expected: true},
+		{name: "decimal", value: serial.String(), expected: true},
+		{name: "surrounded by spaces", value: " 1a2b3c4d5e6f\n", expected: true},
+		{name: "another serial", value: "1a2b3c4d5e70", expected: false},
+		{name: "empty value", value: "", expected: false},
+		{name: "invalid value", value: "serial", expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, isSerialNumberEqual(test.value, serial))
+		})
+	}
+}
@@ -19,8 +19,10 @@ import (
 	"fmt"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/types"
 	"net/http"
 	"os"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -40,14 +42,28 @@ var (
 
 type ServerContext struct {
 	replicationChecker ReplicationChecker
+	client             client.Client
+	resourceName       types.NamespacedName
+	authenticator      Authenticator
+}
+
+// NewServerContext returns context of the server which checks replication and switches disaster recovery mode
+// of the specified custom resource. Site manager endpoints are not protected if authenticator is nil.
+func NewServerContext(replicationChecker ReplicationChecker, client client.Client, resourceName types.NamespacedName,
+	authenticator Authenticator) ServerContext {
+	return ServerContext{
+		replicationChecker: replicationChecker,
+		client:             client,
+		resourceName:       resourceName,
+		authenticator:      authenticator,
+	}
 }
 
 type ClusterState struct {
 	Status string `json:"status"`
 }
 
-func StartServer(replicationChecker ReplicationChecker) error {
-	serverContext := ServerContext{replicationChecker: replicationChecker}
+func StartServer(serverContext ServerContext) error {
 	server := &http.Server{
 		Addr:    ":8069",
 		Handler: ServerHandlers(serverContext),
@@ -58,6 +74,8 @@ func StartServer(replicationChecker ReplicationChecker) error {
 func ServerHandlers(serverContext ServerContext) http.Handler {
 	r := mux.NewRouter()
 	r.Handle("/healthz", http.HandlerFunc(serverContext.GetClusterHealthStatus())).Methods("GET")
+	r.Handle("/sitemanager", serverContext.authenticated(serverContext.GetSiteManagerStatus())).Methods("GET")
+	r.Handle("/sitemanager", serverContext.authenticated(serverContext.SwitchSiteManagerMode())).Methods("POST")
 	return JsonContentType(handlers.CompressHandler(r))
 }
 
@@ -81,7 +99,7 @@ func (serverContext ServerContext) GetClusterHealthStatus() func(w http.Response
 			sendFailedHealthResponse(w)
 			return
 		}
-		status, err := serverContext.replicationChecker.CheckReplication()
+		status, err := serverContext.replicationChecker.CheckReplication(r.Context())
 		if err != nil {
 			sendFailedHealthResponse(w)
 			return
@@ -15,6 +15,7 @@
 package disasterrecovery
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
@@ -81,8 +82,9 @@ type ReplicationChecker struct {
 	restClient util.RestClient
 }
 
-func (rc ReplicationChecker) CheckReplication() (string, error) {
-	statusCode, responseBody, err := rc.restClient.SendRequest(http.MethodGet, "_plugins/_replication/autofollow_stats", nil)
+// CheckReplication returns the state of replication, the context limits the time of requests to OpenSearch
+func (rc ReplicationChecker) CheckReplication(ctx context.Context) (string, error) {
+	statusCode, responseBody, err := rc.restClient.SendRequestWithContext(ctx, http.MethodGet, "_plugins/_replication/autofollow_stats", nil)
 	if err != nil {
 		log.Error(err, "An error occurred during autofollow_stats HTTP request")
 		return "", err
@@ -114,7 +116,7 @@ func (rc ReplicationChecker) CheckReplication() (string, error) {
 				}
 			}
 		}
-		unhealthyIndices, err := rc.listUnhealthyIndices(rule.Pattern)
+		unhealthyIndices, err := rc.listUnhealthyIndices(ctx, rule.Pattern)
 		if err != nil {
 			return "", err
 		}
@@ -122,7 +124,7 @@ func (rc ReplicationChecker) CheckReplication() (string, error) {
 			log.Info(fmt.Sprintf("The following indices are not healthy: %v", unhealthyIndices))
 			return DEGRADED, nil
 		}
-		failedReplicationsFound, err := rc.areFailedReplicationsFound(rule.Pattern)
+		failedReplicationsFound, err := rc.areFailedReplicationsFound(ctx, rule.Pattern)
 		if err != nil {
 			return "", err
 		}
@@ -137,9 +139,9 @@ func (rc ReplicationChecker) CheckReplication() (string, error) {
 	return DOWN, nil
 }
 
-func (rc ReplicationChecker) listUnhealthyIndices(pattern string) ([]string, error) {
+func (rc ReplicationChecker) listUnhealthyIndices(ctx context.Context, pattern string) ([]string, error) {
 	var indices []string
-	responseBody, err := rc.restClient.SendRequestWithStatusCodeCheck(http.MethodGet, catIndicesPath, nil)
+	responseBody, err := rc.restClient.SendRequestWithStatusCodeCheckWithContext(ctx, http.MethodGet, catIndicesPath, nil)
 	if err != nil {
 		log.Error(err, "An error occurred during getting OpenSearch indices")
 		return indices, err
@@ -159,8 +161,8 @@ func (rc ReplicationChecker) listUnhealthyIndices(pattern string) ([]string, err
 	return indices, nil
 }
 
-func (rc ReplicationChecker) areFailedReplicationsFound(pattern string) (bool, error) {
-	responseBody, err := rc.restClient.SendRequestWithStatusCodeCheck(http.MethodGet, pattern, nil)
+func (rc ReplicationChecker) areFailedReplicationsFound(ctx context.Context, pattern string) (bool, error) {
+	responseBody, err := rc.restClient.SendRequestWithStatusCodeCheckWithContext(ctx, http.MethodGet, pattern, nil)
 	if err != nil {
 		log.Error(err, "An error occurred during getting OpenSearch indices")
 		return true, err
@@ -175,7 +177,7 @@ func (rc ReplicationChecker) areFailedReplicationsFound(pattern string) (bool, e
 		if strings.HasPrefix(index, ".") {
 			continue
 		}
-		replicationStatus, err := rc.getIndexReplicationStatus(index)
+		replicationStatus, err := rc.getIndexReplicationStatus(ctx, index)
 		if err != nil {
 			log.Error(err, fmt.Sprintf("Cannot get replication status of [%s] index", index))
 			return true, err
@@ -188,10 +190,10 @@ func (rc ReplicationChecker) areFailedReplicationsFound(pattern string) (bool, e
 	return false, nil
 }
 
-func (rc ReplicationChecker) getIndexReplicationStatus(indexName string) (IndexReplicationStatus, error) {
+func (rc ReplicationChecker) getIndexReplicationStatus(ctx context.Context, indexName string) (IndexReplicationStatus, error) {
 	var indexReplicationStatus IndexReplicationStatus
 	path := fmt.Sprintf(indexReplicationStatusPattern, indexName)
-	_, responseBody, err := rc.restClient.SendRequest(http.MethodGet, path, nil)
+	_, responseBody, err := rc.restClient.SendRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return indexReplicationStatus, err
 	}
@@ -0,0 +1,197 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disasterrecovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/strings/slices"
+	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"strings"
+	"time"
+)
+
+//+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+
+const queueStatus = "queue"
+
+var (
+	siteManagerModes                 = []string{"active", "standby", "disable"}
+	errDisasterRecoveryNotConfigured = errors.New("disaster recovery is not configured")
+)
+
+// SiteManagerRequest is the body of the switchover request sent by site manager
+type SiteManagerRequest struct {
+	Mode   string `json:"mode"`
+	NoWait bool   `json:"noWait,omitempty"`
+}
+
+// SiteManagerResponse describes the requested disaster recovery mode and the state of the switchover to it
+type SiteManagerResponse struct {
+	Mode    string `json:"mode,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Authenticator checks credentials of requests to site manager endpoints
+type Authenticator interface {
+	Authenticate(r *http.Request) error
+}
+
+// TokenReviewAuthenticator allows requests with bearer token of the site manager service account verified by Kubernetes
+type TokenReviewAuthenticator struct {
+	client    client.Client
+	username  string
+	audiences []string
+}
+
+func NewTokenReviewAuthenticator(client client.Client, namespace string, serviceAccountName string,
+	audience string) TokenReviewAuthenticator {
+	authenticator := TokenReviewAuthenticator{
+		client:   client,
+		username: fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccountName),
+	}
+	if audience != "" {
+		authenticator.audiences = []string{audience}
+	}
+	return authenticator
+}
+
+func (a TokenReviewAuthenticator) Authenticate(r *http.Request) error {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		return errors.New("bearer token is not provided")
+	}
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: a.audiences,
+		},
+	}
+	if err := a.client.Create(r.Context(), review); err != nil {
+		return fmt.Errorf("unable to review token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return fmt.Errorf("token is not authenticated: %s", review.Status.Error)
+	}
+	if review.Status.User.Username != a.username {
+		return fmt.Errorf("user [%s] is not allowed to manage disaster recovery", review.Status.User.Username)
+	}
+	return nil
+}
+
+// authenticated rejects requests which are not authenticated if authentication is enabled
+func (serverContext ServerContext) authenticated(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if serverContext.authenticator != nil {
+			if err := serverContext.authenticator.Authenticate(r); err != nil {
+				log.Error(err, "Site manager request is not authenticated")
+				sendResponse(w, http.StatusUnauthorized, SiteManagerResponse{Message: "unauthorized"})
+				return
+			}
+		}
+		handler(w, r)
+	}
+}
+
+// GetSiteManagerStatus returns the disaster recovery mode and the status of the switchover from the custom resource
+func (serverContext ServerContext) GetSiteManagerStatus() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cr, err := serverContext.getOpenSearchService(r.Context())
+		if err != nil {
+			sendErrorResponse(w, err)
+			return
+		}
+		drStatus := cr.Status.DisasterRecoveryStatus
+		response := SiteManagerResponse{
+			Mode:    cr.Spec.DisasterRecovery.Mode,
+			Status:  drStatus.Status,
+			Message: drStatus.Message,
+		}
+		// The switchover to the requested mode is not started by the operator yet
+		if !strings.EqualFold(drStatus.Mode, cr.Spec.DisasterRecovery.Mode) {
+			response.Status = queueStatus
+			response.Message = ""
+		}
+		sendSuccessfulResponse(w, response)
+	}
+}
+
+// SwitchSiteManagerMode requests the switchover to specified mode in the custom resource and returns immediately,
+// the progress of the switchover is available with GetSiteManagerStatus
+func (serverContext ServerContext) SwitchSiteManagerMode() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var request SiteManagerRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			sendResponse(w, http.StatusBadRequest, SiteManagerResponse{Message: fmt.Sprintf("invalid request body: %v", err)})
+			return
+		}
+		mode := strings.ToLower(request.Mode)
+		if !slices.Contains(siteManagerModes, mode) {
+			sendResponse(w, http.StatusBadRequest, SiteManagerResponse{
+				Message: fmt.Sprintf("mode must be in the list of values %v, but [%s] is given", siteManagerModes, request.Mode),
+			})
+			return
+		}
+		cr, err := serverContext.getOpenSearchService(r.Context())
+		if err != nil {
+			sendErrorResponse(w, err)
+			return
+		}
+		patch := client.MergeFrom(cr.DeepCopy())
+		cr.Spec.DisasterRecovery.Mode = mode
+		cr.Spec.DisasterRecovery.NoWait = request.NoWait
+		// The annotation triggers the switchover even if the mode is not changed, for example, to retry failed one
+		annotations := cr.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[util.SwitchoverAnnotationKey] = time.Now().Format(time.RFC3339Nano)
+		cr.SetAnnotations(annotations)
+		if err = serverContext.client.Patch(r.Context(), cr, patch); err != nil {
+			sendErrorResponse(w, err)
+			return
+		}
+		log.Info(fmt.Sprintf("Switchover to %s mode with no-wait: %t is requested", mode, request.NoWait))
+		sendSuccessfulResponse(w, SiteManagerResponse{Mode: mode, Status: queueStatus})
+	}
+}
+
+func (serverContext ServerContext) getOpenSearchService(ctx context.Context) (*opensearchservice.OpenSearchService, error) {
+	cr := &opensearchservice.OpenSearchService{}
+	if err := serverContext.client.Get(ctx, serverContext.resourceName, cr); err != nil {
+		return nil, err
+	}
+	if cr.Spec.DisasterRecovery == nil {
+		return nil, errDisasterRecoveryNotConfigured
+	}
+	return cr, nil
+}
+
+func sendErrorResponse(w http.ResponseWriter, err error) {
+	log.Error(err, "Unable to process site manager request")
+	statusCode := http.StatusInternalServerError
+	if apierrors.IsNotFound(err) || errors.Is(err, errDisasterRecoveryNotConfigured) {
+		statusCode = http.StatusNotFound
+	}
+	sendResponse(w, statusCode, SiteManagerResponse{Message: err.Error()})
+}
@@ -0,0 +1,209 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disasterrecovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	opensearchservice "github.com/Netcracker/opensearch-service/api/v1"
+	"github.com/Netcracker/opensearch-service/util"
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var resourceName = types.NamespacedName{Namespace: "opensearch-service", Name: "opensearch"}
+
+type authenticatorFunc func(r *http.Request) error
+
+func (f authenticatorFunc) Authenticate(r *http.Request) error {
+	return f(r)
+}
+
+func newClientBuilder(objects ...client.Object) *fake.ClientBuilder {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = opensearchservice.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...)
+}
+
+func newOpenSearchService(disasterRecovery *opensearchservice.DisasterRecovery,
+	status opensearchservice.DisasterRecoveryStatus) *opensearchservice.OpenSearchService {
+	return &opensearchservice.OpenSearchService{
+		ObjectMeta: metav1.ObjectMeta{Name: resourceName.Name, Namespace: resourceName.Namespace},
+		Spec:       opensearchservice.OpenSearchServiceSpec{DisasterRecovery: disasterRecovery},
+		Status:     opensearchservice.OpenSearchServiceStatus{DisasterRecoveryStatus: status},
+	}
+}
+
+func sendSiteManagerRequest(serverContext ServerContext, method string, body string) (int, SiteManagerResponse) {
+	request := httptest.NewRequest(method, "/sitemanager", strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	ServerHandlers(serverContext).ServeHTTP(recorder, request)
+	var response SiteManagerResponse
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+	return recorder.Code, response
+}
+
+func TestGetSiteManagerStatus(t *testing.T) {
+	tests := []struct {
+		name               string
+		objects            []client.Object
+		expectedStatusCode int
+		expected           SiteManagerResponse
+	}{
+		{name: "switchover is finished",
+			objects: []client.Object{newOpenSearchService(&opensearchservice.DisasterRecovery{Mode: "standby"},
+				opensearchservice.DisasterRecoveryStatus{Mode: "standby", Status: "done", Message: "Switchover is finished"})},
+			expectedStatusCode: http.StatusOK,
+			expected:           SiteManagerResponse{Mode: "standby", Status: "done", Message: "Switchover is finished"}},
+		{name: "switchover is not started yet",
+			objects: []client.Object{newOpenSearchService(&opensearchservice.DisasterRecovery{Mode: "active"},
+				opensearchservice.DisasterRecoveryStatus{Mode: "standby", Status: "done", Message: "Switchover is finished"})},
+			expectedStatusCode: http.StatusOK,
+			expected:           SiteManagerResponse{Mode: "active", Status: queueStatus}},
+		{name: "disaster recovery is not configured",
+			objects:            []client.Object{newOpenSearchService(nil, opensearchservice.DisasterRecoveryStatus{})},
+			expectedStatusCode: http.StatusNotFound,
+			expected:           SiteManagerResponse{Message: errDisasterRecoveryNotConfigured.Error()}},
+		{name: "custom resource is not found", expectedStatusCode: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			serverContext := NewServerContext(ReplicationChecker{}, newClientBuilder(test.objects...).Build(), resourceName, nil)
+			statusCode, response := sendSiteManagerRequest(serverContext, http.MethodGet, "")
+			assert.Equal(t, test.expectedStatusCode, statusCode)
+			if test.expected != (SiteManagerResponse{}) {
+				assert.Equal(t, test.expected, response)
+			}
+		})
+	}
+}
+
+func TestSwitchSiteManagerMode(t *testing.T) {
+	tests := []struct {
+		name               string
+		disasterRecovery   *opensearchservice.DisasterRecovery
+		body               string
+		expectedStatusCode int
+		expectedMode       string
+		expectedNoWait     bool
+	}{
+		{name: "switchover is requested", disasterRecovery: &opensearchservice.DisasterRecovery{Mode: "standby"},
+			body: `{"mode":"Active","noWait":true}`, expectedStatusCode: http.StatusOK, expectedMode: "active",
+			expectedNoWait: true},
+		{name: "switchover to the same mode is requested again",
+			disasterRecovery: &opensearchservice.DisasterRecovery{Mode: "active", NoWait: true},
+			body:             `{"mode":"active"}`, expectedStatusCode: http.StatusOK, expectedMode: "active"},
+		{name: "unknown mode", disasterRecovery: &opensearchservice.DisasterRecovery{Mode: "standby"},
+			body: `{"mode":"passive"}`, expectedStatusCode: http.StatusBadRequest, expectedMode: "standby"},
+		{name: "invalid body", disasterRecovery: &opensearchservice.DisasterRecovery{Mode: "standby"},
+			body: `mode=active`, expectedStatusCode: http.StatusBadRequest, expectedMode: "standby"},
+		{name: "disaster recovery is not configured", body: `{"mode":"active"}`,
+			expectedStatusCode: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cr := newOpenSearchService(test.disasterRecovery, opensearchservice.DisasterRecoveryStatus{})
+			k8sClient := newClientBuilder(cr).Build()
+			serverContext := NewServerContext(ReplicationChecker{}, k8sClient, resourceName, nil)
+
+			statusCode, response := sendSiteManagerRequest(serverContext, http.MethodPost, test.body)
+			assert.Equal(t, test.expectedStatusCode, statusCode)
+
+			updated := &opensearchservice.OpenSearchService{}
+			assert.NoError(t, k8sClient.Get(context.TODO(), resourceName, updated))
+			_, annotated := updated.Annotations[util.SwitchoverAnnotationKey]
+			assert.Equal(t, test.expectedStatusCode == http.StatusOK, annotated)
+			if test.expectedStatusCode == http.StatusOK {
+				assert.Equal(t, SiteManagerResponse{Mode: test.expectedMode, Status: queueStatus}, response)
+			}
+			if test.disasterRecovery != nil {
+				assert.Equal(t, test.expectedMode, updated.Spec.DisasterRecovery.Mode)
+				assert.Equal(t, test.expectedNoWait, updated.Spec.DisasterRecovery.NoWait)
+			}
+		})
+	}
+}
+
+func TestSiteManagerAuthentication(t *testing.T) {
+	cr := newOpenSearchService(&opensearchservice.DisasterRecovery{Mode: "standby"},
+		opensearchservice.DisasterRecoveryStatus{Mode: "standby", Status: "done"})
+	rejecting := authenticatorFunc(func(r *http.Request) error {
+		return errors.New("token is not authenticated")
+	})
+	serverContext := NewServerContext(ReplicationChecker{}, newClientBuilder(cr).Build(), resourceName, rejecting)
+
+	statusCode, response := sendSiteManagerRequest(serverContext, http.MethodGet, "")
+	assert.Equal(t, http.StatusUnauthorized, statusCode)
+	assert.Equal(t, SiteManagerResponse{Message: "unauthorized"}, response)
+	statusCode, _ = sendSiteManagerRequest(serverContext, http.MethodPost, `{"mode":"active"}`)
+	assert.Equal(t, http.StatusUnauthorized, statusCode)
+}
+
+func TestTokenReviewAuthenticator(t *testing.T) {
+	const siteManagerUser = "system:serviceaccount:site-manager:sm-auth-sa"
+	tests := []struct {
+		name          string
+		header        string
+		authenticated bool
+		username      string
+		expectedError bool
+	}{
+		{name: "site manager token", header: "Bearer token", authenticated: true, username: siteManagerUser},
+		{name: "token of another service account", header: "Bearer token", authenticated: true,
+			username: "system:serviceaccount:site-manager:default", expectedError: true},
+		{name: "token is not authenticated", header: "Bearer token", expectedError: true},
+		{name: "token is not provided", expectedError: true},
+		{name: "basic authentication", header: "Basic dXNlcjpwYXNzd29yZA==", expectedError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var reviewed *authenticationv1.TokenReview
+			k8sClient := newClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+					reviewed = obj.(*authenticationv1.TokenReview)
+					reviewed.Status.Authenticated = test.authenticated
+					reviewed.Status.User.Username = test.username
+					return nil
+				},
+			}).Build()
+			authenticator := NewTokenReviewAuthenticator(k8sClient, "site-manager", "sm-auth-sa", "sm-services")
+			request := httptest.NewRequest(http.MethodGet, "/sitemanager", nil)
+			if test.header != "" {
+				request.Header.Set("Authorization", test.header)
+			}
+
+			err := authenticator.Authenticate(request)
+			assert.Equal(t, test.expectedError, err != nil, err)
+			if test.header == "Bearer token" {
+				assert.Equal(t, "token", reviewed.Spec.Token)
+				assert.Equal(t, []string{"sm-services"}, reviewed.Spec.Audiences)
+			}
+		})
+	}
+}
@@ -15,10 +15,11 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
-	"sync"
+	"path/filepath"
 
 	"github.com/Netcracker/opensearch-service/disasterrecovery"
 
@@ -26,14 +27,18 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	qubershiporgv1 "github.com/Netcracker/opensearch-service/api/v1"
+	qubershiporgv2 "github.com/Netcracker/opensearch-service/api/v2"
 	"github.com/Netcracker/opensearch-service/controllers"
 	//+kubebuilder:scaffold:imports
 )
@@ -43,6 +48,16 @@ const (
 	opensearchNameEnvVar     = "OPENSEARCH_NAME"
 	opensearchUsernameEnvVar = "OPENSEARCH_USERNAME"
 	opensearchPasswordEnvVar = "OPENSEARCH_PASSWORD"
+	enableWebhooksEnvVar     = "ENABLE_WEBHOOKS"
+	webhookServiceEnvVar     = "WEBHOOK_SERVICE_NAME"
+	// Site manager endpoints of disaster recovery server require the token of specified service account if it is set
+	siteManagerNamespaceEnvVar      = "SITE_MANAGER_NAMESPACE"
+	siteManagerServiceAccountEnvVar = "SITE_MANAGER_SERVICE_ACCOUNT_NAME"
+	siteManagerAudienceEnvVar       = "SITE_MANAGER_CUSTOM_AUDIENCE"
+
+	crdName               = "opensearchservices.qubership.org"
+	webhookCertDir        = "/tmp/k8s-webhook-server/serving-certs"
+	conversionWebhookPath = "/convert"
 )
 
 var (
@@ -54,6 +69,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(qubershiporgv1.AddToScheme(scheme))
+	utilruntime.Must(qubershiporgv2.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -95,18 +111,57 @@ func main() {
 		os.Exit(1)
 	}
 
-	var mutex sync.Mutex
-	var mutexTwo sync.Mutex
+	instances, err := controllers.NewInstanceStates(mgr)
+	if err != nil {
+		setupLog.Error(err, "unable to set up background watchers")
+		os.Exit(1)
+	}
 	if err = (&controllers.OpenSearchServiceReconciler{
-		Client:                mgr.GetClient(),
-		Scheme:                mgr.GetScheme(),
-		ResourceHashes:        map[string]string{},
-		ReplicationWatcher:    controllers.NewReplicationWatcher(&mutex),
-		SlowLogIndicesWatcher: controllers.NewSlowLogIndicesWatcher(&mutexTwo),
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Instances: instances,
+		Recorder:  mgr.GetEventRecorderFor("opensearch-service-operator"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OpenSearchService")
 		os.Exit(1)
 	}
+	if err = (&controllers.OpenSearchUserReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpenSearchUser")
+		os.Exit(1)
+	}
+	if err = (&controllers.OpenSearchRoleReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpenSearchRole")
+		os.Exit(1)
+	}
+	if err = (&controllers.OpenSearchRoleMappingReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpenSearchRoleMapping")
+		os.Exit(1)
+	}
+	if os.Getenv(enableWebhooksEnvVar) == "true" {
+		if err = (&qubershiporgv1.OpenSearchService{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OpenSearchService")
+			os.Exit(1)
+		}
+		if err = (&qubershiporgv2.OpenSearchService{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OpenSearchService")
+			os.Exit(1)
+		}
+		if serviceName := os.Getenv(webhookServiceEnvVar); serviceName != "" {
+			if err = configureConversionWebhook(serviceName, namespace); err != nil {
+				setupLog.Error(err, "unable to configure conversion webhook", "crd", crdName)
+				os.Exit(1)
+			}
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -117,6 +172,10 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if err := mgr.AddReadyzCheck("watchers", instances.CheckWatchers); err != nil {
+		setupLog.Error(err, "unable to set up watchers check")
+		os.Exit(1)
+	}
 
 	opensearchName := os.Getenv(opensearchNameEnvVar)
 	if opensearchName == "" {
@@ -127,10 +186,17 @@ func main() {
 	opensearchUsername := os.Getenv(opensearchUsernameEnvVar)
 	opensearchPassword := os.Getenv(opensearchPasswordEnvVar)
 	replicationChecker := disasterrecovery.NewReplicationChecker(opensearchName, opensearchProtocol, opensearchUsername, opensearchPassword)
+	var authenticator disasterrecovery.Authenticator
+	if serviceAccountName := os.Getenv(siteManagerServiceAccountEnvVar); serviceAccountName != "" {
+		authenticator = disasterrecovery.NewTokenReviewAuthenticator(mgr.GetClient(),
+			os.Getenv(siteManagerNamespaceEnvVar), serviceAccountName, os.Getenv(siteManagerAudienceEnvVar))
+	}
+	serverContext := disasterrecovery.NewServerContext(replicationChecker, mgr.GetClient(),
+		types.NamespacedName{Name: opensearchName, Namespace: namespace}, authenticator)
 
 	setupLog.Info("Starting disaster recovery REST server.")
 	go func() {
-		if err = disasterrecovery.StartServer(replicationChecker); err != nil {
+		if err = disasterrecovery.StartServer(serverContext); err != nil {
 			setupLog.Error(err, "Disaster recovery REST server cannot be created because of error")
 			os.Exit(1)
 		}
@@ -156,3 +222,41 @@ func getWatchNamespace() (string, error) {
 	}
 	return ns, nil
 }
+
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;update
+
+// configureConversionWebhook points conversion of OpenSearchService custom resource definition to the operator webhook service.
+// It is necessary when the definition is installed without kustomize patches, for example from Helm chart.
+func configureConversionWebhook(serviceName string, namespace string) error {
+	caBundle, err := os.ReadFile(filepath.Join(webhookCertDir, "ca.crt"))
+	if err != nil {
+		return err
+	}
+	crdScheme := runtime.NewScheme()
+	utilruntime.Must(apiextensionsv1.AddToScheme(crdScheme))
+	k8sClient, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: crdScheme})
+	if err != nil {
+		return err
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err = k8sClient.Get(context.TODO(), types.NamespacedName{Name: crdName}, crd); err != nil {
+		return err
+	}
+	path := conversionWebhookPath
+	crd.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{
+		Strategy: apiextensionsv1.WebhookConverter,
+		Webhook: &apiextensionsv1.WebhookConversion{
+			ClientConfig: &apiextensionsv1.WebhookClientConfig{
+				Service: &apiextensionsv1.ServiceReference{
+					Namespace: namespace,
+					Name:      serviceName,
+					Path:      &path,
+				},
+				CABundle: caBundle,
+			},
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+	setupLog.Info(fmt.Sprintf("Configuring conversion webhook for %s with service %s/%s", crdName, namespace, serviceName))
+	return k8sClient.Update(context.TODO(), crd)
+}
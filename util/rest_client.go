@@ -15,10 +15,16 @@
 package util
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sync/atomic"
+	"time"
 )
 
 type Credentials struct {
@@ -33,27 +39,258 @@ func NewCredentials(username, password string) Credentials {
 	}
 }
 
+// RetryPolicy describes how requests failed with connection errors, 429 or 5xx status codes are retried.
+// Only idempotent requests are retried unless RetryNonIdempotent is set.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt, zero disables retries
+	MaxRetries int
+	// WaitMin and WaitMax limit the exponential backoff between attempts
+	WaitMin time.Duration
+	WaitMax time.Duration
+	// RetryNonIdempotent allows to retry POST and PATCH requests which are safe to repeat
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is used by clients created without WithRetryPolicy option
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 2,
+	WaitMin:    time.Second,
+	WaitMax:    5 * time.Second,
+}
+
+// DefaultRequestTimeout limits each attempt of clients created without WithRequestTimeout option
+const DefaultRequestTimeout = 30 * time.Second
+
+// backoff returns the time to wait before the retry with specified number starting from zero
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	wait := p.WaitMin
+	for i := 0; i < retry && wait < p.WaitMax; i++ {
+		wait *= 2
+	}
+	if p.WaitMax > 0 && wait > p.WaitMax {
+		return p.WaitMax
+	}
+	return wait
+}
+
+// OpenSearchError is returned when OpenSearch responds with error status code.
+// Type and Reason are filled from the error in the response body if OpenSearch provides it.
+type OpenSearchError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Type       string
+	Reason     string
+	Body       []byte
+}
+
+func (e *OpenSearchError) Error() string {
+	return fmt.Sprintf("%s request to %s returned [%d] status code: %s", e.Method, e.URL, e.StatusCode, e.Body)
+}
+
+func newOpenSearchError(method string, url string, statusCode int, body []byte) *OpenSearchError {
+	openSearchError := &OpenSearchError{
+		Method:     method,
+		URL:        url,
+		StatusCode: statusCode,
+		Body:       body,
+	}
+	var response struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil || len(response.Error) == 0 {
+		return openSearchError
+	}
+	var cause struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(response.Error, &cause); err == nil {
+		openSearchError.Type = cause.Type
+		openSearchError.Reason = cause.Reason
+	} else {
+		// some plugins return error as a plain string
+		_ = json.Unmarshal(response.Error, &openSearchError.Reason)
+	}
+	return openSearchError
+}
+
+// IsOpenSearchErrorType checks if the error is returned by OpenSearch with specified error type,
+// for example "index_not_found_exception"
+func IsOpenSearchErrorType(err error, errorType string) bool {
+	var openSearchError *OpenSearchError
+	return errors.As(err, &openSearchError) && openSearchError.Type == errorType
+}
+
+// IsNotFound checks if the error is returned by OpenSearch with 404 status code
+func IsNotFound(err error) bool {
+	var openSearchError *OpenSearchError
+	return errors.As(err, &openSearchError) && openSearchError.StatusCode == http.StatusNotFound
+}
+
+type RestClientOption func(*RestClient)
+
+// WithFailoverURLs adds URLs the client switches to when the current URL is unavailable
+func WithFailoverURLs(urls ...string) RestClientOption {
+	return func(rc *RestClient) {
+		rc.urls = append(rc.urls, urls...)
+	}
+}
+
+// WithRetryPolicy overrides DefaultRetryPolicy of the client
+func WithRetryPolicy(retryPolicy RetryPolicy) RestClientOption {
+	return func(rc *RestClient) {
+		rc.retryPolicy = retryPolicy
+	}
+}
+
+// WithRequestTimeout overrides DefaultRequestTimeout of the client, zero leaves only the timeout of HTTP client
+func WithRequestTimeout(timeout time.Duration) RestClientOption {
+	return func(rc *RestClient) {
+		rc.requestTimeout = timeout
+	}
+}
+
+// WithClientCertificates configures the client to authenticate by specified TLS certificates.
+// The transport of HTTP client is replaced with a copy if it is not http.Transport.
+func WithClientCertificates(certificates ...tls.Certificate) RestClientOption {
+	return func(rc *RestClient) {
+		rc.certificates = append(rc.certificates, certificates...)
+	}
+}
+
 type RestClient struct {
-	url         string
-	httpClient  http.Client
-	credentials Credentials
+	urls []string
+	// active is the index of the URL requests are sent to, it is shared by copies of the client
+	active         *atomic.Int32
+	httpClient     http.Client
+	credentials    Credentials
+	retryPolicy    RetryPolicy
+	requestTimeout time.Duration
+	certificates   []tls.Certificate
 }
 
-func NewRestClient(url string, httpClient http.Client, credentials Credentials) *RestClient {
-	return &RestClient{
-		url:         url,
-		httpClient:  httpClient,
-		credentials: credentials,
+func NewRestClient(url string, httpClient http.Client, credentials Credentials, options ...RestClientOption) *RestClient {
+	restClient := &RestClient{
+		urls:           []string{url},
+		active:         &atomic.Int32{},
+		httpClient:     httpClient,
+		credentials:    credentials,
+		retryPolicy:    DefaultRetryPolicy,
+		requestTimeout: DefaultRequestTimeout,
+	}
+	for _, option := range options {
+		option(restClient)
 	}
+	if len(restClient.certificates) > 0 {
+		restClient.httpClient.Transport = withCertificates(restClient.httpClient.Transport, restClient.certificates)
+	}
+	return restClient
+}
+
+// WithURL returns the client with the same credentials which sends requests to another URL
+func (rc RestClient) WithURL(url string, httpClient http.Client) *RestClient {
+	return NewRestClient(url, httpClient, rc.credentials,
+		WithRetryPolicy(rc.retryPolicy),
+		WithRequestTimeout(rc.requestTimeout),
+		WithClientCertificates(rc.certificates...))
+}
+
+// WithCredentials returns copy of the client which uses another credentials
+func (rc RestClient) WithCredentials(credentials Credentials) *RestClient {
+	rc.credentials = credentials
+	return &rc
+}
+
+// WithRetryPolicy returns copy of the client which retries requests according to another policy
+func (rc RestClient) WithRetryPolicy(retryPolicy RetryPolicy) *RestClient {
+	rc.retryPolicy = retryPolicy
+	return &rc
+}
+
+// WithNonIdempotentRetries returns copy of the client which also retries POST and PATCH requests,
+// it must be used only for requests which are safe to repeat, for example flush or reload of settings
+func (rc RestClient) WithNonIdempotentRetries() *RestClient {
+	rc.retryPolicy.RetryNonIdempotent = true
+	return &rc
 }
 
 func (rc RestClient) SendRequest(method string, path string, body io.Reader) (int, []byte, error) {
-	return rc.SendBasicRequest(method, path, body, true)
+	return rc.SendBasicRequestWithContext(context.Background(), method, path, body, true)
+}
+
+func (rc RestClient) SendRequestWithContext(ctx context.Context, method string, path string, body io.Reader) (int, []byte, error) {
+	return rc.SendBasicRequestWithContext(ctx, method, path, body, true)
+}
+
+func (rc RestClient) SendBasicRequest(method string, path string, body io.Reader, useHeaders bool) (int, []byte, error) {
+	return rc.SendBasicRequestWithContext(context.Background(), method, path, body, useHeaders)
+}
+
+// SendBasicRequestWithContext sends request to the current URL and retries it according to the retry policy.
+// The client switches to the next URL on connection errors and 5xx status codes.
+// Requests are bounded by the context and each attempt is bounded by the request timeout of the client.
+// The last status code and response body are returned when all attempts are failed.
+func (rc RestClient) SendBasicRequestWithContext(ctx context.Context, method string, path string, body io.Reader,
+	useHeaders bool) (int, []byte, error) {
+	_, statusCode, responseBody, err := rc.sendWithRetries(ctx, method, path, body, useHeaders)
+	return statusCode, responseBody, err
+}
+
+// sendWithRetries sends request and returns the URL of the last attempt along with its result
+func (rc RestClient) sendWithRetries(ctx context.Context, method string, path string, body io.Reader,
+	useHeaders bool) (url string, statusCode int, responseBody []byte, err error) {
+	var payload []byte
+	if body != nil {
+		if payload, err = io.ReadAll(body); err != nil {
+			return
+		}
+	}
+	for retry := 0; ; retry++ {
+		index := int(rc.active.Load()) % len(rc.urls)
+		url = fmt.Sprintf("%s/%s", rc.urls[index], path)
+		var retryable bool
+		statusCode, responseBody, retryable, err = rc.send(ctx, url, method, payload, useHeaders)
+		if !retryable || ctx.Err() != nil {
+			return
+		}
+		if statusCode != http.StatusTooManyRequests && len(rc.urls) > 1 {
+			rc.active.CompareAndSwap(int32(index), int32((index+1)%len(rc.urls)))
+		}
+		if retry >= rc.retryPolicy.MaxRetries || !rc.canRetry(method) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return url, 0, nil, ctx.Err()
+		case <-time.After(rc.retryPolicy.backoff(retry)):
+		}
+	}
 }
 
-func (rc RestClient) SendBasicRequest(method string, path string, body io.Reader, useHeaders bool) (statusCode int, responseBody []byte, err error) {
-	requestUrl := fmt.Sprintf("%s/%s", rc.url, path)
-	request, err := http.NewRequest(method, requestUrl, body)
+// canRetry checks if the request with specified method can be sent again
+func (rc RestClient) canRetry(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return rc.retryPolicy.RetryNonIdempotent
+	}
+}
+
+// send makes one attempt to send request and reports whether it can be retried
+func (rc RestClient) send(ctx context.Context, url string, method string, payload []byte,
+	useHeaders bool) (statusCode int, responseBody []byte, retryable bool, err error) {
+	if rc.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, rc.requestTimeout)
+		defer cancel()
+	}
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return
 	}
@@ -66,21 +303,31 @@ func (rc RestClient) SendBasicRequest(method string, path string, body io.Reader
 	}
 	response, err := rc.httpClient.Do(request)
 	if err != nil {
-		return
+		return 0, nil, true, err
 	}
 	defer response.Body.Close()
 	statusCode = response.StatusCode
 	responseBody, err = io.ReadAll(response.Body)
+	retryable = err != nil || statusCode == http.StatusTooManyRequests ||
+		(statusCode >= 500 && statusCode != http.StatusNotImplemented)
 	return
 }
 
 func (rc RestClient) SendRequestWithStatusCodeCheck(method string, path string, body io.Reader) ([]byte, error) {
-	statusCode, responseBody, err := rc.SendRequest(method, path, body)
+	return rc.SendRequestWithStatusCodeCheckWithContext(context.Background(), method, path, body)
+}
+
+// SendRequestWithStatusCodeCheckWithContext sends request and returns OpenSearchError if status code is 4xx or 5xx
+func (rc RestClient) SendRequestWithStatusCodeCheckWithContext(ctx context.Context, method string, path string,
+	body io.Reader) ([]byte, error) {
+	url, statusCode, responseBody, err := rc.sendWithRetries(ctx, method, path, body, true)
+	if err != nil {
+		return responseBody, err
+	}
 	if statusCode >= 400 {
-		return responseBody, fmt.Errorf("%s request to %s/%s returned [%d] status code: %s", method, rc.url,
-			path, statusCode, responseBody)
+		return responseBody, newOpenSearchError(method, url, statusCode, responseBody)
 	}
-	return responseBody, err
+	return responseBody, nil
 }
 
 func (rc RestClient) GetArrayData(path, key string, filter func(string) bool) ([]string, error) {
@@ -102,3 +349,18 @@ func (rc RestClient) GetArrayData(path, key string, filter func(string) bool) ([
 	}
 	return arrayData, nil
 }
+
+// withCertificates returns copy of the transport with client certificates
+func withCertificates(roundTripper http.RoundTripper, certificates []tls.Certificate) http.RoundTripper {
+	transport, ok := roundTripper.(*http.Transport)
+	if ok {
+		transport = transport.Clone()
+	} else {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.Certificates = append(transport.TLSClientConfig.Certificates, certificates...)
+	return transport
+}
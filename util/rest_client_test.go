@@ -0,0 +1,152 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{WaitMin: time.Second, WaitMax: 5 * time.Second}
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		retry    int
+		expected time.Duration
+	}{
+		{name: "first retry", policy: policy, retry: 0, expected: time.Second},
+		{name: "second retry", policy: policy, retry: 1, expected: 2 * time.Second},
+		{name: "third retry", policy: policy, retry: 2, expected: 4 * time.Second},
+		{name: "limited by maximum", policy: policy, retry: 3, expected: 5 * time.Second},
+		{name: "large retry number", policy: policy, retry: 100, expected: 5 * time.Second},
+		{name: "constant without maximum", policy: RetryPolicy{WaitMin: time.Second}, retry: 3, expected: time.Second},
+		{name: "zero policy", policy: RetryPolicy{}, retry: 2, expected: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.policy.backoff(test.retry))
+		})
+	}
+}
+
+func TestSendWithRetriesFailover(t *testing.T) {
+	tests := []struct {
+		name               string
+		method             string
+		primaryStatus      int
+		retryNonIdempotent bool
+		expectedStatus     int
+		expectedPrimary    int32
+		expectedSecondary  int32
+		expectedActive     int32
+	}{
+		{name: "primary is available", method: http.MethodGet, primaryStatus: http.StatusOK,
+			expectedStatus: http.StatusOK, expectedPrimary: 1, expectedSecondary: 0, expectedActive: 0},
+		{name: "failover on server error", method: http.MethodGet, primaryStatus: http.StatusServiceUnavailable,
+			expectedStatus: http.StatusOK, expectedPrimary: 1, expectedSecondary: 1, expectedActive: 1},
+		{name: "no failover on client error", method: http.MethodGet, primaryStatus: http.StatusNotFound,
+			expectedStatus: http.StatusNotFound, expectedPrimary: 1, expectedSecondary: 0, expectedActive: 0},
+		{name: "no failover on not implemented", method: http.MethodGet, primaryStatus: http.StatusNotImplemented,
+			expectedStatus: http.StatusNotImplemented, expectedPrimary: 1, expectedSecondary: 0, expectedActive: 0},
+		{name: "retry on the same URL on too many requests", method: http.MethodGet,
+			primaryStatus: http.StatusTooManyRequests, expectedStatus: http.StatusTooManyRequests,
+			expectedPrimary: 3, expectedSecondary: 0, expectedActive: 0},
+		{name: "non-idempotent request is not retried", method: http.MethodPost,
+			primaryStatus: http.StatusServiceUnavailable, expectedStatus: http.StatusServiceUnavailable,
+			expectedPrimary: 1, expectedSecondary: 0, expectedActive: 1},
+		{name: "non-idempotent request is retried if allowed", method: http.MethodPost,
+			primaryStatus: http.StatusServiceUnavailable, retryNonIdempotent: true, expectedStatus: http.StatusOK,
+			expectedPrimary: 1, expectedSecondary: 1, expectedActive: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var primaryRequests, secondaryRequests atomic.Int32
+			primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				primaryRequests.Add(1)
+				w.WriteHeader(test.primaryStatus)
+			}))
+			defer primary.Close()
+			secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				secondaryRequests.Add(1)
+				_, _ = w.Write([]byte(`{"acknowledged":true}`))
+			}))
+			defer secondary.Close()
+
+			client := NewRestClient(primary.URL, http.Client{}, Credentials{},
+				WithFailoverURLs(secondary.URL),
+				WithRetryPolicy(RetryPolicy{
+					MaxRetries:         2,
+					WaitMin:            time.Millisecond,
+					WaitMax:            time.Millisecond,
+					RetryNonIdempotent: test.retryNonIdempotent,
+				}))
+			url, statusCode, _, err := client.sendWithRetries(context.Background(), test.method, "_cluster/health",
+				strings.NewReader(`{}`), true)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, statusCode)
+			assert.Equal(t, test.expectedPrimary, primaryRequests.Load())
+			assert.Equal(t, test.expectedSecondary, secondaryRequests.Load())
+			assert.Equal(t, test.expectedActive, client.active.Load())
+			assert.True(t, strings.HasSuffix(url, "/_cluster/health"))
+		})
+	}
+}
+
+func TestSendWithRetriesConnectionError(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+	unavailable := httptest.NewServer(http.NotFoundHandler())
+	unavailableURL := unavailable.URL
+	unavailable.Close()
+
+	client := NewRestClient(unavailableURL, http.Client{}, Credentials{}, WithFailoverURLs(server.URL),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 1, WaitMin: time.Millisecond}))
+	url, statusCode, _, err := client.sendWithRetries(context.Background(), http.MethodGet, "", nil, true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, server.URL+"/", url)
+	assert.Equal(t, int32(1), requests.Load())
+
+	// the next request is sent to the available URL at once
+	_, _, _, err = client.sendWithRetries(context.Background(), http.MethodGet, "", nil, true)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestSendWithRetriesRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := NewRestClient(server.URL, http.Client{}, Credentials{}, WithRequestTimeout(10*time.Millisecond),
+		WithRetryPolicy(RetryPolicy{}))
+	_, _, _, err := client.sendWithRetries(context.Background(), http.MethodGet, "", nil, true)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}